@@ -4,20 +4,30 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	v2config "github.com/wesleyorama2/lunge/internal/performance/v2/config"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/control"
 	"github.com/wesleyorama2/lunge/internal/performance/v2/engine"
 	"github.com/wesleyorama2/lunge/internal/performance/v2/executor"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/live"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/otlp"
 	"github.com/wesleyorama2/lunge/internal/performance/v2/output"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/prometheus"
 	"github.com/wesleyorama2/lunge/internal/performance/v2/report"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/stream"
 )
 
 var perfCmd = &cobra.Command{
@@ -40,7 +50,13 @@ Arrival rate mode:
     --executor constant-arrival-rate \
     --rate 100 \
     --duration 5m \
-    --max-vus 200`,
+    --max-vus 200
+
+Externally controlled mode (change the load while the test runs):
+  lunge perf --url https://api.example.com/health \
+    --executor externally-controlled \
+    --vus 5 --max-vus 100 \
+    --control-addr localhost:6565`,
 	Run: func(cmd *cobra.Command, args []string) {
 		runPerfTest(cmd, args)
 	},
@@ -64,13 +80,31 @@ func runPerfTest(cmd *cobra.Command, args []string) {
 	rate, _ := cmd.Flags().GetFloat64("rate")
 	maxVUs, _ := cmd.Flags().GetInt("max-vus")
 	preAllocatedVUs, _ := cmd.Flags().GetInt("pre-allocated-vus")
+	controlAddr, _ := cmd.Flags().GetString("control-addr")
+	liveAddr, _ := cmd.Flags().GetString("live-addr")
+	prometheusAddr, _ := cmd.Flags().GetString("prometheus-addr")
+	outSpecs, _ := cmd.Flags().GetStringArray("out")
+	otlpEndpoint, _ := cmd.Flags().GetString("otlp-endpoint")
+	otlpTraces, _ := cmd.Flags().GetBool("otlp-traces")
+	otlpHeaderFlags, _ := cmd.Flags().GetStringArray("otlp-header")
+
+	// Config variable flags
+	envFile, _ := cmd.Flags().GetString("env-file")
+	varFlags, _ := cmd.Flags().GetStringArray("var")
 
 	var testConfig *v2config.TestConfig
 	var err error
 
 	if configFile != "" {
 		// Load config from file
-		testConfig, err = v2config.LoadConfig(configFile)
+		var vars map[string]string
+		vars, err = parsePerfVars(envFile, varFlags)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading variables: %v\n", err)
+			os.Exit(1)
+		}
+
+		testConfig, err = v2config.LoadConfigWithVars(configFile, vars)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 			os.Exit(1)
@@ -88,6 +122,15 @@ func runPerfTest(cmd *cobra.Command, args []string) {
 		return
 	}
 
+	// Link request spans to server-side traces
+	if otlpTraces {
+		if otlpEndpoint == "" {
+			fmt.Fprintln(os.Stderr, "Error: --otlp-traces requires --otlp-endpoint")
+			os.Exit(1)
+		}
+		testConfig.Settings.Traceparent = true
+	}
+
 	// Calculate total duration from config
 	totalDuration := calculateTotalDuration(testConfig)
 
@@ -124,6 +167,93 @@ func runPerfTest(cmd *cobra.Command, args []string) {
 		fmt.Println()
 	}
 
+	// Servers run for the duration of the test and are closed before exiting
+	var servers []io.Closer
+
+	// Serve the control API for the duration of the test
+	if controlAddr != "" {
+		controlServer := control.NewServer(eng)
+		if err := controlServer.Start(controlAddr); err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting control API: %v\n", err)
+			os.Exit(1)
+		}
+		servers = append(servers, controlServer)
+		if control.BindsAllInterfaces(controlAddr) {
+			fmt.Fprintf(os.Stderr, "Warning: the control API has no authentication and %s accepts connections from any host\n", controlAddr)
+		}
+		if !quiet {
+			fmt.Printf("Control API listening on http://%s/v1\n", controlServer.Addr())
+		}
+	}
+
+	// Serve live metrics for the duration of the test
+	if liveAddr != "" {
+		liveServer := live.NewServer(eng, 0)
+		if err := liveServer.Start(liveAddr); err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting live metrics server: %v\n", err)
+			os.Exit(1)
+		}
+		servers = append(servers, liveServer)
+		if !quiet {
+			fmt.Printf("Live metrics on http://%s/v1/metrics (stream: /v1/events)\n", liveServer.Addr())
+		}
+	}
+
+	// Serve metrics for Prometheus to scrape for the duration of the test
+	if prometheusAddr != "" {
+		prometheusServer := prometheus.NewServer(eng)
+		if err := prometheusServer.Start(prometheusAddr); err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting Prometheus endpoint: %v\n", err)
+			os.Exit(1)
+		}
+		servers = append(servers, prometheusServer)
+		if !quiet {
+			fmt.Printf("Prometheus metrics on http://%s/metrics\n", prometheusServer.Addr())
+		}
+	}
+
+	// Stream samples and time buckets to external outputs as they are recorded
+	var outputs []*stream.Output
+	for _, spec := range outSpecs {
+		out, err := stream.New(spec, stream.DefaultConfig())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating output: %v\n", err)
+			os.Exit(1)
+		}
+		eng.AddListener(out)
+		outputs = append(outputs, out)
+		if !quiet {
+			fmt.Printf("Streaming metrics to %s\n", out)
+		}
+	}
+
+	// Export metrics, and optionally a span per request, over OTLP/HTTP
+	var otlpExporter *otlp.Exporter
+	if otlpEndpoint != "" {
+		otlpConfig, err := parseOTLPConfig(otlpEndpoint, otlpHeaderFlags)
+		if err == nil {
+			otlpExporter, err = otlp.NewExporter(eng, otlpConfig)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating OTLP exporter: %v\n", err)
+			os.Exit(1)
+		}
+		otlpExporter.Start()
+
+		if otlpTraces {
+			out, err := otlp.NewTraceOutput(otlpConfig, stream.DefaultConfig())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating OTLP trace output: %v\n", err)
+				os.Exit(1)
+			}
+			eng.AddListener(out)
+			outputs = append(outputs, out)
+		}
+		if !quiet {
+			fmt.Printf("Exporting OTLP to %s\n", otlpEndpoint)
+		}
+	}
+
 	// Print header
 	consoleOutput.PrintHeader()
 
@@ -143,6 +273,19 @@ func runPerfTest(cmd *cobra.Command, args []string) {
 		result, runErr = eng.Run(ctx)
 	}()
 
+	// Stop gracefully on Ctrl+C so partial results are still reported
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	interrupts := watchInterrupts(signals, func(reason string) {
+		eng.StopWithReason(context.Background(), reason)
+	}, func() {
+		os.Exit(exitPerfInterrupted)
+	})
+	defer func() {
+		signal.Stop(signals)
+		interrupts.Close()
+	}()
+
 	// Update progress while engine is running
 	updateTicker := time.NewTicker(time.Second)
 	defer updateTicker.Stop()
@@ -191,6 +334,14 @@ progressLoop:
 	// Wait for engine to complete
 	wg.Wait()
 
+	// Write what the outputs still buffer, and report what they lost
+	closeOutputs(outputs)
+	if otlpExporter != nil {
+		if err := otlpExporter.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: OTLP metrics export failed: %v\n", err)
+		}
+	}
+
 	if runErr != nil {
 		fmt.Fprintf(os.Stderr, "Error running test: %v\n", runErr)
 		// Continue to output results even on error
@@ -230,13 +381,173 @@ progressLoop:
 		outputJSONResult(result, jsonPath)
 	}
 
+	// Close the servers here rather than in a defer, which os.Exit would
+	// skip: live clients get the final end event on every outcome
+	closeServers(servers)
+
 	// Exit with error code if test failed
+	if code := perfExitCode(result, runErr, interrupts.Interrupted()); code != 0 {
+		os.Exit(code)
+	}
+}
+
+// closeServers shuts down the control, live and Prometheus servers.
+func closeServers(servers []io.Closer) {
+	for _, s := range servers {
+		if err := s.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: closing server: %v\n", err)
+		}
+	}
+}
+
+// closeOutputs flushes and closes the streaming outputs, warning about
+// samples that were dropped or could not be written.
+func closeOutputs(outputs []*stream.Output) {
+	for _, out := range outputs {
+		if err := out.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: closing output %s: %v\n", out, err)
+		}
+
+		stats := out.Stats()
+		if stats.Dropped > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: output %s dropped %d samples because it could not keep up\n", out, stats.Dropped)
+		}
+		if stats.Failed > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: output %s failed to write %d samples: %v\n", out, stats.Failed, stats.LastError)
+		}
+	}
+}
+
+// Exit codes of lunge perf.
+const (
+	exitPerfFailed         = 1   // Thresholds failed or the test could not run
+	exitPerfThresholdAbort = 99  // An abortOnFail threshold or an exhausted data source stopped the test early
+	exitPerfInterrupted    = 130 // The test was stopped by SIGINT or SIGTERM
+)
+
+// interruptWatcher stops a test gracefully on the first interrupt signal
+// and forces an exit on the second.
+type interruptWatcher struct {
+	interrupted atomic.Bool
+	done        chan struct{}
+	closeOnce   sync.Once
+}
+
+// watchInterrupts calls stop with a reason on the first signal received
+// and forceExit on the second. stop runs in its own goroutine, since a
+// graceful stop waits for in-flight iterations.
+func watchInterrupts(signals <-chan os.Signal, stop func(reason string), forceExit func()) *interruptWatcher {
+	w := &interruptWatcher{done: make(chan struct{})}
+
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				if w.interrupted.CompareAndSwap(false, true) {
+					fmt.Fprintf(os.Stderr, "\nReceived %s, stopping gracefully (press Ctrl+C again to force exit)...\n", signalName(sig))
+					go stop(fmt.Sprintf("interrupted by %s", signalName(sig)))
+					continue
+				}
+				fmt.Fprintf(os.Stderr, "\nReceived %s again, exiting immediately\n", signalName(sig))
+				forceExit()
+				return
+			case <-w.done:
+				return
+			}
+		}
+	}()
+
+	return w
+}
+
+// Interrupted reports whether an interrupt signal was received.
+func (w *interruptWatcher) Interrupted() bool {
+	return w.interrupted.Load()
+}
+
+// Close stops watching for signals.
+func (w *interruptWatcher) Close() {
+	w.closeOnce.Do(func() {
+		close(w.done)
+	})
+}
+
+// signalName returns the conventional name of an interrupt signal.
+func signalName(sig os.Signal) string {
+	switch sig {
+	case os.Interrupt:
+		return "SIGINT"
+	case syscall.SIGTERM:
+		return "SIGTERM"
+	}
+	return sig.String()
+}
+
+// perfExitCode returns the process exit code for a finished test.
+func perfExitCode(result *engine.TestResult, runErr error, interrupted bool) int {
+	if result != nil && result.Aborted {
+		if result.DataExhausted {
+			return exitPerfThresholdAbort
+		}
+		for _, t := range result.Thresholds {
+			if t.Aborted {
+				return exitPerfThresholdAbort
+			}
+		}
+	}
+	if interrupted {
+		return exitPerfInterrupted
+	}
 	if result != nil && !result.Passed {
-		os.Exit(1)
+		return exitPerfFailed
 	}
 	if runErr != nil {
-		os.Exit(1)
+		return exitPerfFailed
+	}
+	return 0
+}
+
+// parsePerfVars builds the variables for ${VAR} expansion in the config
+// file from --env-file and --var flags. --var values override the env file.
+func parsePerfVars(envFile string, varFlags []string) (map[string]string, error) {
+	vars := make(map[string]string)
+
+	if envFile != "" {
+		fileVars, err := v2config.ReadEnvFile(envFile)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range fileVars {
+			vars[name] = value
+		}
+	}
+
+	for _, flag := range varFlags {
+		name, value, found := strings.Cut(flag, "=")
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid --var %q: expected key=value", flag)
+		}
+		vars[name] = value
+	}
+
+	return vars, nil
+}
+
+// parseOTLPConfig builds the OTLP exporter config from the endpoint and
+// the key=value --otlp-header flags.
+func parseOTLPConfig(endpoint string, headerFlags []string) (otlp.Config, error) {
+	config := otlp.Config{Endpoint: endpoint}
+	for _, flag := range headerFlags {
+		name, value, found := strings.Cut(flag, "=")
+		if !found || name == "" {
+			return config, fmt.Errorf("invalid --otlp-header %q: expected key=value", flag)
+		}
+		if config.Headers == nil {
+			config.Headers = make(map[string]string)
+		}
+		config.Headers[name] = value
 	}
+	return config, nil
 }
 
 // calculateTotalDuration calculates the total test duration from config.
@@ -257,6 +568,11 @@ func calculateTotalDuration(cfg *v2config.TestConfig) time.Duration {
 			if d, err := time.ParseDuration(scenario.Duration); err == nil {
 				scenarioDuration = d
 			}
+		} else if scenario.MaxDuration != "" {
+			// Iteration-based executors: maxDuration is the upper bound
+			if d, err := time.ParseDuration(scenario.MaxDuration); err == nil {
+				scenarioDuration = d
+			}
 		}
 
 		if scenarioDuration > maxDuration {
@@ -365,9 +681,13 @@ func buildConfigFromCLI(url, executorType, duration string, vus int, stages stri
 	if vus == 0 && executorType == "constant-vus" {
 		vus = 10
 	}
+	if vus == 0 && executorType == "externally-controlled" && rate == 0 && maxVUs == 0 {
+		vus = 10
+	}
 
-	// Default duration for non-stage executors
-	if duration == "" && stages == "" {
+	// Default duration for non-stage executors; externally-controlled
+	// runs until stopped
+	if duration == "" && stages == "" && executorType != "externally-controlled" {
 		duration = "30s"
 	}
 
@@ -467,10 +787,15 @@ func outputConsoleResult(result *engine.TestResult, verbose bool) {
 
 	// Test summary
 	passStatus := "✓ PASSED"
-	if !result.Passed {
+	if result.Aborted {
+		passStatus = "✗ ABORTED"
+	} else if !result.Passed {
 		passStatus = "✗ FAILED"
 	}
 	fmt.Printf("Status:    %s\n", passStatus)
+	if result.AbortReason != "" {
+		fmt.Printf("Reason:    %s\n", result.AbortReason)
+	}
 	fmt.Printf("Duration:  %s\n", result.Duration.Round(time.Millisecond))
 	fmt.Printf("Start:     %s\n", result.StartTime.Format(time.RFC3339))
 	fmt.Printf("End:       %s\n", result.EndTime.Format(time.RFC3339))
@@ -483,9 +808,15 @@ func outputConsoleResult(result *engine.TestResult, verbose bool) {
 		fmt.Printf("  Total Requests:    %d\n", m.TotalRequests)
 		fmt.Printf("  Successful:        %d\n", m.SuccessRequests)
 		fmt.Printf("  Failed:            %d\n", m.FailedRequests)
+		if m.TimedOutRequests > 0 {
+			fmt.Printf("  Timed Out:         %d\n", m.TimedOutRequests)
+		}
 		fmt.Printf("  Error Rate:        %.2f%%\n", m.ErrorRate*100)
 		fmt.Printf("  Throughput:        %.2f req/s\n", m.RPS)
 		fmt.Printf("  Data Transferred:  %s\n", formatBytes(m.TotalBytes))
+		if m.DroppedIterations > 0 {
+			fmt.Printf("  Dropped Iters:     %d\n", m.DroppedIterations)
+		}
 		fmt.Println()
 
 		// Latency stats
@@ -498,6 +829,86 @@ func outputConsoleResult(result *engine.TestResult, verbose bool) {
 		fmt.Printf("  P95:    %s\n", m.Latency.P95.Round(time.Microsecond))
 		fmt.Printf("  P99:    %s\n", m.Latency.P99.Round(time.Microsecond))
 		fmt.Println()
+
+		// HTTP phase timings
+		if m.Timings != nil {
+			fmt.Println("─── Request Timings " + strings.Repeat("─", 40))
+			fmt.Printf("  %-15s %10s %10s %10s\n", "", "Avg", "P95", "P99")
+			for _, phase := range m.Timings.Phases() {
+				fmt.Printf("  %-15s %10s %10s %10s\n", phase.Name+":", phase.Mean.Round(time.Microsecond),
+					phase.P95.Round(time.Microsecond), phase.P99.Round(time.Microsecond))
+			}
+			fmt.Println()
+		}
+
+		// Request groups
+		if len(m.Groups) > 0 {
+			fmt.Println("─── Groups " + strings.Repeat("─", 49))
+			fmt.Printf("  %-24s %8s %10s %10s %10s\n", "", "Runs", "Avg", "P95", "P99")
+			for _, g := range m.Groups {
+				fmt.Printf("  %-24s %8d %10s %10s %10s\n", g.Name+":", g.Duration.Count, g.Duration.Mean.Round(time.Microsecond),
+					g.Duration.P95.Round(time.Microsecond), g.Duration.P99.Round(time.Microsecond))
+			}
+			fmt.Println()
+		}
+
+		// Status code distribution
+		if len(m.StatusCodes) > 0 {
+			fmt.Println("─── Status Codes " + strings.Repeat("─", 43))
+			for _, sc := range metrics.SortStatusCodes(m.StatusCodes) {
+				fmt.Printf("  %d:  %d\n", sc.Code, sc.Count)
+			}
+			fmt.Println()
+		}
+
+		// Request errors
+		if len(m.ErrorCategories) > 0 {
+			fmt.Println("─── Errors " + strings.Repeat("─", 49))
+			for _, cc := range metrics.SortErrorCategories(m.ErrorCategories) {
+				fmt.Printf("  %-20s %d\n", string(cc.Category)+":", cc.Count)
+			}
+			for _, e := range m.TopErrors {
+				fmt.Printf("    %d x %s (%s)\n", e.Count, e.Message, e.Category)
+			}
+			fmt.Println()
+		}
+
+		// Check results
+		if len(m.Checks) > 0 {
+			fmt.Println("─── Checks " + strings.Repeat("─", 49))
+			for _, c := range m.Checks {
+				status := "✓"
+				if c.Fails > 0 {
+					status = "✗"
+				}
+				fmt.Printf("  %s %s: %.2f%% (%d passed, %d failed)\n", status, c.Name, c.PassRate*100, c.Passes, c.Fails)
+			}
+			fmt.Println()
+		}
+	}
+
+	// Target and actual mix of weighted flows
+	for _, name := range result.ScenarioNames() {
+		scenario := result.Scenarios[name]
+		if len(scenario.Mix) == 0 {
+			continue
+		}
+		title := fmt.Sprintf("─── Traffic Mix (%s) ", name)
+		fmt.Println(title + strings.Repeat("─", max(60-len([]rune(title)), 3)))
+		fmt.Printf("  %-24s %8s %8s %10s\n", "", "Target", "Actual", "Iterations")
+		for _, m := range scenario.Mix {
+			fmt.Printf("  %-24s %7.1f%% %7.1f%% %10d\n", m.Name+":", m.Target*100, m.Actual*100, m.Iterations)
+		}
+		fmt.Println()
+	}
+
+	// Changes made through the control API
+	if len(result.ControlEvents) > 0 {
+		fmt.Println("─── Control Events " + strings.Repeat("─", 41))
+		for _, ev := range result.ControlEvents {
+			fmt.Printf("  %10s  %s\n", ev.Elapsed.Round(time.Millisecond), ev)
+		}
+		fmt.Println()
 	}
 
 	// Scenario results
@@ -508,6 +919,11 @@ func outputConsoleResult(result *engine.TestResult, verbose bool) {
 			fmt.Printf("    Executor:    %s\n", scenario.Executor)
 			fmt.Printf("    Duration:    %s\n", scenario.Duration.Round(time.Millisecond))
 			fmt.Printf("    Iterations:  %d\n", scenario.Iterations)
+			if scenario.Metrics != nil {
+				fmt.Printf("    Requests:    %d\n", scenario.Metrics.TotalRequests)
+				fmt.Printf("    Error Rate:  %.2f%%\n", scenario.Metrics.ErrorRate*100)
+				fmt.Printf("    P95:         %s\n", scenario.Metrics.Latency.P95.Round(time.Microsecond))
+			}
 			if scenario.Error != nil {
 				fmt.Printf("    Error:       %v\n", scenario.Error)
 			}
@@ -576,18 +992,27 @@ func formatBytes(bytes int64) string {
 func init() {
 	// Performance engine flags
 	perfCmd.Flags().String("url", "", "URL to test (alternative to --config)")
-	perfCmd.Flags().String("executor", "", "Executor type: constant-vus, ramping-vus, constant-arrival-rate, ramping-arrival-rate")
+	perfCmd.Flags().String("executor", "", "Executor type: constant-vus, ramping-vus, constant-arrival-rate, ramping-arrival-rate, externally-controlled")
 	perfCmd.Flags().Int("vus", 0, "Number of virtual users")
 	perfCmd.Flags().String("stages", "", "Stages in format 'duration:target,duration:target,...' for ramping executors")
 	perfCmd.Flags().Float64("rate", 0, "Iterations per second for arrival-rate executors")
-	perfCmd.Flags().Int("max-vus", 0, "Maximum VUs for arrival-rate executors")
+	perfCmd.Flags().Int("max-vus", 0, "Maximum VUs for arrival-rate and externally-controlled executors")
 	perfCmd.Flags().Int("pre-allocated-vus", 0, "Pre-allocated VUs for arrival-rate executors")
 	perfCmd.Flags().Bool("json", false, "Output results as JSON")
 	perfCmd.Flags().Bool("html", false, "Generate HTML report")
 	perfCmd.Flags().BoolP("quiet", "q", false, "Disable live progress output, show only final summary")
+	perfCmd.Flags().String("control-addr", "", "Serve the control API on this address (e.g., localhost:6565; a bare port binds 127.0.0.1) to pause, resume, scale and stop the test")
+	perfCmd.Flags().String("live-addr", "", "Serve live metrics as JSON and Server-Sent Events on this address (e.g., localhost:6566)")
+	perfCmd.Flags().StringArray("out", nil, "Stream metrics to an output as it runs: influxdb=<write URL> or statsd=<host:port> (repeatable)")
+	perfCmd.Flags().String("otlp-endpoint", "", "Export metrics over OTLP/HTTP to this collector (e.g., http://localhost:4318)")
+	perfCmd.Flags().Bool("otlp-traces", false, "Also export a span per request and send a traceparent header with each request (requires --otlp-endpoint)")
+	perfCmd.Flags().StringArray("otlp-header", nil, "Header for OTLP export requests, as key=value (repeatable)")
+	perfCmd.Flags().String("prometheus-addr", "", "Serve metrics in the Prometheus text format at /metrics on this address (e.g., localhost:9464)")
 
 	// Basic flags
 	perfCmd.Flags().StringP("config", "c", "", "Configuration file")
+	perfCmd.Flags().StringArray("var", nil, "Set a config variable as key=value, overriding the environment (repeatable)")
+	perfCmd.Flags().String("env-file", "", "Read config variables from a .env file")
 	perfCmd.Flags().BoolP("verbose", "v", false, "Enable verbose output")
 	perfCmd.Flags().DurationP("timeout", "t", 30*time.Second, "Request timeout")
 	perfCmd.Flags().String("duration", "", "Test duration (e.g., 5m, 30s)")
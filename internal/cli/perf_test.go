@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -326,6 +327,19 @@ func TestCalculateTotalDuration(t *testing.T) {
 			},
 			expected: 1 * time.Minute,
 		},
+		{
+			name: "Iteration-based scenario with maxDuration",
+			config: &v2config.TestConfig{
+				Scenarios: map[string]*v2config.ScenarioConfig{
+					"test": {
+						Executor:    "per-vu-iterations",
+						Iterations:  10,
+						MaxDuration: "2m",
+					},
+				},
+			},
+			expected: 2 * time.Minute,
+		},
 		{
 			name: "Single scenario with stages",
 			config: &v2config.TestConfig{
@@ -821,6 +835,206 @@ func TestOutputConsoleResult(t *testing.T) {
 	outputConsoleResult(failedResult, false)
 }
 
+func TestPerfExitCode(t *testing.T) {
+	tests := []struct {
+		name        string
+		result      *engine.TestResult
+		runErr      error
+		interrupted bool
+		want        int
+	}{
+		{
+			name:   "passed",
+			result: &engine.TestResult{Passed: true},
+			want:   0,
+		},
+		{
+			name:   "thresholds failed",
+			result: &engine.TestResult{Passed: false},
+			want:   exitPerfFailed,
+		},
+		{
+			name:   "run error",
+			result: &engine.TestResult{Passed: true},
+			runErr: os.ErrDeadlineExceeded,
+			want:   exitPerfFailed,
+		},
+		{
+			name:   "no result",
+			runErr: os.ErrDeadlineExceeded,
+			want:   exitPerfFailed,
+		},
+		{
+			name: "aborted by threshold",
+			result: &engine.TestResult{
+				Passed:      false,
+				Aborted:     true,
+				AbortReason: "threshold http_req_failed rate < 0.01 failed",
+				Thresholds: []engine.ThresholdResult{
+					{Metric: "http_req_duration", Expression: "p95 < 1s", Passed: true},
+					{Metric: "http_req_failed", Expression: "rate < 0.01", AbortOnFail: true, Aborted: true},
+				},
+			},
+			want: exitPerfThresholdAbort,
+		},
+		{
+			name: "aborted by exhausted data",
+			result: &engine.TestResult{
+				Passed:        true,
+				Aborted:       true,
+				AbortReason:   "scenario test ran out of data in users.csv",
+				DataExhausted: true,
+			},
+			want: exitPerfThresholdAbort,
+		},
+		{
+			name:   "stopped without a threshold",
+			result: &engine.TestResult{Passed: true, Aborted: true, AbortReason: "stopped"},
+			want:   0,
+		},
+		{
+			name:        "interrupted",
+			result:      &engine.TestResult{Passed: true, Aborted: true, AbortReason: "interrupted by SIGINT"},
+			interrupted: true,
+			want:        exitPerfInterrupted,
+		},
+		{
+			name:        "interrupted with failed thresholds",
+			result:      &engine.TestResult{Passed: false, Aborted: true, AbortReason: "interrupted by SIGINT"},
+			interrupted: true,
+			want:        exitPerfInterrupted,
+		},
+		{
+			name: "interrupted after threshold abort",
+			result: &engine.TestResult{
+				Aborted:    true,
+				Thresholds: []engine.ThresholdResult{{Metric: "http_req_failed", AbortOnFail: true, Aborted: true}},
+			},
+			interrupted: true,
+			want:        exitPerfThresholdAbort,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := perfExitCode(tt.result, tt.runErr, tt.interrupted); got != tt.want {
+				t.Errorf("perfExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWatchInterrupts(t *testing.T) {
+	signals := make(chan os.Signal, 2)
+	stopped := make(chan string, 1)
+	exited := make(chan struct{})
+
+	w := watchInterrupts(signals, func(reason string) {
+		stopped <- reason
+	}, func() {
+		close(exited)
+	})
+	defer w.Close()
+
+	if w.Interrupted() {
+		t.Error("Interrupted() before any signal = true, want false")
+	}
+
+	// First signal stops gracefully
+	signals <- os.Interrupt
+	select {
+	case reason := <-stopped:
+		if reason != "interrupted by SIGINT" {
+			t.Errorf("stop reason = %q, want %q", reason, "interrupted by SIGINT")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("stop was not called after the first signal")
+	}
+	if !w.Interrupted() {
+		t.Error("Interrupted() after a signal = false, want true")
+	}
+
+	select {
+	case <-exited:
+		t.Fatal("forceExit called after the first signal")
+	default:
+	}
+
+	// Second signal forces an exit
+	signals <- syscall.SIGTERM
+	select {
+	case <-exited:
+	case <-time.After(time.Second):
+		t.Fatal("forceExit was not called after the second signal")
+	}
+}
+
+func TestParsePerfVars(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(envFile, []byte("BASE_URL=http://file\nAPI_KEY=from-file\n"), 0644); err != nil {
+		t.Fatalf("Failed to write env file: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		envFile string
+		vars    []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "none",
+			want: map[string]string{},
+		},
+		{
+			name:    "env file",
+			envFile: envFile,
+			want:    map[string]string{"BASE_URL": "http://file", "API_KEY": "from-file"},
+		},
+		{
+			name:    "var overrides env file",
+			envFile: envFile,
+			vars:    []string{"BASE_URL=http://flag", "EXTRA=a=b"},
+			want:    map[string]string{"BASE_URL": "http://flag", "API_KEY": "from-file", "EXTRA": "a=b"},
+		},
+		{
+			name: "empty value",
+			vars: []string{"EMPTY="},
+			want: map[string]string{"EMPTY": ""},
+		},
+		{
+			name:    "missing equals",
+			vars:    []string{"BASE_URL"},
+			wantErr: true,
+		},
+		{
+			name:    "missing env file",
+			envFile: filepath.Join(t.TempDir(), "missing.env"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePerfVars(tt.envFile, tt.vars)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePerfVars() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("parsePerfVars() = %v, want %v", got, tt.want)
+			}
+			for name, value := range tt.want {
+				if got[name] != value {
+					t.Errorf("parsePerfVars()[%s] = %q, want %q", name, got[name], value)
+				}
+			}
+		})
+	}
+}
+
 func TestPerfCommand_Help(t *testing.T) {
 	rootCmd := RootCmd
 	rootCmd.SetArgs([]string{"perf", "--help"})
@@ -1099,6 +1313,36 @@ func TestBuildConfigFromCLI_AllArrivalRateOptions(t *testing.T) {
 	}
 }
 
+func TestBuildConfigFromCLI_ExternallyControlled(t *testing.T) {
+	cfg, err := buildConfigFromCLI("https://example.com", "externally-controlled", "", 0, "", 0, 0, 0)
+	if err != nil {
+		t.Fatalf("buildConfigFromCLI() error = %v", err)
+	}
+
+	scenario := cfg.Scenarios["cli-test"]
+	if scenario.VUs != 10 {
+		t.Errorf("Default VUs = %d, want 10", scenario.VUs)
+	}
+	if scenario.Duration != "" {
+		t.Errorf("Duration = %q, want none (runs until stopped)", scenario.Duration)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	// With a rate, VUs come from --max-vus
+	cfg, err = buildConfigFromCLI("https://example.com", "externally-controlled", "10m", 0, "", 20, 50, 0)
+	if err != nil {
+		t.Fatalf("buildConfigFromCLI() error = %v", err)
+	}
+	if scenario := cfg.Scenarios["cli-test"]; scenario.VUs != 0 || scenario.Duration != "10m" {
+		t.Errorf("VUs = %d, Duration = %q, want 0 and 10m", scenario.VUs, scenario.Duration)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
 func TestBuildConfigFromCLI_RampingArrivalRate(t *testing.T) {
 	cfg, err := buildConfigFromCLI(
 		"https://example.com",
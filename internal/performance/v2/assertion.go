@@ -0,0 +1,204 @@
+package v2
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wesleyorama2/lunge/pkg/jsonpath"
+)
+
+// AssertionConfig defines a validation evaluated against every response.
+type AssertionConfig struct {
+	// Type: "status", "body", "header", "duration"
+	Type string `json:"type" yaml:"type"`
+
+	// Condition: "eq", "ne", "gt", "lt", "gte", "lte", "contains", "matches"
+	Condition string `json:"condition" yaml:"condition"`
+
+	// Value is the expected value (supports variable substitution)
+	Value string `json:"value" yaml:"value"`
+
+	// Path: JSONPath for body, header name for header
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+
+	// Message is an optional name for this check in the results
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// CheckName returns the name under which this assertion is reported.
+//
+// The name is prefixed with the request name so identical assertions on
+// different requests are counted separately.
+func (a *AssertionConfig) CheckName(requestName string) string {
+	desc := a.Message
+	if desc == "" {
+		subject := a.Type
+		if a.Path != "" {
+			subject = fmt.Sprintf("%s %s", a.Type, a.Path)
+		}
+		desc = fmt.Sprintf("%s %s %s", subject, a.Condition, a.Value)
+	}
+	if requestName == "" {
+		return desc
+	}
+	return fmt.Sprintf("%s: %s", requestName, desc)
+}
+
+// evaluateAssertion evaluates an assertion against a request result.
+//
+// The expected value must already have its variables resolved. An error
+// is returned, and the assertion fails, if the values cannot be compared.
+func evaluateAssertion(a *AssertionConfig, expected string, result *RequestResult) (bool, error) {
+	if result.Error != nil {
+		return false, nil
+	}
+
+	static := !strings.Contains(a.Value, "{{")
+
+	switch a.Type {
+	case "status":
+		return compareValues(strconv.Itoa(result.StatusCode), a.Condition, expected, static)
+
+	case "header":
+		return compareValues(result.ResponseHeaders.Get(a.Path), a.Condition, expected, static)
+
+	case "body":
+		actual := string(result.ResponseBody)
+		if a.Path != "" {
+			value, err := jsonpath.Extract(actual, a.Path)
+			if err != nil {
+				return false, nil
+			}
+			actual = value
+		}
+		return compareValues(actual, a.Condition, expected, static)
+
+	case "duration":
+		expectedDur, err := parseDurationValue(expected)
+		if err != nil {
+			return false, fmt.Errorf("invalid duration %q", expected)
+		}
+		return compareOrdered(float64(result.Duration), a.Condition, float64(expectedDur)), nil
+
+	default:
+		return false, fmt.Errorf("unknown assertion type: %s", a.Type)
+	}
+}
+
+// compareValues compares an actual value against an expected value.
+//
+// Equality conditions compare numerically when both sides parse as
+// numbers, otherwise as strings. Ordering conditions (gt, lt, gte, lte)
+// compare numbers or durations, and return an error for other values.
+//
+// static reports that expected came from the configuration without
+// variables, so a "matches" pattern can be cached.
+func compareValues(actual, condition, expected string, static bool) (bool, error) {
+	switch condition {
+	case "contains":
+		return strings.Contains(actual, expected), nil
+
+	case "matches":
+		var re *regexp.Regexp
+		var err error
+		if static {
+			re, err = compileRegex(expected)
+		} else {
+			re, err = regexp.Compile(expected)
+		}
+		if err != nil {
+			return false, fmt.Errorf("invalid regular expression %q: %w", expected, err)
+		}
+		return re.MatchString(actual), nil
+
+	case "eq", "ne":
+		equal := actual == expected
+		if a, b, ok := parseNumbers(actual, expected); ok {
+			equal = a == b
+		}
+		if condition == "eq" {
+			return equal, nil
+		}
+		return !equal, nil
+
+	case "gt", "lt", "gte", "lte":
+		if a, b, ok := parseNumbers(actual, expected); ok {
+			return compareOrdered(a, condition, b), nil
+		}
+		if a, errA := time.ParseDuration(actual); errA == nil {
+			if b, errB := time.ParseDuration(expected); errB == nil {
+				return compareOrdered(float64(a), condition, float64(b)), nil
+			}
+		}
+		return false, fmt.Errorf("cannot compare %q %s %q: values must be numbers or durations", actual, condition, expected)
+
+	default:
+		return false, fmt.Errorf("unknown condition: %s", condition)
+	}
+}
+
+// compareOrdered applies a comparison condition to two numbers.
+func compareOrdered(actual float64, condition string, expected float64) bool {
+	switch condition {
+	case "eq":
+		return actual == expected
+	case "ne":
+		return actual != expected
+	case "gt":
+		return actual > expected
+	case "lt":
+		return actual < expected
+	case "gte":
+		return actual >= expected
+	case "lte":
+		return actual <= expected
+	default:
+		return false
+	}
+}
+
+// parseNumbers parses both values as floats.
+func parseNumbers(a, b string) (float64, float64, bool) {
+	x, err := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	y, err := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return x, y, true
+}
+
+// parseDurationValue parses a duration, treating bare numbers as milliseconds.
+func parseDurationValue(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if ms, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(ms * float64(time.Millisecond)), nil
+	}
+	return time.ParseDuration(s)
+}
+
+// regexCache caches compiled regular expressions shared by all VUs. Only
+// patterns fixed by the configuration are cached, so it stays bounded.
+var regexCache sync.Map
+
+// compileRegex compiles a regular expression, reusing cached results. The
+// pattern must not vary between iterations.
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := regexCache.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	regexCache.Store(pattern, re)
+	return re, nil
+}
@@ -0,0 +1,184 @@
+package v2
+
+import (
+	cryptorand "crypto/rand"
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// builtinPattern matches placeholders that may be built-in variables, such
+// as {{uuid}} or {{randomInt(1, 100)}}.
+var builtinPattern = regexp.MustCompile(`\{\{\s*([A-Za-z]+)\s*(\(([^)]*)\))?\s*\}\}`)
+
+// randomStringChars are the characters used by randomString.
+const randomStringChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// requestBuiltins resolves the built-in variables of a single request.
+//
+// Each distinct placeholder resolves to one value per request, so {{uuid}}
+// used in both a header and the body gets the same value.
+type requestBuiltins struct {
+	vu     *VirtualUser
+	now    time.Time
+	values map[string]string
+}
+
+// newRequestBuiltins creates the built-in variables for a VU's next request.
+func newRequestBuiltins(vu *VirtualUser) *requestBuiltins {
+	return &requestBuiltins{
+		vu:     vu,
+		now:    time.Now(),
+		values: make(map[string]string),
+	}
+}
+
+// resolve replaces built-in variable placeholders in input. Placeholders
+// that are not built-ins, or have invalid arguments, are left untouched.
+func (b *requestBuiltins) resolve(input string) string {
+	if !strings.Contains(input, "{{") {
+		return input
+	}
+
+	return builtinPattern.ReplaceAllStringFunc(input, func(placeholder string) string {
+		if value, ok := b.values[placeholder]; ok {
+			return value
+		}
+
+		match := builtinPattern.FindStringSubmatch(placeholder)
+		var args []string
+		if match[2] != "" {
+			args = splitBuiltinArgs(match[3])
+		}
+
+		value, ok := b.value(match[1], match[2] != "", args)
+		if !ok {
+			return placeholder
+		}
+		b.values[placeholder] = value
+		return value
+	})
+}
+
+// value returns the value of the named built-in.
+func (b *requestBuiltins) value(name string, call bool, args []string) (string, bool) {
+	if !call {
+		switch name {
+		case "vu", "vuId":
+			return strconv.Itoa(b.vu.ID), true
+		case "iteration":
+			return strconv.FormatInt(b.vu.GetIteration(), 10), true
+		case "scenario":
+			if b.vu.Scenario == nil {
+				return "", true
+			}
+			return b.vu.Scenario.Name, true
+		case "timestamp":
+			return strconv.FormatInt(b.now.Unix(), 10), true
+		case "timestampMs":
+			return strconv.FormatInt(b.now.UnixMilli(), 10), true
+		case "isoTimestamp":
+			return b.now.UTC().Format("2006-01-02T15:04:05.000Z07:00"), true
+		case "uuid":
+			return newUUID(), true
+		}
+		return "", false
+	}
+
+	switch name {
+	case "randomInt":
+		if len(args) != 2 {
+			return "", false
+		}
+		min, err1 := strconv.ParseInt(args[0], 10, 64)
+		max, err2 := strconv.ParseInt(args[1], 10, 64)
+		if err1 != nil || err2 != nil || min > max {
+			return "", false
+		}
+		return strconv.FormatInt(randomInt(min, max), 10), true
+
+	case "randomString":
+		if len(args) != 1 && len(args) != 2 {
+			return "", false
+		}
+		min, err := strconv.Atoi(args[0])
+		if err != nil || min < 0 {
+			return "", false
+		}
+		max := min
+		if len(args) == 2 {
+			if max, err = strconv.Atoi(args[1]); err != nil || min > max {
+				return "", false
+			}
+		}
+		return randomString(min + rand.Intn(max-min+1)), true
+
+	case "randomItem":
+		if len(args) == 0 {
+			return "", false
+		}
+		return args[rand.Intn(len(args))], true
+	}
+	return "", false
+}
+
+// splitBuiltinArgs splits a comma-separated argument list, trimming spaces.
+func splitBuiltinArgs(list string) []string {
+	if strings.TrimSpace(list) == "" {
+		return nil
+	}
+	args := strings.Split(list, ",")
+	for i, arg := range args {
+		args[i] = strings.TrimSpace(arg)
+	}
+	return args
+}
+
+// randomInt returns a uniformly distributed integer in [min, max], for any
+// bounds with min <= max.
+func randomInt(min, max int64) int64 {
+	// The span is computed in uint64 so that it cannot overflow
+	span := uint64(max) - uint64(min)
+	if span == math.MaxUint64 {
+		return int64(rand.Uint64())
+	}
+	span++
+
+	var n uint64
+	if span <= math.MaxInt64 {
+		n = uint64(rand.Int63n(int64(span)))
+	} else {
+		// More than half of all uint64 values are in range, so this
+		// rarely takes more than a couple of draws
+		for n = rand.Uint64(); n >= span; n = rand.Uint64() {
+		}
+	}
+	return int64(uint64(min) + n)
+}
+
+// randomString returns a random alphanumeric string of the given length.
+func randomString(length int) string {
+	var sb strings.Builder
+	sb.Grow(length)
+	for i := 0; i < length; i++ {
+		sb.WriteByte(randomStringChars[rand.Intn(len(randomStringChars))])
+	}
+	return sb.String()
+}
+
+// newUUID returns a random (version 4) UUID.
+func newUUID() string {
+	var b [16]byte
+	if _, err := cryptorand.Read(b[:]); err != nil {
+		for i := range b {
+			b[i] = byte(rand.Intn(256))
+		}
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
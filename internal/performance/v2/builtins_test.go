@@ -0,0 +1,215 @@
+package v2_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	v2 "github.com/wesleyorama2/lunge/internal/performance/v2"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+)
+
+// capturedRequest is a request as received by the echo server.
+type capturedRequest struct {
+	path   string
+	header string
+	body   string
+}
+
+// createEchoServer creates a server that records the path, X-Value header
+// and body of every request.
+func createEchoServer(mu *sync.Mutex, requests *[]capturedRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		*requests = append(*requests, capturedRequest{
+			path:   r.URL.Path,
+			header: r.Header.Get("X-Value"),
+			body:   string(body),
+		})
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+// runBuiltin sends value in the path, a header and the body of one request
+// and returns what the server received.
+func runBuiltin(t *testing.T, value string, variables map[string]string) capturedRequest {
+	t.Helper()
+
+	var mu sync.Mutex
+	var requests []capturedRequest
+	server := createEchoServer(&mu, &requests)
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scenario := &v2.Scenario{
+		Name:      "builtins",
+		Variables: variables,
+		Requests: []*v2.RequestConfig{
+			{
+				Name:    "request",
+				Method:  "POST",
+				URL:     server.URL + "/" + value,
+				Headers: map[string]string{"X-Value": value},
+				Body:    value,
+			},
+		},
+	}
+
+	vu := v2.NewVirtualUser(7, scenario, server.Client(), metricsEngine)
+	if err := vu.RunIteration(context.Background()); err != nil {
+		t.Fatalf("RunIteration() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requests) != 1 {
+		t.Fatalf("server received %d requests, want 1", len(requests))
+	}
+	return requests[0]
+}
+
+func TestBuiltins_Values(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"{{vu}}", "7"},
+		{"{{vuId}}", "7"},
+		{"{{iteration}}", "1"},
+		{"{{scenario}}", "builtins"},
+		{"{{ vu }}-{{iteration}}", "7-1"},
+		{"{{unknown}}", "{{unknown}}"},
+		{"{{randomInt(5, 1)}}", "{{randomInt(5, 1)}}"},
+		{"{{randomInt(x, 1)}}", "{{randomInt(x, 1)}}"},
+		{"{{randomItem()}}", "{{randomItem()}}"},
+		{"{{uuid(1)}}", "{{uuid(1)}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got := runBuiltin(t, tt.value, nil)
+			if got.header != tt.want || got.body != tt.want {
+				t.Errorf("header = %q, body = %q, want %q", got.header, got.body, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuiltins_Timestamps(t *testing.T) {
+	before := time.Now()
+	got := runBuiltin(t, "{{timestamp}} {{timestampMs}} {{isoTimestamp}}", nil)
+	after := time.Now()
+
+	parts := strings.Fields(got.body)
+	if len(parts) != 3 {
+		t.Fatalf("body = %q, want three timestamps", got.body)
+	}
+
+	seconds, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || seconds < before.Unix() || seconds > after.Unix() {
+		t.Errorf("timestamp = %q, want unix seconds between %d and %d", parts[0], before.Unix(), after.Unix())
+	}
+
+	millis, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || millis < before.UnixMilli() || millis > after.UnixMilli() {
+		t.Errorf("timestampMs = %q, want unix milliseconds", parts[1])
+	}
+	if millis/1000 != seconds {
+		t.Errorf("timestamp %d and timestampMs %d should share the same instant", seconds, millis)
+	}
+
+	iso, err := time.Parse(time.RFC3339Nano, parts[2])
+	if err != nil {
+		t.Fatalf("isoTimestamp = %q, want RFC 3339: %v", parts[2], err)
+	}
+	if iso.UnixMilli() != millis {
+		t.Errorf("isoTimestamp = %v, want the same instant as timestampMs %d", iso, millis)
+	}
+}
+
+func TestBuiltins_UUID(t *testing.T) {
+	uuidPattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	first := runBuiltin(t, "{{uuid}}", nil)
+	if !uuidPattern.MatchString(first.body) {
+		t.Errorf("uuid = %q, want a version 4 UUID", first.body)
+	}
+
+	// One value per request, in every part of it
+	if first.path != "/"+first.body || first.header != first.body {
+		t.Errorf("path = %q, header = %q, body = %q, want the same uuid", first.path, first.header, first.body)
+	}
+
+	second := runBuiltin(t, "{{uuid}}", nil)
+	if second.body == first.body {
+		t.Errorf("uuid should differ between requests, got %q twice", first.body)
+	}
+}
+
+func TestBuiltins_Random(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		got := runBuiltin(t, "{{randomInt(-2, 3)}}|{{randomString(8)}}|{{randomString(2, 4)}}|{{randomItem(red, green, blue)}}", nil)
+
+		parts := strings.Split(got.body, "|")
+		if len(parts) != 4 {
+			t.Fatalf("body = %q, want four values", got.body)
+		}
+
+		if n, err := strconv.Atoi(parts[0]); err != nil || n < -2 || n > 3 {
+			t.Errorf("randomInt(-2, 3) = %q, want an integer in [-2, 3]", parts[0])
+		}
+		if !regexp.MustCompile(`^[A-Za-z0-9]{8}$`).MatchString(parts[1]) {
+			t.Errorf("randomString(8) = %q, want 8 alphanumeric characters", parts[1])
+		}
+		if l := len(parts[2]); l < 2 || l > 4 {
+			t.Errorf("randomString(2, 4) = %q, want 2 to 4 characters", parts[2])
+		}
+		if parts[3] != "red" && parts[3] != "green" && parts[3] != "blue" {
+			t.Errorf("randomItem(red, green, blue) = %q", parts[3])
+		}
+	}
+}
+
+func TestBuiltins_RandomIntExtremeBounds(t *testing.T) {
+	tests := []struct {
+		min, max int64
+	}{
+		{0, math.MaxInt64},
+		{-1, math.MaxInt64},
+		{math.MinInt64, math.MaxInt64},
+		{math.MinInt64, 0},
+		{math.MinInt64, math.MinInt64},
+		{math.MaxInt64, math.MaxInt64},
+	}
+
+	for _, tt := range tests {
+		call := fmt.Sprintf("{{randomInt(%d, %d)}}", tt.min, tt.max)
+		for i := 0; i < 20; i++ {
+			got := runBuiltin(t, call, nil)
+			n, err := strconv.ParseInt(got.body, 10, 64)
+			if err != nil || n < tt.min || n > tt.max {
+				t.Fatalf("%s = %q, want an integer in [%d, %d]", call, got.body, tt.min, tt.max)
+			}
+		}
+	}
+}
+
+func TestBuiltins_UserVariablesTakePrecedence(t *testing.T) {
+	got := runBuiltin(t, "{{timestamp}}", map[string]string{"timestamp": "fixed"})
+	if got.body != "fixed" {
+		t.Errorf("body = %q, want the scenario variable %q", got.body, "fixed")
+	}
+}
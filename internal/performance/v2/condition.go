@@ -0,0 +1,42 @@
+package v2
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ConditionConfig is a condition on the VU's state, used to decide whether
+// a step runs (When) or stops repeating (Until).
+type ConditionConfig struct {
+	// Source: "variable" (default) or "status", the status code of the
+	// VU's most recent response in the iteration (0 if none)
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+
+	// Variable is the name of the variable tested by the variable source.
+	// Anything usable as a {{placeholder}} works; unset variables are empty.
+	Variable string `json:"variable,omitempty" yaml:"variable,omitempty"`
+
+	// Condition: "eq", "ne", "gt", "lt", "gte", "lte", "contains", "matches"
+	Condition string `json:"condition" yaml:"condition"`
+
+	// Value is the value to compare against (supports variable substitution)
+	Value string `json:"value" yaml:"value"`
+}
+
+// evaluateCondition reports whether a condition holds for the VU.
+func (vu *VirtualUser) evaluateCondition(cond *ConditionConfig) bool {
+	var actual string
+	switch cond.Source {
+	case "status":
+		actual = strconv.Itoa(vu.lastStatus)
+	default:
+		placeholder := fmt.Sprintf("{{%s}}", cond.Variable)
+		if actual = vu.resolveVariables(placeholder); actual == placeholder {
+			actual = ""
+		}
+	}
+	// Values that cannot be compared do not satisfy the condition
+	holds, _ := compareValues(actual, cond.Condition, vu.resolveVariables(cond.Value), !strings.Contains(cond.Value, "{{"))
+	return holds
+}
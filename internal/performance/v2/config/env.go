@@ -0,0 +1,253 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// variableNamePattern matches valid variable names.
+var variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ExpandVariables expands shell-style variable references in a string.
+//
+// Supported forms:
+//   - ${VAR}: the value of VAR, or empty if unset
+//   - ${VAR:-default}: the value of VAR, or default if unset or empty
+//   - ${VAR:?message}: the value of VAR, or an error if unset or empty
+//   - $${VAR}: a literal ${VAR}
+//
+// Values are looked up with lookup.
+func ExpandVariables(s string, lookup func(name string) (string, bool)) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+
+	var out strings.Builder
+	out.Grow(len(s))
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '$' || i+1 >= len(s) {
+			out.WriteByte(c)
+			continue
+		}
+
+		// $${...} escapes a reference
+		if s[i+1] == '$' && i+2 < len(s) && s[i+2] == '{' {
+			out.WriteByte('$')
+			i++
+			continue
+		}
+
+		if s[i+1] != '{' {
+			out.WriteByte(c)
+			continue
+		}
+
+		end := strings.IndexByte(s[i+2:], '}')
+		if end < 0 || strings.IndexByte(s[i+2:i+2+end], '\n') >= 0 {
+			return "", fmt.Errorf("unterminated variable reference")
+		}
+
+		value, err := expandReference(s[i+2:i+2+end], lookup)
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(value)
+		i += end + 2
+	}
+
+	return out.String(), nil
+}
+
+// expandYAML expands variable references in the scalars of a parsed YAML
+// document, so values are never re-parsed as YAML and comments are left
+// alone. Errors name the line of the scalar.
+func expandYAML(node *yaml.Node, lookup func(name string) (string, bool)) error {
+	if node.Kind == yaml.ScalarNode {
+		value, err := ExpandVariables(node.Value, lookup)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		if value != node.Value {
+			node.Value = value
+			// Resolve the type of plain scalars from the expanded value, so
+			// that e.g. vus: ${VUS} is still a number
+			if node.Style&(yaml.TaggedStyle|yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+				node.Tag = ""
+			}
+		}
+		return nil
+	}
+
+	for _, child := range node.Content {
+		if err := expandYAML(child, lookup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expandJSON decodes a JSON document, expanding variable references in its
+// keys and string values. Errors name the line and path of the value.
+//
+// The document must already be known to be valid JSON.
+func expandJSON(data []byte, lookup func(name string) (string, bool)) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return expandJSONValue(decoder, data, "", lookup)
+}
+
+// expandJSONValue decodes and expands the next value of decoder, found at
+// path.
+func expandJSONValue(decoder *json.Decoder, data []byte, path string, lookup func(name string) (string, bool)) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := token.(type) {
+	case string:
+		expanded, err := ExpandVariables(t, lookup)
+		if err != nil {
+			return nil, jsonError(decoder, data, path, err)
+		}
+		return expanded, nil
+	case json.Delim:
+		if t == '[' {
+			values := make([]any, 0)
+			for i := 0; decoder.More(); i++ {
+				value, err := expandJSONValue(decoder, data, fmt.Sprintf("%s[%d]", path, i), lookup)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, value)
+			}
+			_, err := decoder.Token() // ]
+			return values, err
+		}
+
+		object := make(map[string]any)
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key := token.(string)
+			newKey, err := ExpandVariables(key, lookup)
+			if err != nil {
+				return nil, jsonError(decoder, data, path+"."+key, err)
+			}
+			if object[newKey], err = expandJSONValue(decoder, data, path+"."+key, lookup); err != nil {
+				return nil, err
+			}
+		}
+		_, err := decoder.Token() // }
+		return object, err
+	}
+	return token, nil
+}
+
+// jsonError reports an expansion error at the token just read by decoder.
+func jsonError(decoder *json.Decoder, data []byte, path string, err error) error {
+	line := 1 + bytes.Count(data[:decoder.InputOffset()], []byte("\n"))
+	return fmt.Errorf("line %d: %s: %w", line, jsonPath(path), err)
+}
+
+// jsonPath formats a path built by expandJSON, e.g. "settings.baseUrl".
+func jsonPath(path string) string {
+	if path == "" {
+		return "$"
+	}
+	return strings.TrimPrefix(path, ".")
+}
+
+// expandReference resolves the contents of a single ${...} reference.
+func expandReference(ref string, lookup func(name string) (string, bool)) (string, error) {
+	name, operand, op := ref, "", ""
+	if idx := strings.Index(ref, ":"); idx >= 0 {
+		name = ref[:idx]
+		rest := ref[idx+1:]
+		if len(rest) == 0 || (rest[0] != '-' && rest[0] != '?') {
+			return "", fmt.Errorf("invalid variable reference ${%s}", ref)
+		}
+		op, operand = rest[:1], rest[1:]
+	}
+
+	if !variableNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid variable name %q", name)
+	}
+
+	value, _ := lookup(name)
+	if value != "" {
+		return value, nil
+	}
+
+	switch op {
+	case "-":
+		return operand, nil
+	case "?":
+		if operand == "" {
+			operand = "required variable is not set"
+		}
+		return "", fmt.Errorf("%s: %s", name, operand)
+	}
+	return "", nil
+}
+
+// ReadEnvFile reads variables from a .env file.
+//
+// Each non-empty line that is not a # comment must have the form
+// KEY=VALUE, optionally prefixed by "export ". Values may be wrapped in
+// single or double quotes.
+func ReadEnvFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read env file: %w", err)
+	}
+
+	vars := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		text = strings.TrimPrefix(text, "export ")
+
+		name, value, found := strings.Cut(text, "=")
+		name = strings.TrimSpace(name)
+		if !found || !variableNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("%s: line %d: expected KEY=VALUE", path, line)
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		vars[name] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read env file: %w", err)
+	}
+
+	return vars, nil
+}
+
+// lookupVariable returns a lookup function that checks vars before the
+// process environment.
+func lookupVariable(vars map[string]string) func(name string) (string, bool) {
+	return func(name string) (string, bool) {
+		if value, ok := vars[name]; ok {
+			return value, true
+		}
+		return os.LookupEnv(name)
+	}
+}
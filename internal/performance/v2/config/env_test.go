@@ -0,0 +1,306 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExpandVariables(t *testing.T) {
+	vars := map[string]string{
+		"BASE_URL": "https://api.example.com",
+		"EMPTY":    "",
+	}
+	lookup := func(name string) (string, bool) {
+		value, ok := vars[name]
+		return value, ok
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:  "no references",
+			input: "url: \"$.data\"",
+			want:  "url: \"$.data\"",
+		},
+		{
+			name:  "set variable",
+			input: "baseUrl: \"${BASE_URL}\"",
+			want:  "baseUrl: \"https://api.example.com\"",
+		},
+		{
+			name:  "unset variable",
+			input: "key: \"${MISSING}\"",
+			want:  "key: \"\"",
+		},
+		{
+			name:  "default unused",
+			input: "${BASE_URL:-https://httpbin.org}",
+			want:  "https://api.example.com",
+		},
+		{
+			name:  "default for unset",
+			input: "${MISSING:-https://httpbin.org}",
+			want:  "https://httpbin.org",
+		},
+		{
+			name:  "default for empty",
+			input: "${EMPTY:-fallback}",
+			want:  "fallback",
+		},
+		{
+			name:  "empty default",
+			input: "[${MISSING:-}]",
+			want:  "[]",
+		},
+		{
+			name:  "required and set",
+			input: "${BASE_URL:?base url is required}",
+			want:  "https://api.example.com",
+		},
+		{
+			name:  "escaped reference",
+			input: "literal: $${BASE_URL} and ${BASE_URL}",
+			want:  "literal: ${BASE_URL} and https://api.example.com",
+		},
+		{
+			name:  "dollar without brace",
+			input: "price: $5 and $$",
+			want:  "price: $5 and $$",
+		},
+		{
+			name:    "required and missing",
+			input:   "Bearer ${TOKEN:?set TOKEN to an API token}",
+			wantErr: true,
+			errMsg:  "TOKEN: set TOKEN to an API token",
+		},
+		{
+			name:    "required and empty without message",
+			input:   "${EMPTY:?}",
+			wantErr: true,
+			errMsg:  "EMPTY: required variable is not set",
+		},
+		{
+			name:    "unterminated",
+			input:   "${BASE_URL\nc: 2}",
+			wantErr: true,
+			errMsg:  "unterminated",
+		},
+		{
+			name:    "invalid name",
+			input:   "${1ABC}",
+			wantErr: true,
+			errMsg:  "invalid variable name",
+		},
+		{
+			name:    "unsupported operator",
+			input:   "${BASE_URL:=x}",
+			wantErr: true,
+			errMsg:  "invalid variable reference",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandVariables(tt.input, lookup)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExpandVariables() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("Error should contain '%s', got: %v", tt.errMsg, err)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ExpandVariables() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadEnvFile(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	content := `# Test environment
+BASE_URL=https://staging.example.com
+export API_KEY="secret key"
+QUOTED='single'
+
+EMPTY=
+WITH_EQUALS=a=b
+`
+	path := filepath.Join(tmpDir, ".env")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write env file: %v", err)
+	}
+
+	vars, err := ReadEnvFile(path)
+	if err != nil {
+		t.Fatalf("ReadEnvFile() error = %v", err)
+	}
+
+	want := map[string]string{
+		"BASE_URL":    "https://staging.example.com",
+		"API_KEY":     "secret key",
+		"QUOTED":      "single",
+		"EMPTY":       "",
+		"WITH_EQUALS": "a=b",
+	}
+	if len(vars) != len(want) {
+		t.Errorf("ReadEnvFile() = %v, want %v", vars, want)
+	}
+	for name, value := range want {
+		if vars[name] != value {
+			t.Errorf("%s = %q, want %q", name, vars[name], value)
+		}
+	}
+
+	invalid := filepath.Join(tmpDir, "invalid.env")
+	if err := os.WriteFile(invalid, []byte("A=1\nnot a variable\n"), 0644); err != nil {
+		t.Fatalf("Failed to write env file: %v", err)
+	}
+	if _, err := ReadEnvFile(invalid); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("ReadEnvFile() error = %v, want it to name line 2", err)
+	}
+
+	if _, err := ReadEnvFile(filepath.Join(tmpDir, "missing.env")); err == nil {
+		t.Error("ReadEnvFile() should return error for a missing file")
+	}
+}
+
+func TestParseConfigWithVars(t *testing.T) {
+	t.Setenv("LUNGE_TEST_BASE_URL", "http://from-env")
+	t.Setenv("LUNGE_TEST_VUS", "3")
+
+	yamlContent := `
+name: "Env Test"
+settings:
+  baseUrl: "${LUNGE_TEST_BASE_URL:-http://default}"
+scenarios:
+  test:
+    executor: constant-vus
+    vus: ${LUNGE_TEST_VUS}
+    duration: 10s
+    requests:
+      - url: "{{baseUrl}}/api"
+`
+
+	config, err := ParseConfig([]byte(yamlContent), "test.yaml")
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+	if config.Settings.BaseURL != "http://from-env" {
+		t.Errorf("BaseURL = %v, want value from the environment", config.Settings.BaseURL)
+	}
+	if config.Scenarios["test"].VUs != 3 {
+		t.Errorf("VUs = %v, want 3", config.Scenarios["test"].VUs)
+	}
+
+	// Vars override the environment
+	config, err = ParseConfigWithVars([]byte(yamlContent), "test.yaml", map[string]string{"LUNGE_TEST_BASE_URL": "http://from-var"})
+	if err != nil {
+		t.Fatalf("ParseConfigWithVars() error = %v", err)
+	}
+	if config.Settings.BaseURL != "http://from-var" {
+		t.Errorf("BaseURL = %v, want value from vars", config.Settings.BaseURL)
+	}
+
+	_, err = ParseConfig([]byte("name: test\nsettings:\n  baseUrl: \"${LUNGE_TEST_MISSING:?base URL required}\"\n"), "test.yaml")
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("ParseConfig() error = %v, want it to name line 3", err)
+	}
+}
+
+func TestParseConfigWithVars_ValuesAreNotParsed(t *testing.T) {
+	vars := map[string]string{
+		"QUOTED":  `say "hi"`,
+		"MAPPING": "key: value",
+		"COMMENT": "a # b",
+		"LINES":   "one\ntwo",
+		"VUS":     "4",
+	}
+
+	yamlContent := `
+# Set ${TOKEN:?set me} before running, e.g. TOKEN=abc
+name: "${QUOTED}"
+variables:
+  plain: ${MAPPING}
+  quoted: "${MAPPING}"
+  comment: ${COMMENT}   # trailing ${ALSO:?ignored}
+  lines: "${LINES}"
+  single: '${QUOTED}'
+scenarios:
+  test:
+    executor: constant-vus
+    vus: ${VUS}
+    duration: 10s
+    requests:
+      - url: http://localhost
+`
+
+	config, err := ParseConfigWithVars([]byte(yamlContent), "test.yaml", vars)
+	if err != nil {
+		t.Fatalf("ParseConfigWithVars() error = %v", err)
+	}
+	if config.Name != `say "hi"` {
+		t.Errorf("Name = %q, want %q", config.Name, `say "hi"`)
+	}
+	want := map[string]string{
+		"plain":   "key: value",
+		"quoted":  "key: value",
+		"comment": "a # b",
+		"lines":   "one\ntwo",
+		"single":  `say "hi"`,
+	}
+	for name, value := range want {
+		if config.Variables[name] != value {
+			t.Errorf("variables.%s = %q, want %q", name, config.Variables[name], value)
+		}
+	}
+	if config.Scenarios["test"].VUs != 4 {
+		t.Errorf("VUs = %v, want 4", config.Scenarios["test"].VUs)
+	}
+
+	jsonContent := `{
+  "name": "${QUOTED}",
+  "variables": {"mapping": "${MAPPING}", "lines": "${LINES}", "${VUS}": "key"}
+}`
+	config, err = ParseConfigWithVars([]byte(jsonContent), "test.json", vars)
+	if err != nil {
+		t.Fatalf("ParseConfigWithVars() JSON error = %v", err)
+	}
+	if config.Name != `say "hi"` {
+		t.Errorf("JSON Name = %q, want %q", config.Name, `say "hi"`)
+	}
+	if config.Variables["mapping"] != "key: value" || config.Variables["lines"] != "one\ntwo" || config.Variables["4"] != "key" {
+		t.Errorf("JSON variables = %v", config.Variables)
+	}
+
+	_, err = ParseConfigWithVars([]byte(`{"settings": {"baseUrl": "${MISSING:?base URL required}"}}`), "test.json", vars)
+	if err == nil || !strings.Contains(err.Error(), "line 1: settings.baseUrl: MISSING: base URL required") {
+		t.Errorf("ParseConfigWithVars() JSON error = %v, want it to name the line and path", err)
+	}
+
+	jsonContent = `{
+  "name": "test",
+  "scenarios": {
+    "test": {"requests": [{"url": "/ok"}, {"url": "${BASE_URL"}]}
+  }
+}`
+	_, err = ParseConfigWithVars([]byte(jsonContent), "test.json", vars)
+	if err == nil || !strings.Contains(err.Error(), "line 4: scenarios.test.requests[1].url: unterminated") {
+		t.Errorf("ParseConfigWithVars() JSON error = %v, want unterminated reference on line 4", err)
+	}
+
+	_, err = ParseConfigWithVars([]byte("a: 1\nb: ${VUS\nc: 2}"), "test.yaml", vars)
+	if err == nil || !strings.Contains(err.Error(), "line 2: unterminated") {
+		t.Errorf("ParseConfigWithVars() error = %v, want unterminated reference on line 2", err)
+	}
+}
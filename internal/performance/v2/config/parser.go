@@ -1,10 +1,12 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,43 +19,205 @@ import (
 //   - .yaml, .yml -> YAML
 //   - .json -> JSON
 //
+// Relative scenario data file paths are resolved against the directory of
+// the config file.
+//
 // Returns the parsed TestConfig or an error if parsing fails.
 func LoadConfig(path string) (*TestConfig, error) {
+	return LoadConfigWithVars(path, nil)
+}
+
+// LoadConfigWithVars loads a test configuration from a file, expanding
+// ${VAR} references from vars before the environment.
+func LoadConfigWithVars(path string, vars map[string]string) (*TestConfig, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	return ParseConfig(data, path)
+	config, err := ParseConfigWithVars(data, path, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	// Data files are relative to the config file
+	dir := filepath.Dir(path)
+	for _, sc := range config.Scenarios {
+		if sc != nil && sc.Data != nil && sc.Data.File != "" && !filepath.IsAbs(sc.Data.File) {
+			sc.Data.File = filepath.Join(dir, sc.Data.File)
+		}
+	}
+
+	return config, nil
 }
 
 // ParseConfig parses configuration data.
 //
 // The format is determined by the file extension in path, or defaults to YAML
 // if the path is empty or has an unknown extension.
+//
+// ${VAR}, ${VAR:-default} and ${VAR:?error} references in string values
+// are expanded from the environment (see ExpandVariables).
 func ParseConfig(data []byte, path string) (*TestConfig, error) {
+	return ParseConfigWithVars(data, path, nil)
+}
+
+// ParseConfigWithVars parses configuration data, expanding ${VAR}
+// references from vars before the environment.
+//
+// References are expanded in the parsed values rather than the raw file,
+// so comments are ignored and values containing quotes, newlines or YAML
+// syntax cannot change the structure of the document.
+func ParseConfigWithVars(data []byte, path string, vars map[string]string) (*TestConfig, error) {
 	var config TestConfig
+	lookup := lookupVariable(vars)
 
 	ext := strings.ToLower(filepath.Ext(path))
 	switch ext {
 	case ".json":
+		if bytes.Contains(data, []byte("${")) {
+			var raw any
+			if err := json.Unmarshal(data, &raw); err != nil {
+				return nil, fmt.Errorf("failed to parse JSON config: %w", err)
+			}
+			expanded, err := expandJSON(data, lookup)
+			if err != nil {
+				return nil, fmt.Errorf("failed to expand variables in config: %w", err)
+			}
+			if data, err = json.Marshal(expanded); err != nil {
+				return nil, fmt.Errorf("failed to expand variables in config: %w", err)
+			}
+		}
 		if err := json.Unmarshal(data, &config); err != nil {
 			return nil, fmt.Errorf("failed to parse JSON config: %w", err)
 		}
 	case ".yaml", ".yml", "":
-		if err := yaml.Unmarshal(data, &config); err != nil {
-			return nil, fmt.Errorf("failed to parse YAML config: %w", err)
+		if err := parseYAML(data, lookup, &config, "failed to parse YAML config"); err != nil {
+			return nil, err
 		}
 	default:
 		// Try YAML by default
-		if err := yaml.Unmarshal(data, &config); err != nil {
-			return nil, fmt.Errorf("failed to parse config (unknown format %s): %w", ext, err)
+		if err := parseYAML(data, lookup, &config, fmt.Sprintf("failed to parse config (unknown format %s)", ext)); err != nil {
+			return nil, err
 		}
 	}
 
 	return &config, nil
 }
 
+// parseYAML decodes a YAML document into config after expanding the
+// variable references in its scalars. Parse errors are prefixed with
+// errPrefix.
+func parseYAML(data []byte, lookup func(name string) (string, bool), config *TestConfig, errPrefix string) error {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("%s: %w", errPrefix, err)
+	}
+	if root.Kind == 0 {
+		// Empty document
+		return nil
+	}
+	if err := expandYAML(&root, lookup); err != nil {
+		return fmt.Errorf("failed to expand variables in config: %w", err)
+	}
+	if err := root.Decode(config); err != nil {
+		return fmt.Errorf("%s: %w", errPrefix, err)
+	}
+	return nil
+}
+
+// Threshold metric names.
+const (
+	MetricHTTPReqDuration   = "http_req_duration"
+	MetricHTTPReqFailed     = "http_req_failed"
+	MetricHTTPReqs          = "http_reqs"
+	MetricDroppedIterations = "dropped_iterations"
+
+	// HTTP request phases
+	MetricHTTPReqBlocked        = "http_req_blocked"
+	MetricHTTPReqLookingUp      = "http_req_looking_up"
+	MetricHTTPReqConnecting     = "http_req_connecting"
+	MetricHTTPReqTLSHandshaking = "http_req_tls_handshaking"
+	MetricHTTPReqSending        = "http_req_sending"
+	MetricHTTPReqWaiting        = "http_req_waiting"
+	MetricHTTPReqReceiving      = "http_req_receiving"
+)
+
+// IsPhaseMetric reports whether name is the threshold metric of an HTTP
+// request phase, such as http_req_waiting.
+func IsPhaseMetric(name string) bool {
+	switch name {
+	case MetricHTTPReqBlocked, MetricHTTPReqLookingUp, MetricHTTPReqConnecting, MetricHTTPReqTLSHandshaking,
+		MetricHTTPReqSending, MetricHTTPReqWaiting, MetricHTTPReqReceiving:
+		return true
+	}
+	return false
+}
+
+// legacyThresholdSuffixes maps the suffixes of the legacy custom threshold
+// form "<scenario>_duration" to the metric they scope.
+var legacyThresholdSuffixes = map[string]string{
+	"_duration": MetricHTTPReqDuration,
+	"_failed":   MetricHTTPReqFailed,
+	"_reqs":     MetricHTTPReqs,
+}
+
+// ParseThresholdMetric parses a threshold key into its metric and scope.
+//
+// Supported forms:
+//   - Plain metric: "http_req_duration", or a request phase such as
+//     "http_req_waiting"
+//   - Scoped metric: "http_req_duration{scenario:api,name:Login}"
+//   - Legacy scenario form: "api_duration", "api_failed", "api_reqs"
+//     (equivalent to the metric scoped to scenario "api")
+func ParseThresholdMetric(key string) (ThresholdMetric, error) {
+	key = strings.TrimSpace(key)
+
+	name, rest, scoped := strings.Cut(key, "{")
+	name = strings.TrimSpace(name)
+
+	if !scoped {
+		switch {
+		case name == MetricHTTPReqDuration, name == MetricHTTPReqFailed, name == MetricHTTPReqs,
+			name == MetricDroppedIterations, IsPhaseMetric(name):
+			return ThresholdMetric{Name: name}, nil
+		}
+		for suffix, metric := range legacyThresholdSuffixes {
+			if scenario, ok := strings.CutSuffix(name, suffix); ok && scenario != "" {
+				return ThresholdMetric{Name: metric, Scope: map[string]string{"scenario": scenario}}, nil
+			}
+		}
+		return ThresholdMetric{}, fmt.Errorf("unknown threshold metric: %s", key)
+	}
+
+	switch {
+	case name == MetricHTTPReqDuration, name == MetricHTTPReqFailed, name == MetricHTTPReqs,
+		name == MetricDroppedIterations, IsPhaseMetric(name):
+	default:
+		return ThresholdMetric{}, fmt.Errorf("unknown threshold metric: %s", name)
+	}
+
+	body, ok := strings.CutSuffix(rest, "}")
+	if !ok {
+		return ThresholdMetric{}, fmt.Errorf("missing closing brace in threshold metric: %s", key)
+	}
+
+	scope := make(map[string]string)
+	for _, part := range strings.Split(body, ",") {
+		k, v, ok := strings.Cut(part, ":")
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+		if !ok || k == "" || v == "" {
+			return ThresholdMetric{}, fmt.Errorf("invalid scope %q in threshold metric %s (expected key:value)", strings.TrimSpace(part), key)
+		}
+		if _, dup := scope[k]; dup {
+			return ThresholdMetric{}, fmt.Errorf("duplicate scope key %q in threshold metric %s", k, key)
+		}
+		scope[k] = v
+	}
+
+	return ThresholdMetric{Name: name, Scope: scope}, nil
+}
+
 // ParseDurationString parses a duration string with support for common formats.
 //
 // Supported formats:
@@ -81,6 +245,39 @@ func ParseDurationString(s string) (time.Duration, error) {
 	return 0, fmt.Errorf("invalid duration format: %s", s)
 }
 
+// ParseStatusRange parses an expected status entry: a status code such as
+// "404", or an inclusive range such as "200-299".
+//
+// Returns the lowest and highest status codes of the range.
+func ParseStatusRange(s string) (min, max int, err error) {
+	lo, hi, isRange := strings.Cut(strings.TrimSpace(s), "-")
+	if !isRange {
+		hi = lo
+	}
+
+	min, err = parseStatusCode(lo)
+	if err != nil {
+		return 0, 0, err
+	}
+	max, err = parseStatusCode(hi)
+	if err != nil {
+		return 0, 0, err
+	}
+	if min > max {
+		return 0, 0, fmt.Errorf("invalid status range %q: start is after end", s)
+	}
+	return min, max, nil
+}
+
+// parseStatusCode parses a single HTTP status code.
+func parseStatusCode(s string) (int, error) {
+	code, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || code < 100 || code > 599 {
+		return 0, fmt.Errorf("invalid status code %q: must be between 100 and 599", strings.TrimSpace(s))
+	}
+	return code, nil
+}
+
 // ParseScenarioDuration parses the duration for a scenario config.
 //
 // For stage-based executors, if no explicit duration is set,
@@ -208,13 +405,23 @@ func applyScenarioDefaults(name string, sc *ScenarioConfig) {
 		}
 	}
 
-	// Default request names
-	for i, req := range sc.Requests {
+	applyRequestDefaults(name, sc.Requests)
+}
+
+// applyRequestDefaults applies default values to requests, including those
+// in groups. Unnamed requests are named after their scenario or group.
+func applyRequestDefaults(parent string, requests []RequestConfig) {
+	for i := range requests {
+		req := &requests[i]
+		if req.IsGroup() {
+			applyRequestDefaults(req.Group, req.Requests)
+			continue
+		}
 		if req.Name == "" {
-			sc.Requests[i].Name = fmt.Sprintf("%s_request_%d", name, i+1)
+			req.Name = fmt.Sprintf("%s_request_%d", parent, i+1)
 		}
 		if req.Method == "" {
-			sc.Requests[i].Method = "GET"
+			req.Method = "GET"
 		}
 	}
 }
@@ -70,6 +70,227 @@ func TestParseDurationString(t *testing.T) {
 	}
 }
 
+func TestParseStatusRange(t *testing.T) {
+	tests := []struct {
+		input   string
+		min     int
+		max     int
+		wantErr bool
+	}{
+		{input: "200", min: 200, max: 200},
+		{input: "200-299", min: 200, max: 299},
+		{input: " 400 - 404 ", min: 400, max: 404},
+		{input: "2xx", wantErr: true},
+		{input: "99", wantErr: true},
+		{input: "200-600", wantErr: true},
+		{input: "299-200", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			min, max, err := ParseStatusRange(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseStatusRange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if min != tt.min || max != tt.max {
+				t.Errorf("ParseStatusRange() = %d-%d, want %d-%d", min, max, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestParseConfig_ExpectedStatuses(t *testing.T) {
+	yamlConfig := `
+name: "Statuses"
+settings:
+  expectedStatuses: "200-399"
+scenarios:
+  api:
+    executor: constant-vus
+    vus: 1
+    duration: 10s
+    expectedStatuses: [200, "404"]
+    requests:
+      - method: PUT
+        url: "http://localhost/"
+        expectedStatuses:
+          - 201
+          - "409"
+          - 500-503
+`
+	config, err := ParseConfig([]byte(yamlConfig), "test.yaml")
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+
+	if got := config.Settings.ExpectedStatuses; len(got) != 1 || got[0] != "200-399" {
+		t.Errorf("Settings.ExpectedStatuses = %v, want [200-399]", got)
+	}
+	if got := config.Scenarios["api"].ExpectedStatuses; len(got) != 2 || got[0] != "200" || got[1] != "404" {
+		t.Errorf("Scenario ExpectedStatuses = %v, want [200 404]", got)
+	}
+	if got := config.Scenarios["api"].Requests[0].ExpectedStatuses; len(got) != 3 || got[0] != "201" || got[2] != "500-503" {
+		t.Errorf("Request ExpectedStatuses = %v, want [201 409 500-503]", got)
+	}
+
+	jsonConfig := `{
+		"name": "Statuses",
+		"settings": {"expectedStatuses": 204},
+		"scenarios": {"api": {"executor": "constant-vus", "vus": 1, "duration": "10s",
+			"requests": [{"method": "GET", "url": "http://localhost/", "expectedStatuses": [200, "300-302"]}]}}
+	}`
+	config, err = ParseConfig([]byte(jsonConfig), "test.json")
+	if err != nil {
+		t.Fatalf("ParseConfig() JSON error = %v", err)
+	}
+	if got := config.Settings.ExpectedStatuses; len(got) != 1 || got[0] != "204" {
+		t.Errorf("JSON Settings.ExpectedStatuses = %v, want [204]", got)
+	}
+	if got := config.Scenarios["api"].Requests[0].ExpectedStatuses; len(got) != 2 || got[0] != "200" || got[1] != "300-302" {
+		t.Errorf("JSON Request ExpectedStatuses = %v, want [200 300-302]", got)
+	}
+
+	invalid := `{"name": "x", "settings": {"expectedStatuses": [true]}, "scenarios": {}}`
+	if _, err := ParseConfig([]byte(invalid), "test.json"); err == nil {
+		t.Error("ParseConfig() should reject a non-numeric, non-string status")
+	}
+}
+
+func TestParseConfig_Groups(t *testing.T) {
+	yamlConfig := `
+name: "Groups"
+scenarios:
+  shop:
+    executor: constant-vus
+    vus: 1
+    duration: 10s
+    requests:
+      - name: "Home"
+        url: "http://localhost/"
+      - group: "checkout flow"
+        repeat: 2
+        requests:
+          - method: POST
+            url: "http://localhost/cart"
+          - group: "payment"
+            requests:
+              - method: POST
+                url: "http://localhost/pay"
+`
+	config, err := ParseConfig([]byte(yamlConfig), "test.yaml")
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+	ApplyDefaults(config)
+
+	requests := config.Scenarios["shop"].Requests
+	if len(requests) != 2 || requests[0].IsGroup() || !requests[1].IsGroup() {
+		t.Fatalf("Requests = %+v, want a request and a group", requests)
+	}
+
+	checkout := requests[1]
+	if checkout.Group != "checkout flow" || checkout.Repeat != 2 || len(checkout.Requests) != 2 {
+		t.Errorf("group = %q repeating %d with %d requests, want checkout flow x2 with 2", checkout.Group, checkout.Repeat, len(checkout.Requests))
+	}
+	if checkout.Method != "" || checkout.Name != "" {
+		t.Errorf("group Method = %q, Name = %q, want defaults left unset on groups", checkout.Method, checkout.Name)
+	}
+
+	// Unnamed requests in groups are named after their group
+	if got := checkout.Requests[0].Name; got != "checkout flow_request_1" {
+		t.Errorf("group request Name = %q, want checkout flow_request_1", got)
+	}
+	payment := checkout.Requests[1]
+	if !payment.IsGroup() || payment.Requests[0].Name != "payment_request_1" || payment.Requests[0].Method != "POST" {
+		t.Errorf("nested group = %+v, want payment with payment_request_1", payment)
+	}
+}
+
+func TestParseConfig_ConditionsAndLoops(t *testing.T) {
+	yamlConfig := `
+name: "Conditions"
+scenarios:
+  shop:
+    executor: constant-vus
+    vus: 1
+    duration: 10s
+    requests:
+      - name: "Add item"
+        method: POST
+        url: "http://localhost/cart"
+        when:
+          variable: cartCount
+          condition: eq
+          value: "0"
+      - name: "Poll job"
+        method: GET
+        url: "http://localhost/job"
+        repeat: 10
+        until:
+          source: status
+          condition: eq
+          value: "200"
+`
+	config, err := ParseConfig([]byte(yamlConfig), "test.yaml")
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	requests := config.Scenarios["shop"].Requests
+	when := requests[0].When
+	if when == nil || when.Variable != "cartCount" || when.Condition != "eq" || when.Value != "0" {
+		t.Errorf("When = %+v, want cartCount eq 0", when)
+	}
+
+	until := requests[1].Until
+	if requests[1].Repeat != 10 || until == nil || until.Source != "status" || until.Value != "200" {
+		t.Errorf("Repeat = %d, Until = %+v, want 10 runs until status eq 200", requests[1].Repeat, until)
+	}
+}
+
+func TestParseConfig_WeightedFlow(t *testing.T) {
+	yamlConfig := `
+name: "Traffic mix"
+scenarios:
+  shop:
+    executor: constant-vus
+    vus: 1
+    duration: 10s
+    flow: weighted
+    seed: 42
+    requests:
+      - name: "Search"
+        url: "http://localhost/search"
+        weight: 70
+      - group: "purchase"
+        weight: 30
+        requests:
+          - method: POST
+            url: "http://localhost/buy"
+`
+	config, err := ParseConfig([]byte(yamlConfig), "test.yaml")
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+	ApplyDefaults(config)
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	sc := config.Scenarios["shop"]
+	if sc.Flow != "weighted" || sc.Seed == nil || *sc.Seed != 42 {
+		t.Errorf("Flow = %q, Seed = %v, want weighted with seed 42", sc.Flow, sc.Seed)
+	}
+	if sc.Requests[0].Weight != 70 || sc.Requests[1].Weight != 30 {
+		t.Errorf("weights = %d and %d, want 70 and 30", sc.Requests[0].Weight, sc.Requests[1].Weight)
+	}
+}
+
 func TestParseConfig_YAML(t *testing.T) {
 	yamlConfig := `
 name: "Test Config"
@@ -225,6 +446,62 @@ scenarios:
 	}
 }
 
+func TestLoadConfig_DataFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	tmpFile := filepath.Join(tmpDir, "test-config.yaml")
+
+	yamlContent := `
+name: "Data Test"
+scenarios:
+  relative:
+    executor: constant-vus
+    vus: 5
+    duration: 10s
+    data:
+      file: data/users.csv
+      mode: unique
+      onExhausted: stop
+    requests:
+      - method: GET
+        url: "/users/{{data.id}}"
+  absolute:
+    executor: constant-vus
+    vus: 5
+    duration: 10s
+    data:
+      file: /srv/users.json
+    requests:
+      - method: GET
+        url: "/users/{{data.id}}"
+`
+	if err := os.WriteFile(tmpFile, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+
+	config, err := LoadConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	data := config.Scenarios["relative"].Data
+	if data == nil {
+		t.Fatal("Data should not be nil")
+	}
+	if want := filepath.Join(tmpDir, "data", "users.csv"); data.File != want {
+		t.Errorf("File = %v, want %v", data.File, want)
+	}
+	if data.Mode != "unique" || data.OnExhausted != "stop" {
+		t.Errorf("Mode = %v, OnExhausted = %v, want unique, stop", data.Mode, data.OnExhausted)
+	}
+	if data.EffectiveFormat() != "csv" {
+		t.Errorf("EffectiveFormat() = %v, want csv", data.EffectiveFormat())
+	}
+
+	if file := config.Scenarios["absolute"].Data.File; file != "/srv/users.json" {
+		t.Errorf("File = %v, want absolute path unchanged", file)
+	}
+}
+
 func TestLoadConfig_NotFound(t *testing.T) {
 	_, err := LoadConfig("/nonexistent/path/config.yaml")
 	if err == nil {
@@ -533,3 +810,254 @@ func TestDuration_MarshalJSON(t *testing.T) {
 		t.Errorf("MarshalJSON() = %v, want %v", string(got), expected)
 	}
 }
+
+func TestParseThresholdMetric(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       string
+		wantName  string
+		wantScope map[string]string
+		wantErr   bool
+	}{
+		{
+			name:     "plain metric",
+			key:      "http_req_duration",
+			wantName: "http_req_duration",
+		},
+		{
+			name:      "scenario scope",
+			key:       "http_req_duration{scenario:api}",
+			wantName:  "http_req_duration",
+			wantScope: map[string]string{"scenario": "api"},
+		},
+		{
+			name:      "multiple scopes with spaces",
+			key:       "http_req_failed{ scenario: api , name: Login }",
+			wantName:  "http_req_failed",
+			wantScope: map[string]string{"scenario": "api", "name": "Login"},
+		},
+		{
+			name:      "tag scope",
+			key:       "http_reqs{env:prod}",
+			wantName:  "http_reqs",
+			wantScope: map[string]string{"env": "prod"},
+		},
+		{
+			name:      "legacy duration",
+			key:       "api_integration_duration",
+			wantName:  "http_req_duration",
+			wantScope: map[string]string{"scenario": "api_integration"},
+		},
+		{
+			name:      "legacy failed",
+			key:       "checkout_failed",
+			wantName:  "http_req_failed",
+			wantScope: map[string]string{"scenario": "checkout"},
+		},
+		{
+			name:     "phase metric",
+			key:      "http_req_waiting",
+			wantName: "http_req_waiting",
+		},
+		{
+			name:      "scoped phase metric",
+			key:       "http_req_tls_handshaking{name:Login}",
+			wantName:  "http_req_tls_handshaking",
+			wantScope: map[string]string{"name": "Login"},
+		},
+		{
+			name:     "http_reqs is not legacy",
+			key:      "http_reqs",
+			wantName: "http_reqs",
+		},
+		{
+			name:    "unknown metric",
+			key:     "latency{scenario:api}",
+			wantErr: true,
+		},
+		{
+			name:    "unknown plain metric",
+			key:     "something",
+			wantErr: true,
+		},
+		{
+			name:    "missing brace",
+			key:     "http_req_duration{scenario:api",
+			wantErr: true,
+		},
+		{
+			name:    "missing value",
+			key:     "http_req_duration{scenario}",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate key",
+			key:     "http_req_duration{name:a,name:b}",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseThresholdMetric(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseThresholdMetric() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Name != tt.wantName {
+				t.Errorf("Name = %v, want %v", got.Name, tt.wantName)
+			}
+			if len(got.Scope) != len(tt.wantScope) {
+				t.Fatalf("Scope = %v, want %v", got.Scope, tt.wantScope)
+			}
+			for k, v := range tt.wantScope {
+				if got.Scope[k] != v {
+					t.Errorf("Scope[%s] = %v, want %v", k, got.Scope[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestParseConfig_ScopedThresholds(t *testing.T) {
+	yamlConfig := `
+name: "Scoped"
+scenarios:
+  api:
+    executor: constant-vus
+    vus: 1
+    duration: 10s
+    requests:
+      - method: GET
+        url: "http://localhost/"
+thresholds:
+  http_req_duration:
+    - "p95 < 500ms"
+  http_req_duration{scenario:api}:
+    - "p95 < 300ms"
+  http_req_waiting:
+    - "p95 < 200ms"
+  custom:
+    api_failed:
+      - "rate < 0.01"
+`
+	config, err := ParseConfig([]byte(yamlConfig), "test.yaml")
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+
+	if len(config.Thresholds.HTTPReqDuration) != 1 {
+		t.Errorf("len(HTTPReqDuration) = %d, want 1", len(config.Thresholds.HTTPReqDuration))
+	}
+	if got := config.Thresholds.Custom["http_req_duration{scenario:api}"]; len(got) != 1 || got[0].Expression != "p95 < 300ms" {
+		t.Errorf("Custom[http_req_duration{scenario:api}] = %v, want [p95 < 300ms]", got)
+	}
+	if got := config.Thresholds.Custom["http_req_waiting"]; len(got) != 1 || got[0].Expression != "p95 < 200ms" {
+		t.Errorf("Custom[http_req_waiting] = %v, want [p95 < 200ms]", got)
+	}
+	if got := config.Thresholds.Custom["api_failed"]; len(got) != 1 {
+		t.Errorf("Custom[api_failed] = %v, want 1 expression", got)
+	}
+
+	jsonConfig := `{
+		"name": "Scoped",
+		"scenarios": {"api": {"executor": "constant-vus", "vus": 1, "duration": "10s",
+			"requests": [{"method": "GET", "url": "http://localhost/"}]}},
+		"thresholds": {
+			"http_reqs": ["count > 0"],
+			"http_req_failed{name:Login}": ["rate < 0.05"]
+		}
+	}`
+	config, err = ParseConfig([]byte(jsonConfig), "test.json")
+	if err != nil {
+		t.Fatalf("ParseConfig() JSON error = %v", err)
+	}
+	if len(config.Thresholds.HTTPReqs) != 1 {
+		t.Errorf("len(HTTPReqs) = %d, want 1", len(config.Thresholds.HTTPReqs))
+	}
+	if got := config.Thresholds.Custom["http_req_failed{name:Login}"]; len(got) != 1 {
+		t.Errorf("Custom[http_req_failed{name:Login}] = %v, want 1 expression", got)
+	}
+
+	if _, err := ParseConfig([]byte("thresholds:\n  http_req_duration{scenario:api}: \"p95 < 1s\"\n"), "test.yaml"); err == nil {
+		t.Error("ParseConfig() should reject a scoped threshold that is not a list")
+	}
+}
+
+func TestParseConfig_AbortThresholds(t *testing.T) {
+	yamlConfig := `
+name: "Abort"
+scenarios:
+  api:
+    executor: constant-vus
+    vus: 1
+    duration: 10s
+    requests:
+      - method: GET
+        url: "http://localhost/"
+thresholds:
+  http_req_duration:
+    - "p95 < 500ms"
+    - threshold: "p99 < 2s"
+      abortOnFail: true
+      delayAbortEval: 30s
+  http_req_failed{scenario:api}:
+    - threshold: "rate < 0.1"
+      abortOnFail: true
+`
+	config, err := ParseConfig([]byte(yamlConfig), "test.yaml")
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+
+	durations := config.Thresholds.HTTPReqDuration
+	if len(durations) != 2 {
+		t.Fatalf("len(HTTPReqDuration) = %d, want 2", len(durations))
+	}
+	if durations[0].Expression != "p95 < 500ms" || durations[0].AbortOnFail {
+		t.Errorf("HTTPReqDuration[0] = %+v, want plain p95 < 500ms", durations[0])
+	}
+	if durations[1].Expression != "p99 < 2s" || !durations[1].AbortOnFail || durations[1].DelayAbortEval != Duration(30*time.Second) {
+		t.Errorf("HTTPReqDuration[1] = %+v, want p99 < 2s aborting after 30s", durations[1])
+	}
+	if scoped := config.Thresholds.Custom["http_req_failed{scenario:api}"]; len(scoped) != 1 || !scoped[0].AbortOnFail {
+		t.Errorf("Custom[http_req_failed{scenario:api}] = %+v, want one abortOnFail threshold", scoped)
+	}
+
+	jsonConfig := `{
+		"name": "Abort",
+		"scenarios": {"api": {"executor": "constant-vus", "vus": 1, "duration": "10s",
+			"requests": [{"method": "GET", "url": "http://localhost/"}]}},
+		"thresholds": {
+			"http_req_failed": ["rate < 0.5", {"threshold": "rate < 0.1", "abortOnFail": true, "delayAbortEval": "10s"}]
+		}
+	}`
+	config, err = ParseConfig([]byte(jsonConfig), "test.json")
+	if err != nil {
+		t.Fatalf("ParseConfig() JSON error = %v", err)
+	}
+	failed := config.Thresholds.HTTPReqFailed
+	if len(failed) != 2 || failed[0].Expression != "rate < 0.5" || !failed[1].AbortOnFail || failed[1].DelayAbortEval != Duration(10*time.Second) {
+		t.Errorf("HTTPReqFailed = %+v, want a plain and an abortOnFail threshold", failed)
+	}
+}
+
+func TestThreshold_MarshalJSON(t *testing.T) {
+	got, err := Threshold{Expression: "count == 0"}.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+	if string(got) != `"count == 0"` {
+		t.Errorf("MarshalJSON() = %s, want a plain string", got)
+	}
+
+	got, err = Threshold{Expression: "count == 0", AbortOnFail: true}.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+	if string(got) != `{"threshold":"count == 0","abortOnFail":true}` {
+		t.Errorf("MarshalJSON() = %s, want an object", got)
+	}
+}
@@ -2,7 +2,14 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // TestConfig is the root configuration for a performance test.
@@ -35,10 +42,18 @@ type TestConfig struct {
 	// Variables are global variables available to all scenarios
 	Variables map[string]string `json:"variables,omitempty" yaml:"variables,omitempty"`
 
+	// Setup requests run once before any scenario starts. Variables they
+	// extract are available read-only to every VU and to teardown.
+	Setup []RequestConfig `json:"setup,omitempty" yaml:"setup,omitempty"`
+
 	// Scenarios defines the load profiles to run
 	// Each scenario runs independently with its own executor
 	Scenarios map[string]*ScenarioConfig `json:"scenarios" yaml:"scenarios"`
 
+	// Teardown requests run once after all scenarios finish, including when
+	// they fail or the test is stopped early
+	Teardown []RequestConfig `json:"teardown,omitempty" yaml:"teardown,omitempty"`
+
 	// Thresholds define pass/fail criteria for metrics
 	Thresholds *ThresholdsConfig `json:"thresholds,omitempty" yaml:"thresholds,omitempty"`
 
@@ -68,12 +83,21 @@ type GlobalSettings struct {
 
 	// Headers are default headers applied to all requests
 	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+
+	// ExpectedStatuses are the response statuses that count as success
+	// (default: any status below 400)
+	ExpectedStatuses StatusList `json:"expectedStatuses,omitempty" yaml:"expectedStatuses,omitempty"`
+
+	// Traceparent sends a W3C traceparent header with each request, so
+	// server-side traces can be linked to the requests that caused them
+	Traceparent bool `json:"traceparent,omitempty" yaml:"traceparent,omitempty"`
 }
 
 // ScenarioConfig defines a single load testing scenario.
 type ScenarioConfig struct {
 	// Executor specifies the load generation strategy
-	// Options: "constant-vus", "ramping-vus", "constant-arrival-rate", "ramping-arrival-rate"
+	// Options: "constant-vus", "ramping-vus", "constant-arrival-rate", "ramping-arrival-rate",
+	// "per-vu-iterations", "shared-iterations", "externally-controlled"
 	Executor string `json:"executor" yaml:"executor"`
 
 	// VUs is the number of virtual users (for VU-based executors)
@@ -82,32 +106,92 @@ type ScenarioConfig struct {
 	// Duration is how long to run (e.g., "30s", "2m", "1h")
 	Duration string `json:"duration,omitempty" yaml:"duration,omitempty"`
 
+	// Iterations is the iteration count (for iteration-based executors)
+	Iterations int `json:"iterations,omitempty" yaml:"iterations,omitempty"`
+
+	// MaxDuration caps how long iteration-based executors may run (default: 10m)
+	MaxDuration string `json:"maxDuration,omitempty" yaml:"maxDuration,omitempty"`
+
 	// Rate is iterations per second (for arrival-rate executors)
 	Rate float64 `json:"rate,omitempty" yaml:"rate,omitempty"`
 
 	// PreAllocatedVUs is the number of VUs to pre-allocate (for arrival-rate executors)
 	PreAllocatedVUs int `json:"preAllocatedVUs,omitempty" yaml:"preAllocatedVUs,omitempty"`
 
-	// MaxVUs is the maximum number of VUs to scale up to (for arrival-rate executors)
+	// MaxVUs is the maximum number of VUs to scale up to (for arrival-rate and
+	// externally-controlled executors)
 	MaxVUs int `json:"maxVUs,omitempty" yaml:"maxVUs,omitempty"`
 
+	// LatencyFromIntendedStart measures latency from each iteration's scheduled
+	// start rather than the actual send time (for arrival-rate executors)
+	LatencyFromIntendedStart bool `json:"latencyFromIntendedStart,omitempty" yaml:"latencyFromIntendedStart,omitempty"`
+
 	// Stages defines ramping stages (for ramping executors)
 	Stages []StageConfig `json:"stages,omitempty" yaml:"stages,omitempty"`
 
 	// Requests defines the HTTP requests to execute
 	Requests []RequestConfig `json:"requests" yaml:"requests"`
 
+	// Flow is how iterations run the requests: "sequential" (default) runs
+	// them all in order, "weighted" runs one per iteration, picked at random
+	// in proportion to the requests' weights
+	Flow string `json:"flow,omitempty" yaml:"flow,omitempty"`
+
+	// Seed makes the picks of a weighted flow reproducible (optional)
+	Seed *int64 `json:"seed,omitempty" yaml:"seed,omitempty"`
+
 	// GracefulStop is how long to wait for iterations to finish
 	GracefulStop string `json:"gracefulStop,omitempty" yaml:"gracefulStop,omitempty"`
 
 	// Pacing controls time between iterations
 	Pacing *PacingConfig `json:"pacing,omitempty" yaml:"pacing,omitempty"`
 
+	// Data is a CSV or JSON file whose rows parameterize iterations
+	Data *DataConfig `json:"data,omitempty" yaml:"data,omitempty"`
+
 	// StartTime specifies when this scenario should start (relative to test start)
 	StartTime string `json:"startTime,omitempty" yaml:"startTime,omitempty"`
 
 	// Tags are custom tags for this scenario's metrics
 	Tags map[string]string `json:"tags,omitempty" yaml:"tags,omitempty"`
+
+	// ExpectedStatuses are the response statuses that count as success
+	// for this scenario's requests (overrides global)
+	ExpectedStatuses StatusList `json:"expectedStatuses,omitempty" yaml:"expectedStatuses,omitempty"`
+}
+
+// DataConfig defines a data file that parameterizes a scenario.
+//
+// Each iteration is given a row of the file, whose fields are available
+// as {{data.<column>}}.
+type DataConfig struct {
+	// File is the path to the data file, relative to the config file
+	File string `json:"file" yaml:"file"`
+
+	// Format is "csv" or "json" (default: from the file extension)
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+
+	// Mode selects rows: "sequential", "random", "unique", "per-vu" (default: sequential)
+	Mode string `json:"mode,omitempty" yaml:"mode,omitempty"`
+
+	// OnExhausted is what happens when rows run out: "wrap", "stop", "abort"
+	// (default: wrap, or stop in unique mode, which cannot wrap)
+	OnExhausted string `json:"onExhausted,omitempty" yaml:"onExhausted,omitempty"`
+}
+
+// EffectiveFormat returns the data file format, derived from the file
+// extension when not set. It returns "" if the format cannot be determined.
+func (d *DataConfig) EffectiveFormat() string {
+	if d.Format != "" {
+		return strings.ToLower(d.Format)
+	}
+	switch strings.ToLower(filepath.Ext(d.File)) {
+	case ".csv":
+		return "csv"
+	case ".json":
+		return "json"
+	}
+	return ""
 }
 
 // StageConfig defines a single stage in a ramping executor.
@@ -150,6 +234,44 @@ type RequestConfig struct {
 
 	// Assertions validate the response
 	Assertions []AssertionConfig `json:"assertions,omitempty" yaml:"assertions,omitempty"`
+
+	// ExpectedStatuses are the response statuses that count as success
+	// (overrides scenario and global)
+	ExpectedStatuses StatusList `json:"expectedStatuses,omitempty" yaml:"expectedStatuses,omitempty"`
+
+	// Group makes this entry a named group of requests instead of a single
+	// request. A group's duration is measured over all its requests,
+	// including think time. Only when, repeat, until, weight and requests
+	// may be set on a group.
+	Group string `json:"group,omitempty" yaml:"group,omitempty"`
+
+	// When makes the request or group run only if the condition holds;
+	// otherwise it is skipped
+	When *ConditionConfig `json:"when,omitempty" yaml:"when,omitempty"`
+
+	// Repeat is how many times in a row the request or group runs
+	// (default: 1). With until, it is the maximum number of runs.
+	Repeat int `json:"repeat,omitempty" yaml:"repeat,omitempty"`
+
+	// Until ends the repetition as soon as the condition holds after a run
+	Until *ConditionConfig `json:"until,omitempty" yaml:"until,omitempty"`
+
+	// Weight is the relative share of iterations that pick this request or
+	// group in a weighted flow
+	Weight int `json:"weight,omitempty" yaml:"weight,omitempty"`
+
+	// Requests are the requests of a group, which may include other groups
+	Requests []RequestConfig `json:"requests,omitempty" yaml:"requests,omitempty"`
+}
+
+// GroupSeparator joins the names of nested groups into the group name used
+// in metrics, e.g. "checkout::payment".
+const GroupSeparator = "::"
+
+// IsGroup returns true if the entry is a group of requests rather than a
+// single request.
+func (r *RequestConfig) IsGroup() bool {
+	return r.Group != ""
 }
 
 // PacingConfig controls pacing between iterations.
@@ -200,22 +322,202 @@ type AssertionConfig struct {
 	Message string `json:"message,omitempty" yaml:"message,omitempty"`
 }
 
+// ConditionConfig is a condition on a VU's state that decides whether a
+// request or group runs (when) or stops repeating (until).
+//
+// Example YAML:
+//
+//	when:
+//	  variable: cartCount
+//	  condition: eq
+//	  value: "0"
+type ConditionConfig struct {
+	// Source is what is tested: "variable" (default) or "status", the status
+	// code of the VU's most recent response in the iteration
+	Source string `json:"source,omitempty" yaml:"source,omitempty"`
+
+	// Variable is the name of the variable tested by the variable source;
+	// unset variables are empty
+	Variable string `json:"variable,omitempty" yaml:"variable,omitempty"`
+
+	// Condition is the comparison: "eq", "ne", "gt", "lt", "gte", "lte", "contains", "matches"
+	Condition string `json:"condition" yaml:"condition"`
+
+	// Value is the value to compare against (supports variable substitution)
+	Value string `json:"value" yaml:"value"`
+}
+
 // ThresholdsConfig defines pass/fail criteria for the test.
 type ThresholdsConfig struct {
 	// HTTPReqDuration thresholds for request duration
 	// e.g., ["p95 < 500ms", "avg < 200ms"]
-	HTTPReqDuration []string `json:"http_req_duration,omitempty" yaml:"http_req_duration,omitempty"`
+	HTTPReqDuration []Threshold `json:"http_req_duration,omitempty" yaml:"http_req_duration,omitempty"`
 
 	// HTTPReqFailed thresholds for failure rate
 	// e.g., ["rate < 0.01"] (less than 1% failures)
-	HTTPReqFailed []string `json:"http_req_failed,omitempty" yaml:"http_req_failed,omitempty"`
+	HTTPReqFailed []Threshold `json:"http_req_failed,omitempty" yaml:"http_req_failed,omitempty"`
 
 	// HTTPReqs thresholds for request count/rate
 	// e.g., ["count > 1000", "rate > 100"]
-	HTTPReqs []string `json:"http_reqs,omitempty" yaml:"http_reqs,omitempty"`
+	HTTPReqs []Threshold `json:"http_reqs,omitempty" yaml:"http_reqs,omitempty"`
+
+	// DroppedIterations thresholds for iterations arrival-rate executors could not start
+	// e.g., ["count == 0", "rate < 1"]
+	DroppedIterations []Threshold `json:"dropped_iterations,omitempty" yaml:"dropped_iterations,omitempty"`
+
+	// Custom thresholds for scoped metrics and HTTP request phases, keyed
+	// by metric name with an optional scope, e.g.
+	// "http_req_duration{scenario:api}", "http_req_failed{name:Login}" or
+	// "http_req_waiting" (see ParseThresholdMetric).
+	//
+	// These keys may also be written directly under thresholds; they are
+	// collected here when the config is parsed.
+	Custom map[string][]Threshold `json:"custom,omitempty" yaml:"custom,omitempty"`
+}
+
+// Threshold is a single threshold expression.
+//
+// In config files it is either a plain expression, or an object that can
+// also stop the test early when the threshold is crossed:
+//
+//	http_req_duration:
+//	  - "p95 < 500ms"
+//	  - threshold: "p99 < 2s"
+//	    abortOnFail: true
+//	    delayAbortEval: 30s
+type Threshold struct {
+	// Expression is the threshold, e.g. "p95 < 500ms"
+	Expression string `json:"threshold" yaml:"threshold"`
+
+	// AbortOnFail stops the test as soon as the threshold fails while running
+	AbortOnFail bool `json:"abortOnFail,omitempty" yaml:"abortOnFail,omitempty"`
+
+	// DelayAbortEval is how long after the start of the test to wait before
+	// evaluating the threshold for abortOnFail, so early noise is ignored
+	DelayAbortEval Duration `json:"delayAbortEval,omitempty" yaml:"delayAbortEval,omitempty"`
+}
+
+// isPlain reports whether the threshold has no options besides its expression.
+func (t Threshold) isPlain() bool {
+	return !t.AbortOnFail && t.DelayAbortEval == 0
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t Threshold) MarshalJSON() ([]byte, error) {
+	if t.isPlain() {
+		return json.Marshal(t.Expression)
+	}
+	type plain Threshold
+	return json.Marshal(plain(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Threshold) UnmarshalJSON(b []byte) error {
+	var expr string
+	if err := json.Unmarshal(b, &expr); err == nil {
+		*t = Threshold{Expression: expr}
+		return nil
+	}
+
+	type plain Threshold
+	return json.Unmarshal(b, (*plain)(t))
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (t Threshold) MarshalYAML() (interface{}, error) {
+	if t.isPlain() {
+		return t.Expression, nil
+	}
+	type plain Threshold
+	return plain(t), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (t *Threshold) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var expr string
+	if err := unmarshal(&expr); err == nil {
+		*t = Threshold{Expression: expr}
+		return nil
+	}
+
+	type plain Threshold
+	return unmarshal((*plain)(t))
+}
 
-	// Custom thresholds for scenario-specific metrics
-	Custom map[string][]string `json:"custom,omitempty" yaml:"custom,omitempty"`
+// thresholdFields are the threshold keys with a dedicated field.
+var thresholdFields = map[string]bool{
+	"http_req_duration":  true,
+	"http_req_failed":    true,
+	"http_reqs":          true,
+	"dropped_iterations": true,
+	"custom":             true,
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *ThresholdsConfig) UnmarshalJSON(b []byte) error {
+	type plain ThresholdsConfig
+	if err := json.Unmarshal(b, (*plain)(t)); err != nil {
+		return err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	for key, value := range raw {
+		if thresholdFields[key] {
+			continue
+		}
+		var thresholds []Threshold
+		if err := json.Unmarshal(value, &thresholds); err != nil {
+			return fmt.Errorf("threshold %s: expected a list of thresholds", key)
+		}
+		t.addCustom(key, thresholds)
+	}
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (t *ThresholdsConfig) UnmarshalYAML(value *yaml.Node) error {
+	type plain ThresholdsConfig
+	if err := value.Decode((*plain)(t)); err != nil {
+		return err
+	}
+
+	// Mapping nodes hold keys and values alternately
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		key := value.Content[i].Value
+		if thresholdFields[key] {
+			continue
+		}
+		var thresholds []Threshold
+		if err := value.Content[i+1].Decode(&thresholds); err != nil {
+			return fmt.Errorf("threshold %s: expected a list of thresholds", key)
+		}
+		t.addCustom(key, thresholds)
+	}
+	return nil
+}
+
+// addCustom appends thresholds to a custom threshold key.
+func (t *ThresholdsConfig) addCustom(key string, thresholds []Threshold) {
+	if t.Custom == nil {
+		t.Custom = make(map[string][]Threshold)
+	}
+	t.Custom[key] = append(t.Custom[key], thresholds...)
+}
+
+// ThresholdMetric identifies the metric a threshold applies to.
+type ThresholdMetric struct {
+	// Name is the base metric: http_req_duration, http_req_failed,
+	// http_reqs or dropped_iterations
+	Name string
+
+	// Scope narrows the metric to a subset of requests. The "scenario" key
+	// selects a scenario, "name" selects requests by name, and any other
+	// key selects scenarios by tag. Empty for the whole test.
+	Scope map[string]string
 }
 
 // ExecutionOptions controls test execution behavior.
@@ -226,10 +528,10 @@ type ExecutionOptions struct {
 	// IterationsTimeout is the maximum time to wait for iterations to complete
 	IterationsTimeout string `json:"iterationsTimeout,omitempty" yaml:"iterationsTimeout,omitempty"`
 
-	// SetupTimeout is the maximum time for setup operations
+	// SetupTimeout is the maximum time for the setup requests (default 60s)
 	SetupTimeout string `json:"setupTimeout,omitempty" yaml:"setupTimeout,omitempty"`
 
-	// TeardownTimeout is the maximum time for teardown operations
+	// TeardownTimeout is the maximum time for the teardown requests (default 60s)
 	TeardownTimeout string `json:"teardownTimeout,omitempty" yaml:"teardownTimeout,omitempty"`
 
 	// NoVUConnectionReuse disables HTTP connection reuse between VUs
@@ -307,3 +609,51 @@ func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
 func (d Duration) String() string {
 	return time.Duration(d).String()
 }
+
+// StatusList is a list of HTTP status codes and inclusive ranges, such as
+// [200, 404, "200-299"].
+//
+// Codes may be written as numbers or strings; a single code or range may
+// be given without a list.
+type StatusList []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *StatusList) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		raw = []json.RawMessage{b}
+	}
+
+	list := make(StatusList, 0, len(raw))
+	for _, entry := range raw {
+		var status string
+		if err := json.Unmarshal(entry, &status); err != nil {
+			var code int
+			if err := json.Unmarshal(entry, &code); err != nil {
+				return fmt.Errorf("invalid status %s: must be a status code or range", entry)
+			}
+			status = strconv.Itoa(code)
+		}
+		list = append(list, status)
+	}
+	*s = list
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (s *StatusList) UnmarshalYAML(value *yaml.Node) error {
+	entries := []*yaml.Node{value}
+	if value.Kind == yaml.SequenceNode {
+		entries = value.Content
+	}
+
+	list := make(StatusList, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Kind != yaml.ScalarNode {
+			return fmt.Errorf("line %d: invalid status: must be a status code or range", entry.Line)
+		}
+		list = append(list, entry.Value)
+	}
+	*s = list
+	return nil
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"net/url"
+	"regexp"
 	"strings"
 )
 
@@ -65,9 +66,12 @@ func (c *TestConfig) Validate() error {
 		validateScenario(name, scenario, &c.Settings, errs)
 	}
 
+	// Validate setup and teardown
+	validateLifecycle(c, errs)
+
 	// Validate thresholds
 	if c.Thresholds != nil {
-		validateThresholds(c.Thresholds, errs)
+		validateThresholds(c.Thresholds, c.Scenarios, errs)
 	}
 
 	// Validate settings
@@ -91,6 +95,7 @@ func validateScenario(name string, sc *ScenarioConfig, settings *GlobalSettings,
 		"ramping-arrival-rate":  true,
 		"per-vu-iterations":     true,
 		"shared-iterations":     true,
+		"externally-controlled": true,
 	}
 
 	if sc.Executor == "" {
@@ -111,6 +116,14 @@ func validateScenario(name string, sc *ScenarioConfig, settings *GlobalSettings,
 		validateRampingArrivalRate(prefix, sc, errs)
 	case "per-vu-iterations", "shared-iterations":
 		validateIterationBased(prefix, sc, errs)
+	case "externally-controlled":
+		validateExternallyControlled(prefix, sc, errs)
+	}
+
+	arrivalRate := sc.Executor == "constant-arrival-rate" || sc.Executor == "ramping-arrival-rate" ||
+		(sc.Executor == "externally-controlled" && sc.Rate > 0)
+	if sc.LatencyFromIntendedStart && !arrivalRate {
+		errs.Add(prefix+".latencyFromIntendedStart", "only supported by arrival-rate executors")
 	}
 
 	// Validate requests
@@ -118,8 +131,19 @@ func validateScenario(name string, sc *ScenarioConfig, settings *GlobalSettings,
 		errs.Add(prefix+".requests", "at least one request is required")
 	}
 
+	validFlows := map[string]bool{"": true, "sequential": true, "weighted": true}
+	if !validFlows[sc.Flow] {
+		errs.Add(prefix+".flow", fmt.Sprintf("unknown flow: %s (expected sequential or weighted)", sc.Flow))
+	}
+	weighted := sc.Flow == "weighted"
+	if sc.Seed != nil && !weighted {
+		errs.Add(prefix+".seed", "only supported with flow: weighted")
+	}
+
 	for i, req := range sc.Requests {
-		validateRequest(fmt.Sprintf("%s.requests[%d]", prefix, i), &req, settings, errs)
+		reqPrefix := fmt.Sprintf("%s.requests[%d]", prefix, i)
+		validateRequest(reqPrefix, &req, settings, errs)
+		validateWeight(reqPrefix, &req, weighted, errs)
 	}
 
 	// Validate pacing
@@ -127,10 +151,87 @@ func validateScenario(name string, sc *ScenarioConfig, settings *GlobalSettings,
 		validatePacing(prefix+".pacing", sc.Pacing, errs)
 	}
 
+	// Validate data file
+	if sc.Data != nil {
+		validateData(prefix+".data", sc.Data, errs)
+	}
+
 	// Validate stages
 	for i, stage := range sc.Stages {
 		validateStage(fmt.Sprintf("%s.stages[%d]", prefix, i), &stage, errs)
 	}
+
+	validateStatusList(prefix+".expectedStatuses", sc.ExpectedStatuses, errs)
+}
+
+// validateLifecycle validates the setup and teardown requests and their timeouts.
+func validateLifecycle(c *TestConfig, errs *ValidationErrors) {
+	setupVars := make(map[string]bool)
+	for i, req := range c.Setup {
+		if req.IsGroup() {
+			errs.Add(fmt.Sprintf("setup[%d]", i), "groups are not supported in setup")
+			continue
+		}
+		validateLifecycleFlow(fmt.Sprintf("setup[%d]", i), &req, errs)
+		validateRequest(fmt.Sprintf("setup[%d]", i), &req, &c.Settings, errs)
+		for _, extract := range req.Extract {
+			setupVars[extract.Name] = true
+		}
+	}
+
+	for i, req := range c.Teardown {
+		if req.IsGroup() {
+			errs.Add(fmt.Sprintf("teardown[%d]", i), "groups are not supported in teardown")
+			continue
+		}
+		validateLifecycleFlow(fmt.Sprintf("teardown[%d]", i), &req, errs)
+		validateRequest(fmt.Sprintf("teardown[%d]", i), &req, &c.Settings, errs)
+	}
+
+	// Setup variables are read-only in scenarios
+	for name, sc := range c.Scenarios {
+		if sc == nil {
+			continue
+		}
+		validateSetupVarsUnchanged(fmt.Sprintf("scenarios.%s", name), sc.Requests, setupVars, errs)
+	}
+
+	if c.Options != nil {
+		if c.Options.SetupTimeout != "" {
+			if _, err := ParseDurationString(c.Options.SetupTimeout); err != nil {
+				errs.Add("options.setupTimeout", fmt.Sprintf("invalid duration: %v", err))
+			}
+		}
+		if c.Options.TeardownTimeout != "" {
+			if _, err := ParseDurationString(c.Options.TeardownTimeout); err != nil {
+				errs.Add("options.teardownTimeout", fmt.Sprintf("invalid duration: %v", err))
+			}
+		}
+	}
+}
+
+// validateLifecycleFlow checks that a setup or teardown request, which
+// always runs exactly once, has no when, repeat, until or weight.
+func validateLifecycleFlow(prefix string, req *RequestConfig, errs *ValidationErrors) {
+	if req.When != nil || req.Repeat != 0 || req.Until != nil {
+		errs.Add(prefix, "when, repeat and until are not supported in setup and teardown")
+	}
+	validateWeight(prefix, req, false, errs)
+}
+
+// validateSetupVarsUnchanged checks that requests, including those in
+// groups, don't extract into setup variables.
+func validateSetupVarsUnchanged(prefix string, requests []RequestConfig, setupVars map[string]bool, errs *ValidationErrors) {
+	for i, req := range requests {
+		reqPrefix := fmt.Sprintf("%s.requests[%d]", prefix, i)
+		validateSetupVarsUnchanged(reqPrefix, req.Requests, setupVars, errs)
+		for j, extract := range req.Extract {
+			if setupVars[extract.Name] {
+				errs.Add(fmt.Sprintf("%s.extract[%d].name", reqPrefix, j),
+					fmt.Sprintf("%s is a setup variable and cannot be overwritten", extract.Name))
+			}
+		}
+	}
 }
 
 // validateConstantVUs validates constant-vus executor config.
@@ -203,11 +304,70 @@ func validateIterationBased(prefix string, sc *ScenarioConfig, errs *ValidationE
 		errs.Add(prefix+".vus", "vus must be greater than 0")
 	}
 
-	// Note: iterations would need to be added to ScenarioConfig if these executors are fully implemented
+	if sc.Iterations <= 0 {
+		errs.Add(prefix+".iterations", fmt.Sprintf("iterations must be greater than 0 for %s executor", sc.Executor))
+	}
+
+	if sc.MaxDuration != "" {
+		if _, err := ParseDurationString(sc.MaxDuration); err != nil {
+			errs.Add(prefix+".maxDuration", fmt.Sprintf("invalid maxDuration: %v", err))
+		}
+	}
+}
+
+// validateExternallyControlled validates externally-controlled executor config.
+//
+// Without a rate, the executor runs VUs and vus is the starting count;
+// with one, it runs at an arrival rate on up to maxVUs VUs.
+func validateExternallyControlled(prefix string, sc *ScenarioConfig, errs *ValidationErrors) {
+	if sc.Rate < 0 {
+		errs.Add(prefix+".rate", "rate cannot be negative")
+	}
+	if sc.VUs < 0 {
+		errs.Add(prefix+".vus", "vus cannot be negative")
+	}
+	if sc.MaxVUs < 0 {
+		errs.Add(prefix+".maxVUs", "maxVUs cannot be negative")
+	}
+
+	if sc.Rate > 0 {
+		if sc.VUs > 0 {
+			errs.Add(prefix+".vus", "not supported with rate; use preAllocatedVUs and maxVUs")
+		}
+		if sc.PreAllocatedVUs < 0 {
+			errs.Add(prefix+".preAllocatedVUs", "preAllocatedVUs cannot be negative")
+		}
+		if sc.MaxVUs > 0 && sc.PreAllocatedVUs > sc.MaxVUs {
+			errs.Add(prefix+".preAllocatedVUs", "preAllocatedVUs cannot be greater than maxVUs")
+		}
+	} else if sc.Rate == 0 {
+		if sc.VUs == 0 && sc.MaxVUs == 0 {
+			errs.Add(prefix+".maxVUs", "vus or maxVUs must be greater than 0")
+		}
+		if sc.MaxVUs > 0 && sc.VUs > sc.MaxVUs {
+			errs.Add(prefix+".vus", "vus cannot be greater than maxVUs")
+		}
+	}
+
+	if sc.Duration != "" {
+		if _, err := ParseDurationString(sc.Duration); err != nil {
+			errs.Add(prefix+".duration", fmt.Sprintf("invalid duration: %v", err))
+		}
+	}
 }
 
 // validateRequest validates a single request configuration.
 func validateRequest(prefix string, req *RequestConfig, settings *GlobalSettings, errs *ValidationErrors) {
+	validateFlow(prefix, req, errs)
+
+	if req.IsGroup() {
+		validateGroup(prefix, req, settings, errs)
+		return
+	}
+	if len(req.Requests) > 0 {
+		errs.Add(prefix+".requests", "only supported on groups")
+	}
+
 	// Validate method
 	validMethods := map[string]bool{
 		"GET": true, "POST": true, "PUT": true, "DELETE": true,
@@ -269,6 +429,134 @@ func validateRequest(prefix string, req *RequestConfig, settings *GlobalSettings
 	for i, assertion := range req.Assertions {
 		validateAssertion(fmt.Sprintf("%s.assertions[%d]", prefix, i), &assertion, errs)
 	}
+
+	validateStatusList(prefix+".expectedStatuses", req.ExpectedStatuses, errs)
+}
+
+// validateGroup validates a group of requests.
+func validateGroup(prefix string, group *RequestConfig, settings *GlobalSettings, errs *ValidationErrors) {
+	if group.Method != "" || group.URL != "" || group.Body != "" || len(group.Headers) > 0 ||
+		len(group.Extract) > 0 || len(group.Assertions) > 0 || len(group.ExpectedStatuses) > 0 ||
+		group.Timeout != "" || group.ThinkTime != "" || group.Name != "" {
+		errs.Add(prefix, fmt.Sprintf("group %q can only have when, repeat, until, weight and requests", group.Group))
+	}
+	if strings.Contains(group.Group, GroupSeparator) {
+		errs.Add(prefix+".group", fmt.Sprintf("group name cannot contain %q", GroupSeparator))
+	}
+
+	if len(group.Requests) == 0 {
+		errs.Add(prefix+".requests", "at least one request is required")
+	}
+	for i, req := range group.Requests {
+		reqPrefix := fmt.Sprintf("%s.requests[%d]", prefix, i)
+		validateRequest(reqPrefix, &req, settings, errs)
+		validateWeight(reqPrefix, &req, false, errs)
+	}
+}
+
+// validateFlow validates the when, repeat and until settings of a request
+// or group.
+func validateFlow(prefix string, req *RequestConfig, errs *ValidationErrors) {
+	if req.Repeat < 0 {
+		errs.Add(prefix+".repeat", "cannot be negative")
+	}
+	if req.When != nil {
+		validateCondition(prefix+".when", req.When, errs)
+	}
+	if req.Until != nil {
+		validateCondition(prefix+".until", req.Until, errs)
+		if req.Repeat == 0 {
+			errs.Add(prefix+".repeat", "repeat is required with until, as the maximum number of runs")
+		}
+	}
+}
+
+// validateWeight validates the weight of a request or group. Weights are
+// required on the top-level requests of a weighted scenario, and not
+// allowed anywhere else.
+func validateWeight(prefix string, req *RequestConfig, weighted bool, errs *ValidationErrors) {
+	switch {
+	case weighted && req.Weight <= 0:
+		errs.Add(prefix+".weight", "must be positive in a weighted flow")
+	case !weighted && req.Weight != 0:
+		errs.Add(prefix+".weight", "only supported on the top-level requests of a weighted flow")
+	}
+}
+
+// validateCondition validates a when or until condition.
+func validateCondition(prefix string, cond *ConditionConfig, errs *ValidationErrors) {
+	switch cond.Source {
+	case "", "variable":
+		if cond.Variable == "" {
+			errs.Add(prefix+".variable", "variable is required")
+		}
+	case "status":
+		if cond.Variable != "" {
+			errs.Add(prefix+".variable", "only supported for the variable source")
+		}
+	default:
+		errs.Add(prefix+".source", fmt.Sprintf("invalid source: %s", cond.Source))
+	}
+
+	validConditions := map[string]bool{
+		"eq": true, "ne": true, "gt": true, "lt": true,
+		"gte": true, "lte": true, "contains": true, "matches": true,
+	}
+
+	if cond.Condition == "" {
+		errs.Add(prefix+".condition", "condition is required")
+	} else if !validConditions[cond.Condition] {
+		errs.Add(prefix+".condition", fmt.Sprintf("invalid condition: %s", cond.Condition))
+	}
+
+	// Patterns containing variables can only be compiled at runtime
+	if cond.Condition == "matches" && !strings.Contains(cond.Value, "{{") {
+		if _, err := regexp.Compile(cond.Value); err != nil {
+			errs.Add(prefix+".value", fmt.Sprintf("invalid regular expression: %v", err))
+		}
+	}
+}
+
+// validateStatusList validates a list of expected status codes and ranges.
+func validateStatusList(prefix string, statuses StatusList, errs *ValidationErrors) {
+	for i, status := range statuses {
+		if _, _, err := ParseStatusRange(status); err != nil {
+			errs.Add(fmt.Sprintf("%s[%d]", prefix, i), err.Error())
+		}
+	}
+}
+
+// validateData validates a scenario's data file configuration.
+func validateData(prefix string, data *DataConfig, errs *ValidationErrors) {
+	if data.File == "" {
+		errs.Add(prefix+".file", "file is required")
+	}
+
+	switch data.EffectiveFormat() {
+	case "csv", "json":
+	case "":
+		if data.File != "" {
+			errs.Add(prefix+".format", fmt.Sprintf("cannot infer format of %s; set format to csv or json", data.File))
+		}
+	default:
+		errs.Add(prefix+".format", fmt.Sprintf("invalid format: %s (must be csv or json)", data.Format))
+	}
+
+	validModes := map[string]bool{
+		"": true, "sequential": true, "random": true, "unique": true, "per-vu": true,
+	}
+	if !validModes[data.Mode] {
+		errs.Add(prefix+".mode", fmt.Sprintf("invalid mode: %s", data.Mode))
+	}
+
+	validActions := map[string]bool{
+		"": true, "wrap": true, "stop": true, "abort": true,
+	}
+	if !validActions[data.OnExhausted] {
+		errs.Add(prefix+".onExhausted", fmt.Sprintf("invalid onExhausted: %s", data.OnExhausted))
+	} else if data.Mode == "unique" && data.OnExhausted == "wrap" {
+		errs.Add(prefix+".onExhausted", "unique mode cannot wrap (must be stop or abort)")
+	}
 }
 
 // validatePacing validates pacing configuration.
@@ -341,6 +629,18 @@ func validateExtract(prefix string, extract *ExtractConfig, errs *ValidationErro
 	} else if !validSources[extract.Source] {
 		errs.Add(prefix+".source", fmt.Sprintf("invalid source: %s", extract.Source))
 	}
+
+	if extract.Source == "header" && extract.Path == "" {
+		errs.Add(prefix+".path", "path (header name) is required for header extraction")
+	}
+
+	if extract.Regex != "" {
+		if extract.Source == "status" {
+			errs.Add(prefix+".regex", "regex is only supported for body and header extraction")
+		} else if _, err := regexp.Compile(extract.Regex); err != nil {
+			errs.Add(prefix+".regex", fmt.Sprintf("invalid regular expression: %v", err))
+		}
+	}
 }
 
 // validateAssertion validates an assertion configuration.
@@ -365,41 +665,116 @@ func validateAssertion(prefix string, assertion *AssertionConfig, errs *Validati
 	} else if !validConditions[assertion.Condition] {
 		errs.Add(prefix+".condition", fmt.Sprintf("invalid condition: %s", assertion.Condition))
 	}
+
+	if assertion.Type == "header" && assertion.Path == "" {
+		errs.Add(prefix+".path", "path (header name) is required for header assertions")
+	}
+
+	// Patterns containing variables can only be compiled at runtime
+	if assertion.Condition == "matches" && !strings.Contains(assertion.Value, "{{") {
+		if _, err := regexp.Compile(assertion.Value); err != nil {
+			errs.Add(prefix+".value", fmt.Sprintf("invalid regular expression: %v", err))
+		}
+	}
 }
 
 // validateThresholds validates threshold configuration.
-func validateThresholds(t *ThresholdsConfig, errs *ValidationErrors) {
+func validateThresholds(t *ThresholdsConfig, scenarios map[string]*ScenarioConfig, errs *ValidationErrors) {
 	// Validate duration thresholds
 	for i, threshold := range t.HTTPReqDuration {
-		if err := validateThresholdExpression(threshold); err != nil {
+		if err := validateThreshold(threshold); err != nil {
 			errs.Add(fmt.Sprintf("thresholds.http_req_duration[%d]", i), err.Error())
 		}
 	}
 
 	// Validate failure rate thresholds
 	for i, threshold := range t.HTTPReqFailed {
-		if err := validateThresholdExpression(threshold); err != nil {
+		if err := validateThreshold(threshold); err != nil {
 			errs.Add(fmt.Sprintf("thresholds.http_req_failed[%d]", i), err.Error())
 		}
 	}
 
 	// Validate request count thresholds
 	for i, threshold := range t.HTTPReqs {
-		if err := validateThresholdExpression(threshold); err != nil {
+		if err := validateThreshold(threshold); err != nil {
 			errs.Add(fmt.Sprintf("thresholds.http_reqs[%d]", i), err.Error())
 		}
 	}
 
+	// Validate dropped iteration thresholds
+	for i, threshold := range t.DroppedIterations {
+		if err := validateThreshold(threshold); err != nil {
+			errs.Add(fmt.Sprintf("thresholds.dropped_iterations[%d]", i), err.Error())
+		}
+	}
+
 	// Validate custom thresholds
 	for name, thresholds := range t.Custom {
+		if err := validateThresholdMetric(name, scenarios); err != nil {
+			errs.Add(fmt.Sprintf("thresholds.custom.%s", name), err.Error())
+		}
 		for i, threshold := range thresholds {
-			if err := validateThresholdExpression(threshold); err != nil {
+			if err := validateThreshold(threshold); err != nil {
 				errs.Add(fmt.Sprintf("thresholds.custom.%s[%d]", name, i), err.Error())
 			}
 		}
 	}
 }
 
+// validateThreshold validates a threshold expression and its abort options.
+func validateThreshold(t Threshold) error {
+	if err := validateThresholdExpression(t.Expression); err != nil {
+		return err
+	}
+	if t.DelayAbortEval < 0 {
+		return fmt.Errorf("delayAbortEval cannot be negative")
+	}
+	if t.DelayAbortEval > 0 && !t.AbortOnFail {
+		return fmt.Errorf("delayAbortEval requires abortOnFail")
+	}
+	return nil
+}
+
+// validateThresholdMetric validates a custom threshold key and checks that
+// its scope can match something.
+func validateThresholdMetric(key string, scenarios map[string]*ScenarioConfig) error {
+	metric, err := ParseThresholdMetric(key)
+	if err != nil {
+		return err
+	}
+
+	// Phase metrics have no dedicated field, so they may be unscoped
+	if len(metric.Scope) == 0 && !IsPhaseMetric(metric.Name) {
+		return fmt.Errorf("custom threshold %s has no scope; use thresholds.%s instead", key, metric.Name)
+	}
+
+	for k, v := range metric.Scope {
+		switch k {
+		case "scenario":
+			if _, ok := scenarios[v]; !ok {
+				return fmt.Errorf("threshold %s references unknown scenario %q", key, v)
+			}
+		case "name":
+			if metric.Name == MetricDroppedIterations {
+				return fmt.Errorf("dropped_iterations cannot be scoped by request name")
+			}
+		default:
+			found := false
+			for _, sc := range scenarios {
+				if sc != nil && sc.Tags[k] == v {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("threshold %s references tag %s:%s, which no scenario has", key, k, v)
+			}
+		}
+	}
+
+	return nil
+}
+
 // validateThresholdExpression validates a threshold expression.
 //
 // Valid formats:
@@ -462,4 +837,6 @@ func validateSettings(s *GlobalSettings, errs *ValidationErrors) {
 	if s.MaxIdleConnsPerHost < 0 {
 		errs.Add("settings.maxIdleConnsPerHost", "cannot be negative")
 	}
+
+	validateStatusList("settings.expectedStatuses", s.ExpectedStatuses, errs)
 }
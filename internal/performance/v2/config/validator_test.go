@@ -3,6 +3,7 @@ package config
 import (
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestValidate_MinimalValid(t *testing.T) {
@@ -219,6 +220,29 @@ func TestValidate_ConstantArrivalRate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "preallocatedvus",
 		},
+		{
+			name: "valid with latencyFromIntendedStart",
+			config: &ScenarioConfig{
+				Executor:                 "constant-arrival-rate",
+				Rate:                     100,
+				Duration:                 "1m",
+				LatencyFromIntendedStart: true,
+				Requests:                 []RequestConfig{{Method: "GET", URL: "/test"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "latencyFromIntendedStart on closed-model executor",
+			config: &ScenarioConfig{
+				Executor:                 "constant-vus",
+				VUs:                      10,
+				Duration:                 "1m",
+				LatencyFromIntendedStart: true,
+				Requests:                 []RequestConfig{{Method: "GET", URL: "/test"}},
+			},
+			wantErr: true,
+			errMsg:  "latencyfromintendedstart",
+		},
 	}
 
 	for _, tt := range tests {
@@ -297,6 +321,223 @@ func TestValidate_RampingArrivalRate(t *testing.T) {
 	}
 }
 
+func TestValidate_IterationBased(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *ScenarioConfig
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "valid",
+			config: &ScenarioConfig{
+				Executor:   "per-vu-iterations",
+				VUs:        5,
+				Iterations: 10,
+				Requests:   []RequestConfig{{Method: "GET", URL: "/test"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid with maxDuration",
+			config: &ScenarioConfig{
+				Executor:    "per-vu-iterations",
+				VUs:         5,
+				Iterations:  10,
+				MaxDuration: "2m",
+				Requests:    []RequestConfig{{Method: "GET", URL: "/test"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid shared-iterations",
+			config: &ScenarioConfig{
+				Executor:   "shared-iterations",
+				VUs:        5,
+				Iterations: 100,
+				Requests:   []RequestConfig{{Method: "GET", URL: "/test"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "shared-iterations missing iterations",
+			config: &ScenarioConfig{
+				Executor: "shared-iterations",
+				VUs:      5,
+				Requests: []RequestConfig{{Method: "GET", URL: "/test"}},
+			},
+			wantErr: true,
+			errMsg:  "iterations",
+		},
+		{
+			name: "missing iterations",
+			config: &ScenarioConfig{
+				Executor: "per-vu-iterations",
+				VUs:      5,
+				Requests: []RequestConfig{{Method: "GET", URL: "/test"}},
+			},
+			wantErr: true,
+			errMsg:  "iterations",
+		},
+		{
+			name: "missing vus",
+			config: &ScenarioConfig{
+				Executor:   "per-vu-iterations",
+				Iterations: 10,
+				Requests:   []RequestConfig{{Method: "GET", URL: "/test"}},
+			},
+			wantErr: true,
+			errMsg:  "vus",
+		},
+		{
+			name: "invalid maxDuration",
+			config: &ScenarioConfig{
+				Executor:    "per-vu-iterations",
+				VUs:         5,
+				Iterations:  10,
+				MaxDuration: "forever",
+				Requests:    []RequestConfig{{Method: "GET", URL: "/test"}},
+			},
+			wantErr: true,
+			errMsg:  "maxduration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &TestConfig{
+				Name:      "Test",
+				Scenarios: map[string]*ScenarioConfig{"test": tt.config},
+			}
+
+			err := config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr && tt.errMsg != "" && !strings.Contains(strings.ToLower(err.Error()), tt.errMsg) {
+				t.Errorf("Error should contain '%s', got: %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestValidate_ExternallyControlled(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *ScenarioConfig
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "valid vus",
+			config: &ScenarioConfig{
+				Executor: "externally-controlled",
+				VUs:      5,
+				MaxVUs:   50,
+				Requests: []RequestConfig{{Method: "GET", URL: "/test"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid rate with duration",
+			config: &ScenarioConfig{
+				Executor:                 "externally-controlled",
+				Rate:                     10,
+				MaxVUs:                   20,
+				Duration:                 "10m",
+				LatencyFromIntendedStart: true,
+				Requests:                 []RequestConfig{{Method: "GET", URL: "/test"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "no vus or maxVUs",
+			config: &ScenarioConfig{
+				Executor: "externally-controlled",
+				Requests: []RequestConfig{{Method: "GET", URL: "/test"}},
+			},
+			wantErr: true,
+			errMsg:  "vus or maxvus must be greater than 0",
+		},
+		{
+			name: "vus greater than maxVUs",
+			config: &ScenarioConfig{
+				Executor: "externally-controlled",
+				VUs:      10,
+				MaxVUs:   5,
+				Requests: []RequestConfig{{Method: "GET", URL: "/test"}},
+			},
+			wantErr: true,
+			errMsg:  "vus cannot be greater than maxvus",
+		},
+		{
+			name: "vus with rate",
+			config: &ScenarioConfig{
+				Executor: "externally-controlled",
+				VUs:      5,
+				Rate:     10,
+				Requests: []RequestConfig{{Method: "GET", URL: "/test"}},
+			},
+			wantErr: true,
+			errMsg:  "not supported with rate",
+		},
+		{
+			name: "negative rate",
+			config: &ScenarioConfig{
+				Executor: "externally-controlled",
+				VUs:      5,
+				Rate:     -1,
+				Requests: []RequestConfig{{Method: "GET", URL: "/test"}},
+			},
+			wantErr: true,
+			errMsg:  "rate cannot be negative",
+		},
+		{
+			name: "latencyFromIntendedStart without rate",
+			config: &ScenarioConfig{
+				Executor:                 "externally-controlled",
+				VUs:                      5,
+				LatencyFromIntendedStart: true,
+				Requests:                 []RequestConfig{{Method: "GET", URL: "/test"}},
+			},
+			wantErr: true,
+			errMsg:  "latencyfromintendedstart",
+		},
+		{
+			name: "invalid duration",
+			config: &ScenarioConfig{
+				Executor: "externally-controlled",
+				VUs:      5,
+				Duration: "a while",
+				Requests: []RequestConfig{{Method: "GET", URL: "/test"}},
+			},
+			wantErr: true,
+			errMsg:  "invalid duration",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &TestConfig{
+				Name:      "Test",
+				Scenarios: map[string]*ScenarioConfig{"test": tt.config},
+			}
+
+			err := config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr && tt.errMsg != "" && !strings.Contains(strings.ToLower(err.Error()), tt.errMsg) {
+				t.Errorf("Error should contain '%s', got: %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
 func TestValidate_InvalidExecutor(t *testing.T) {
 	config := &TestConfig{
 		Name: "Test",
@@ -371,6 +612,47 @@ func TestValidate_Requests(t *testing.T) {
 			request: RequestConfig{Method: "GET", URL: "/test", Timeout: "30s"},
 			wantErr: false,
 		},
+		{
+			name: "valid assertions",
+			request: RequestConfig{Method: "GET", URL: "/test", Assertions: []AssertionConfig{
+				{Type: "status", Condition: "eq", Value: "200"},
+				{Type: "header", Condition: "matches", Value: "^application/json", Path: "Content-Type"},
+			}},
+			wantErr: false,
+		},
+		{
+			name: "header assertion without path",
+			request: RequestConfig{Method: "GET", URL: "/test", Assertions: []AssertionConfig{
+				{Type: "header", Condition: "eq", Value: "x"},
+			}},
+			wantErr: true,
+			errMsg:  "header name",
+		},
+		{
+			name: "invalid assertion regex",
+			request: RequestConfig{Method: "GET", URL: "/test", Assertions: []AssertionConfig{
+				{Type: "body", Condition: "matches", Value: "([a-z"},
+			}},
+			wantErr: true,
+			errMsg:  "regular expression",
+		},
+		{
+			name:    "valid expected statuses",
+			request: RequestConfig{Method: "GET", URL: "/test", ExpectedStatuses: StatusList{"200-299", "404"}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid expected status",
+			request: RequestConfig{Method: "GET", URL: "/test", ExpectedStatuses: StatusList{"2xx"}},
+			wantErr: true,
+			errMsg:  "expectedstatuses[0]",
+		},
+		{
+			name:    "reversed expected status range",
+			request: RequestConfig{Method: "GET", URL: "/test", ExpectedStatuses: StatusList{"200", "299-200"}},
+			wantErr: true,
+			errMsg:  "expectedstatuses[1]",
+		},
 	}
 
 	for _, tt := range tests {
@@ -482,6 +764,99 @@ func TestValidate_Pacing(t *testing.T) {
 	}
 }
 
+func TestValidate_Data(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    *DataConfig
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "valid csv",
+			data:    &DataConfig{File: "users.csv"},
+			wantErr: false,
+		},
+		{
+			name:    "valid json with mode and policy",
+			data:    &DataConfig{File: "users.json", Mode: "unique", OnExhausted: "abort"},
+			wantErr: false,
+		},
+		{
+			name:    "explicit format",
+			data:    &DataConfig{File: "users.txt", Format: "csv", Mode: "per-vu"},
+			wantErr: false,
+		},
+		{
+			name:    "missing file",
+			data:    &DataConfig{Format: "csv"},
+			wantErr: true,
+			errMsg:  "file is required",
+		},
+		{
+			name:    "unknown extension",
+			data:    &DataConfig{File: "users.txt"},
+			wantErr: true,
+			errMsg:  "cannot infer format",
+		},
+		{
+			name:    "invalid format",
+			data:    &DataConfig{File: "users.csv", Format: "xml"},
+			wantErr: true,
+			errMsg:  "invalid format",
+		},
+		{
+			name:    "invalid mode",
+			data:    &DataConfig{File: "users.csv", Mode: "shuffle"},
+			wantErr: true,
+			errMsg:  "invalid mode",
+		},
+		{
+			name:    "invalid onExhausted",
+			data:    &DataConfig{File: "users.csv", OnExhausted: "retry"},
+			wantErr: true,
+			errMsg:  "invalid onexhausted",
+		},
+		{
+			name:    "unique mode defaults to stop",
+			data:    &DataConfig{File: "users.csv", Mode: "unique"},
+			wantErr: false,
+		},
+		{
+			name:    "unique mode cannot wrap",
+			data:    &DataConfig{File: "users.csv", Mode: "unique", OnExhausted: "wrap"},
+			wantErr: true,
+			errMsg:  "unique mode cannot wrap",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &TestConfig{
+				Name: "Test",
+				Scenarios: map[string]*ScenarioConfig{
+					"test": {
+						Executor: "constant-vus",
+						VUs:      10,
+						Duration: "30s",
+						Data:     tt.data,
+						Requests: []RequestConfig{{Method: "GET", URL: "/test"}},
+					},
+				},
+			}
+
+			err := config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr && tt.errMsg != "" && !strings.Contains(strings.ToLower(err.Error()), tt.errMsg) {
+				t.Errorf("Error should contain '%s', got: %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
 func TestValidate_Stages(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -555,61 +930,176 @@ func TestValidate_Thresholds(t *testing.T) {
 		{
 			name: "valid duration threshold",
 			thresholds: &ThresholdsConfig{
-				HTTPReqDuration: []string{"p95 < 500ms"},
+				HTTPReqDuration: []Threshold{{Expression: "p95 < 500ms"}},
 			},
 			wantErr: false,
 		},
 		{
 			name: "valid failure rate threshold",
 			thresholds: &ThresholdsConfig{
-				HTTPReqFailed: []string{"rate < 0.01"},
+				HTTPReqFailed: []Threshold{{Expression: "rate < 0.01"}},
 			},
 			wantErr: false,
 		},
 		{
 			name: "valid request count threshold",
 			thresholds: &ThresholdsConfig{
-				HTTPReqs: []string{"count > 1000"},
+				HTTPReqs: []Threshold{{Expression: "count > 1000"}},
 			},
 			wantErr: false,
 		},
 		{
-			name: "multiple thresholds",
+			name: "valid dropped iterations threshold",
 			thresholds: &ThresholdsConfig{
-				HTTPReqDuration: []string{"p95 < 500ms", "avg < 200ms"},
-				HTTPReqFailed:   []string{"rate < 0.01"},
+				DroppedIterations: []Threshold{{Expression: "count == 0"}},
 			},
 			wantErr: false,
 		},
 		{
-			name: "empty threshold",
+			name: "invalid dropped iterations threshold",
 			thresholds: &ThresholdsConfig{
-				HTTPReqDuration: []string{""},
+				DroppedIterations: []Threshold{{Expression: "dropped < 5"}},
 			},
 			wantErr: true,
-			errMsg:  "empty",
+			errMsg:  "dropped_iterations",
 		},
 		{
-			name: "invalid threshold format",
+			name: "multiple thresholds",
 			thresholds: &ThresholdsConfig{
-				HTTPReqDuration: []string{"invalid threshold"},
+				HTTPReqDuration: []Threshold{{Expression: "p95 < 500ms"}, {Expression: "avg < 200ms"}},
+				HTTPReqFailed:   []Threshold{{Expression: "rate < 0.01"}},
 			},
-			wantErr: true,
-			errMsg:  "metric",
+			wantErr: false,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			config := &TestConfig{
-				Name: "Test",
-				Scenarios: map[string]*ScenarioConfig{
-					"test": {
-						Executor: "constant-vus",
-						VUs:      10,
-						Duration: "30s",
-						Requests: []RequestConfig{{Method: "GET", URL: "/test"}},
-					},
+		{
+			name: "valid scenario-scoped threshold",
+			thresholds: &ThresholdsConfig{
+				Custom: map[string][]Threshold{"http_req_duration{scenario:test}": {{Expression: "p95 < 300ms"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid legacy scenario threshold",
+			thresholds: &ThresholdsConfig{
+				Custom: map[string][]Threshold{"test_failed": {{Expression: "rate < 0.01"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid name and tag scoped threshold",
+			thresholds: &ThresholdsConfig{
+				Custom: map[string][]Threshold{"http_req_duration{name:Login,env:prod}": {{Expression: "p99 < 1s"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown scenario in scope",
+			thresholds: &ThresholdsConfig{
+				Custom: map[string][]Threshold{"http_req_duration{scenario:missing}": {{Expression: "p95 < 300ms"}}},
+			},
+			wantErr: true,
+			errMsg:  "unknown scenario",
+		},
+		{
+			name: "unknown scenario in legacy form",
+			thresholds: &ThresholdsConfig{
+				Custom: map[string][]Threshold{"api_integration_duration": {{Expression: "p95 < 300ms"}}},
+			},
+			wantErr: true,
+			errMsg:  "unknown scenario",
+		},
+		{
+			name: "unmatched tag scope",
+			thresholds: &ThresholdsConfig{
+				Custom: map[string][]Threshold{"http_reqs{env:staging}": {{Expression: "count > 0"}}},
+			},
+			wantErr: true,
+			errMsg:  "no scenario has",
+		},
+		{
+			name: "unknown custom metric",
+			thresholds: &ThresholdsConfig{
+				Custom: map[string][]Threshold{"latency{scenario:test}": {{Expression: "p95 < 300ms"}}},
+			},
+			wantErr: true,
+			errMsg:  "unknown threshold metric",
+		},
+		{
+			name: "custom threshold without scope",
+			thresholds: &ThresholdsConfig{
+				Custom: map[string][]Threshold{"http_req_duration": {{Expression: "p95 < 300ms"}}},
+			},
+			wantErr: true,
+			errMsg:  "no scope",
+		},
+		{
+			name: "phase threshold without scope",
+			thresholds: &ThresholdsConfig{
+				Custom: map[string][]Threshold{"http_req_waiting": {{Expression: "p95 < 200ms"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "dropped iterations scoped by name",
+			thresholds: &ThresholdsConfig{
+				Custom: map[string][]Threshold{"dropped_iterations{name:Login}": {{Expression: "count == 0"}}},
+			},
+			wantErr: true,
+			errMsg:  "request name",
+		},
+		{
+			name: "valid abortOnFail threshold",
+			thresholds: &ThresholdsConfig{
+				HTTPReqFailed: []Threshold{{Expression: "rate < 0.1", AbortOnFail: true, DelayAbortEval: Duration(30 * time.Second)}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "delayAbortEval without abortOnFail",
+			thresholds: &ThresholdsConfig{
+				HTTPReqFailed: []Threshold{{Expression: "rate < 0.1", DelayAbortEval: Duration(30 * time.Second)}},
+			},
+			wantErr: true,
+			errMsg:  "requires abortonfail",
+		},
+		{
+			name: "negative delayAbortEval",
+			thresholds: &ThresholdsConfig{
+				HTTPReqFailed: []Threshold{{Expression: "rate < 0.1", AbortOnFail: true, DelayAbortEval: Duration(-time.Second)}},
+			},
+			wantErr: true,
+			errMsg:  "negative",
+		},
+		{
+			name: "empty threshold",
+			thresholds: &ThresholdsConfig{
+				HTTPReqDuration: []Threshold{{Expression: ""}},
+			},
+			wantErr: true,
+			errMsg:  "empty",
+		},
+		{
+			name: "invalid threshold format",
+			thresholds: &ThresholdsConfig{
+				HTTPReqDuration: []Threshold{{Expression: "invalid threshold"}},
+			},
+			wantErr: true,
+			errMsg:  "metric",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &TestConfig{
+				Name: "Test",
+				Scenarios: map[string]*ScenarioConfig{
+					"test": {
+						Executor: "constant-vus",
+						VUs:      10,
+						Duration: "30s",
+						Tags:     map[string]string{"env": "prod"},
+						Requests: []RequestConfig{{Method: "GET", URL: "/test"}},
+					},
 				},
 				Thresholds: tt.thresholds,
 			}
@@ -690,6 +1180,29 @@ func TestValidate_Extract(t *testing.T) {
 			wantErr: true,
 			errMsg:  "source",
 		},
+		{
+			name:    "valid body regex",
+			extract: ExtractConfig{Name: "csrf", Source: "body", Regex: `name="csrf" value="([^"]+)"`},
+			wantErr: false,
+		},
+		{
+			name:    "header without name",
+			extract: ExtractConfig{Name: "token", Source: "header"},
+			wantErr: true,
+			errMsg:  "header name",
+		},
+		{
+			name:    "invalid regex",
+			extract: ExtractConfig{Name: "test", Source: "body", Regex: "([a-z"},
+			wantErr: true,
+			errMsg:  "regular expression",
+		},
+		{
+			name:    "regex on status",
+			extract: ExtractConfig{Name: "test", Source: "status", Regex: "2.."},
+			wantErr: true,
+			errMsg:  "regex",
+		},
 	}
 
 	for _, tt := range tests {
@@ -725,6 +1238,415 @@ func TestValidate_Extract(t *testing.T) {
 	}
 }
 
+func TestValidate_Groups(t *testing.T) {
+	get := RequestConfig{Method: "GET", URL: "/test"}
+
+	tests := []struct {
+		name    string
+		request RequestConfig
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "valid group",
+			request: RequestConfig{Group: "checkout", Repeat: 2, Requests: []RequestConfig{get, get}},
+			wantErr: false,
+		},
+		{
+			name: "valid nested group",
+			request: RequestConfig{Group: "checkout", Requests: []RequestConfig{
+				get, {Group: "payment", Requests: []RequestConfig{get}},
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "empty group",
+			request: RequestConfig{Group: "checkout"},
+			wantErr: true,
+			errMsg:  "requests",
+		},
+		{
+			name:    "group with request fields",
+			request: RequestConfig{Group: "checkout", Method: "GET", URL: "/test", Requests: []RequestConfig{get}},
+			wantErr: true,
+			errMsg:  "can only have when, repeat, until, weight and requests",
+		},
+		{
+			name:    "negative repeat",
+			request: RequestConfig{Group: "checkout", Repeat: -1, Requests: []RequestConfig{get}},
+			wantErr: true,
+			errMsg:  "repeat",
+		},
+		{
+			name:    "separator in group name",
+			request: RequestConfig{Group: "a::b", Requests: []RequestConfig{get}},
+			wantErr: true,
+			errMsg:  "cannot contain",
+		},
+		{
+			name:    "invalid request in group",
+			request: RequestConfig{Group: "checkout", Requests: []RequestConfig{{Method: "GET"}}},
+			wantErr: true,
+			errMsg:  "requests[0].requests[0].url",
+		},
+		{
+			name:    "requests on a request",
+			request: RequestConfig{Method: "GET", URL: "/test", Requests: []RequestConfig{get}},
+			wantErr: true,
+			errMsg:  "only supported on groups",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &TestConfig{
+				Name: "Test",
+				Scenarios: map[string]*ScenarioConfig{
+					"test": {
+						Executor: "constant-vus",
+						VUs:      10,
+						Duration: "30s",
+						Requests: []RequestConfig{tt.request},
+					},
+				},
+			}
+
+			err := config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr && tt.errMsg != "" && !strings.Contains(strings.ToLower(err.Error()), tt.errMsg) {
+				t.Errorf("Error should contain '%s', got: %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestValidate_ConditionsAndLoops(t *testing.T) {
+	get := RequestConfig{Method: "GET", URL: "/test"}
+	empty := &ConditionConfig{Variable: "cart", Condition: "eq", Value: ""}
+	ready := &ConditionConfig{Source: "status", Condition: "eq", Value: "200"}
+
+	tests := []struct {
+		name    string
+		request RequestConfig
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "repeated request",
+			request: RequestConfig{Method: "GET", URL: "/test", Repeat: 3},
+			wantErr: false,
+		},
+		{
+			name:    "request with when",
+			request: RequestConfig{Method: "GET", URL: "/test", When: empty},
+			wantErr: false,
+		},
+		{
+			name:    "request with until",
+			request: RequestConfig{Method: "GET", URL: "/test", Repeat: 10, Until: ready},
+			wantErr: false,
+		},
+		{
+			name:    "group with when and until",
+			request: RequestConfig{Group: "poll", When: empty, Repeat: 5, Until: ready, Requests: []RequestConfig{get}},
+			wantErr: false,
+		},
+		{
+			name:    "negative repeat",
+			request: RequestConfig{Method: "GET", URL: "/test", Repeat: -1},
+			wantErr: true,
+			errMsg:  "repeat",
+		},
+		{
+			name:    "until without repeat",
+			request: RequestConfig{Method: "GET", URL: "/test", Until: ready},
+			wantErr: true,
+			errMsg:  "repeat is required with until",
+		},
+		{
+			name:    "variable condition without variable",
+			request: RequestConfig{Method: "GET", URL: "/test", When: &ConditionConfig{Condition: "eq", Value: "1"}},
+			wantErr: true,
+			errMsg:  "when.variable",
+		},
+		{
+			name:    "status condition with variable",
+			request: RequestConfig{Method: "GET", URL: "/test", When: &ConditionConfig{Source: "status", Variable: "x", Condition: "eq", Value: "200"}},
+			wantErr: true,
+			errMsg:  "only supported for the variable source",
+		},
+		{
+			name:    "invalid source",
+			request: RequestConfig{Method: "GET", URL: "/test", When: &ConditionConfig{Source: "header", Condition: "eq", Value: "1"}},
+			wantErr: true,
+			errMsg:  "invalid source",
+		},
+		{
+			name:    "missing condition",
+			request: RequestConfig{Method: "GET", URL: "/test", When: &ConditionConfig{Variable: "x", Value: "1"}},
+			wantErr: true,
+			errMsg:  "condition is required",
+		},
+		{
+			name:    "invalid condition",
+			request: RequestConfig{Method: "GET", URL: "/test", Repeat: 3, Until: &ConditionConfig{Variable: "x", Condition: "between", Value: "1"}},
+			wantErr: true,
+			errMsg:  "until.condition",
+		},
+		{
+			name:    "invalid regular expression",
+			request: RequestConfig{Method: "GET", URL: "/test", When: &ConditionConfig{Variable: "x", Condition: "matches", Value: "("}},
+			wantErr: true,
+			errMsg:  "invalid regular expression",
+		},
+		{
+			name:    "invalid condition in group",
+			request: RequestConfig{Group: "poll", Requests: []RequestConfig{{Method: "GET", URL: "/test", When: &ConditionConfig{Condition: "eq"}}}},
+			wantErr: true,
+			errMsg:  "requests[0].requests[0].when.variable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &TestConfig{
+				Name: "Test",
+				Scenarios: map[string]*ScenarioConfig{
+					"test": {
+						Executor: "constant-vus",
+						VUs:      10,
+						Duration: "30s",
+						Requests: []RequestConfig{tt.request},
+					},
+				},
+			}
+
+			err := config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr && tt.errMsg != "" && !strings.Contains(strings.ToLower(err.Error()), tt.errMsg) {
+				t.Errorf("Error should contain '%s', got: %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestValidate_WeightedFlow(t *testing.T) {
+	seed := int64(42)
+	search := RequestConfig{Name: "search", Method: "GET", URL: "/search", Weight: 7}
+	view := RequestConfig{Name: "view", Method: "GET", URL: "/view", Weight: 3}
+	get := RequestConfig{Method: "GET", URL: "/test"}
+
+	tests := []struct {
+		name     string
+		flow     string
+		seed     *int64
+		requests []RequestConfig
+		wantErr  bool
+		errMsg   string
+	}{
+		{
+			name:     "weighted requests and group",
+			flow:     "weighted",
+			seed:     &seed,
+			requests: []RequestConfig{search, view, {Group: "buy", Weight: 1, Requests: []RequestConfig{get}}},
+			wantErr:  false,
+		},
+		{
+			name:     "explicit sequential flow",
+			flow:     "sequential",
+			requests: []RequestConfig{get},
+			wantErr:  false,
+		},
+		{
+			name:     "unknown flow",
+			flow:     "random",
+			requests: []RequestConfig{get},
+			wantErr:  true,
+			errMsg:   "unknown flow",
+		},
+		{
+			name:     "missing weight",
+			flow:     "weighted",
+			requests: []RequestConfig{search, get},
+			wantErr:  true,
+			errMsg:   "requests[1].weight",
+		},
+		{
+			name:     "negative weight",
+			flow:     "weighted",
+			requests: []RequestConfig{{Method: "GET", URL: "/test", Weight: -1}},
+			wantErr:  true,
+			errMsg:   "must be positive",
+		},
+		{
+			name:     "weight in sequential flow",
+			requests: []RequestConfig{search},
+			wantErr:  true,
+			errMsg:   "only supported on the top-level requests",
+		},
+		{
+			name:     "weight inside a group",
+			flow:     "weighted",
+			requests: []RequestConfig{{Group: "buy", Weight: 1, Requests: []RequestConfig{search}}},
+			wantErr:  true,
+			errMsg:   "requests[0].requests[0].weight",
+		},
+		{
+			name:     "seed in sequential flow",
+			seed:     &seed,
+			requests: []RequestConfig{get},
+			wantErr:  true,
+			errMsg:   "only supported with flow: weighted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &TestConfig{
+				Name: "Test",
+				Scenarios: map[string]*ScenarioConfig{
+					"test": {
+						Executor: "constant-vus",
+						VUs:      10,
+						Duration: "30s",
+						Flow:     tt.flow,
+						Seed:     tt.seed,
+						Requests: tt.requests,
+					},
+				},
+			}
+
+			err := config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr && tt.errMsg != "" && !strings.Contains(strings.ToLower(err.Error()), tt.errMsg) {
+				t.Errorf("Error should contain '%s', got: %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestValidate_SetupTeardown(t *testing.T) {
+	login := RequestConfig{
+		Name:    "login",
+		Method:  "POST",
+		URL:     "/login",
+		Extract: []ExtractConfig{{Name: "token", Source: "body", Path: "$.token"}},
+	}
+
+	tests := []struct {
+		name     string
+		setup    []RequestConfig
+		teardown []RequestConfig
+		extract  []ExtractConfig
+		options  *ExecutionOptions
+		wantErr  bool
+		errMsg   string
+	}{
+		{
+			name:     "valid setup and teardown",
+			setup:    []RequestConfig{login},
+			teardown: []RequestConfig{{Method: "DELETE", URL: "/session/{{token}}"}},
+			wantErr:  false,
+		},
+		{
+			name:    "valid timeouts",
+			setup:   []RequestConfig{login},
+			options: &ExecutionOptions{SetupTimeout: "30s", TeardownTimeout: "2m"},
+			wantErr: false,
+		},
+		{
+			name:    "invalid setup request",
+			setup:   []RequestConfig{{Method: "GET"}},
+			wantErr: true,
+			errMsg:  "setup[0]",
+		},
+		{
+			name:     "invalid teardown request",
+			teardown: []RequestConfig{{Method: "FETCH", URL: "/cleanup"}},
+			wantErr:  true,
+			errMsg:   "teardown[0]",
+		},
+		{
+			name:    "scenario overwrites setup variable",
+			setup:   []RequestConfig{login},
+			extract: []ExtractConfig{{Name: "token", Source: "header", Path: "X-Token"}},
+			wantErr: true,
+			errMsg:  "setup variable",
+		},
+		{
+			name:    "group in setup",
+			setup:   []RequestConfig{{Group: "login", Requests: []RequestConfig{login}}},
+			wantErr: true,
+			errMsg:  "not supported in setup",
+		},
+		{
+			name:     "repeat in teardown",
+			teardown: []RequestConfig{{Method: "POST", URL: "/logout", Repeat: 2}},
+			wantErr:  true,
+			errMsg:   "not supported in setup and teardown",
+		},
+		{
+			name:    "invalid setup timeout",
+			options: &ExecutionOptions{SetupTimeout: "soon"},
+			wantErr: true,
+			errMsg:  "setuptimeout",
+		},
+		{
+			name:    "invalid teardown timeout",
+			options: &ExecutionOptions{TeardownTimeout: "later"},
+			wantErr: true,
+			errMsg:  "teardowntimeout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &TestConfig{
+				Name:     "Test",
+				Setup:    tt.setup,
+				Teardown: tt.teardown,
+				Options:  tt.options,
+				Scenarios: map[string]*ScenarioConfig{
+					"test": {
+						Executor: "constant-vus",
+						VUs:      10,
+						Duration: "30s",
+						Requests: []RequestConfig{
+							{
+								Method:  "GET",
+								URL:     "/test",
+								Extract: tt.extract,
+							},
+						},
+					},
+				},
+			}
+
+			err := config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr && tt.errMsg != "" && !strings.Contains(strings.ToLower(err.Error()), tt.errMsg) {
+				t.Errorf("Error should contain '%s', got: %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
 func TestValidationErrors(t *testing.T) {
 	errs := &ValidationErrors{}
 
@@ -0,0 +1,214 @@
+// Package control provides the HTTP API used to steer a running
+// performance test: pause, resume, scale VUs, set the arrival rate and stop.
+//
+// The API serves JSON on a local address:
+//
+//	GET  /v1/status                              control state of each scenario
+//	POST /v1/pause   {"scenario": "name"}        stop starting iterations
+//	POST /v1/resume  {"scenario": "name"}        start iterations again
+//	POST /v1/vus     {"scenario": "name", "vus": 20}
+//	POST /v1/rate    {"scenario": "name", "rate": 50}
+//	POST /v1/stop                                end the test
+//
+// The scenario may be omitted when only one scenario uses the
+// externally-controlled executor. Errors are returned as {"error": "..."}.
+//
+// The API has no authentication. It binds to 127.0.0.1 unless given a host,
+// and POST requests must be sent as application/json, which browsers
+// cannot do cross-origin without a preflight, so web pages cannot drive it.
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+
+	"github.com/wesleyorama2/lunge/internal/performance/v2/engine"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/serve"
+)
+
+// DefaultHost is the host the API binds to when the address has none.
+const DefaultHost = "127.0.0.1"
+
+// Server serves the control API of an engine.
+type Server struct {
+	engine *engine.Engine
+	http   *serve.Server
+}
+
+// NewServer creates a control server for an engine. Call Start to listen.
+func NewServer(eng *engine.Engine) *Server {
+	s := &Server{engine: eng}
+	s.http = serve.New(s.Handler())
+	return s
+}
+
+// Handler returns the HTTP handler of the control API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/status", s.handleStatus)
+	mux.HandleFunc("POST /v1/pause", requireJSON(s.handlePause))
+	mux.HandleFunc("POST /v1/resume", requireJSON(s.handleResume))
+	mux.HandleFunc("POST /v1/vus", requireJSON(s.handleVUs))
+	mux.HandleFunc("POST /v1/rate", requireJSON(s.handleRate))
+	mux.HandleFunc("POST /v1/stop", requireJSON(s.handleStop))
+	return mux
+}
+
+// Start listens on addr (e.g. "localhost:6565" or "127.0.0.1:0") and
+// serves the API in the background. See BindAddr for addresses without
+// a host.
+func (s *Server) Start(addr string) error {
+	return s.http.Start(BindAddr(addr))
+}
+
+// Addr returns the address the server listens on, once started.
+func (s *Server) Addr() string {
+	return s.http.Addr()
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	return s.http.Close()
+}
+
+// BindAddr returns the address to listen on for addr. An address without
+// a host (e.g. ":6565" or "6565") binds to DefaultHost rather than to
+// every interface.
+func BindAddr(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		// A bare port
+		return net.JoinHostPort(DefaultHost, addr)
+	}
+	if host == "" {
+		host = DefaultHost
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// BindsAllInterfaces reports whether addr listens on every network
+// interface (e.g. "0.0.0.0:6565" or "[::]:6565").
+func BindsAllInterfaces(addr string) bool {
+	host, _, err := net.SplitHostPort(BindAddr(addr))
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsUnspecified()
+}
+
+// controlRequest is the body of the POST endpoints.
+type controlRequest struct {
+	Scenario string   `json:"scenario"`
+	VUs      *int     `json:"vus"`
+	Rate     *float64 `json:"rate"`
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.engine.ControlStatus())
+}
+
+func (s *Server) handlePause(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRequest(w, r)
+	if !ok {
+		return
+	}
+	s.respond(w, s.engine.Pause(req.Scenario))
+}
+
+func (s *Server) handleResume(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRequest(w, r)
+	if !ok {
+		return
+	}
+	s.respond(w, s.engine.Resume(req.Scenario))
+}
+
+func (s *Server) handleVUs(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRequest(w, r)
+	if !ok {
+		return
+	}
+	if req.VUs == nil {
+		writeError(w, http.StatusBadRequest, "vus is required")
+		return
+	}
+	s.respond(w, s.engine.ScaleVUs(req.Scenario, *req.VUs))
+}
+
+func (s *Server) handleRate(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeRequest(w, r)
+	if !ok {
+		return
+	}
+	if req.Rate == nil {
+		writeError(w, http.StatusBadRequest, "rate is required")
+		return
+	}
+	s.respond(w, s.engine.SetRate(req.Scenario, *req.Rate))
+}
+
+// handleStop stops the test in the background, since a graceful stop
+// waits for in-flight iterations.
+func (s *Server) handleStop(w http.ResponseWriter, r *http.Request) {
+	if !s.engine.IsRunning() {
+		writeError(w, http.StatusConflict, engine.ErrNotRunning.Error())
+		return
+	}
+	go s.engine.StopFromControl(context.Background())
+	writeJSON(w, http.StatusAccepted, map[string]string{"status": "stopping"})
+}
+
+// respond writes the outcome of a control change: the new status, or the
+// error with a matching status code.
+func (s *Server) respond(w http.ResponseWriter, err error) {
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusOK, s.engine.ControlStatus())
+	case errors.Is(err, engine.ErrNotRunning):
+		writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, engine.ErrScenarioNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	default:
+		writeError(w, http.StatusBadRequest, err.Error())
+	}
+}
+
+// requireJSON rejects requests whose Content-Type is not application/json,
+// so that HTML forms on other sites cannot post to the API.
+func requireJSON(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || mediaType != "application/json" {
+			writeError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// decodeRequest decodes the optional JSON body of a POST endpoint.
+func decodeRequest(w http.ResponseWriter, r *http.Request) (*controlRequest, bool) {
+	req := &controlRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return nil, false
+	}
+	return req, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
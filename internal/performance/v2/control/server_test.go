@@ -0,0 +1,239 @@
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/config"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/engine"
+)
+
+// startEngine runs an engine with one externally-controlled scenario in
+// the background. The returned channel yields its result.
+func startEngine(t *testing.T, sc *config.ScenarioConfig) (*engine.Engine, <-chan *engine.TestResult) {
+	t.Helper()
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(target.Close)
+
+	sc.Requests = []config.RequestConfig{{Name: "get", Method: "GET", URL: target.URL}}
+	eng, err := engine.NewEngine(&config.TestConfig{
+		Name:      "Control Test",
+		Scenarios: map[string]*config.ScenarioConfig{"explore": sc},
+	})
+	require.NoError(t, err)
+
+	results := make(chan *engine.TestResult, 1)
+	go func() {
+		result, _ := eng.Run(context.Background())
+		results <- result
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(eng.ControlStatus().Scenarios) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return eng, results
+}
+
+// post sends a JSON body to an endpoint and decodes the response.
+func post(t *testing.T, url, body string) (int, map[string]interface{}) {
+	t.Helper()
+
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+	return resp.StatusCode, decoded
+}
+
+func TestServer_VUs(t *testing.T) {
+	eng, results := startEngine(t, &config.ScenarioConfig{
+		Executor: "externally-controlled",
+		VUs:      1,
+		MaxVUs:   10,
+	})
+	server := httptest.NewServer(NewServer(eng).Handler())
+	defer server.Close()
+
+	status, body := post(t, server.URL+"/v1/vus", `{"vus": 4}`)
+	assert.Equal(t, http.StatusOK, status)
+	scenarios := body["scenarios"].(map[string]interface{})
+	assert.Equal(t, float64(4), scenarios["explore"].(map[string]interface{})["vus"])
+
+	status, body = post(t, server.URL+"/v1/vus", `{"scenario": "explore", "vus": 50}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, body["error"], "maxVUs")
+
+	status, body = post(t, server.URL+"/v1/vus", `{}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "vus is required", body["error"])
+
+	status, _ = post(t, server.URL+"/v1/vus", `{"scenario": "missing", "vus": 1}`)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, _ = post(t, server.URL+"/v1/rate", `{"rate": 10}`)
+	assert.Equal(t, http.StatusBadRequest, status, "VU mode has no rate")
+
+	status, _ = post(t, server.URL+"/v1/pause", ``)
+	assert.Equal(t, http.StatusOK, status)
+	status, _ = post(t, server.URL+"/v1/resume", `{"scenario": "explore"}`)
+	assert.Equal(t, http.StatusOK, status)
+
+	status, body = post(t, server.URL+"/v1/stop", ``)
+	assert.Equal(t, http.StatusAccepted, status)
+	assert.Equal(t, "stopping", body["status"])
+
+	select {
+	case result := <-results:
+		var actions []string
+		for _, event := range result.ControlEvents {
+			actions = append(actions, event.Action)
+		}
+		assert.Equal(t, []string{"vus", "pause", "resume", "stop"}, actions)
+	case <-time.After(10 * time.Second):
+		t.Fatal("test did not stop")
+	}
+
+	// Once the test has ended, changes are refused
+	status, _ = post(t, server.URL+"/v1/pause", ``)
+	assert.Equal(t, http.StatusConflict, status)
+}
+
+func TestServer_Rate(t *testing.T) {
+	eng, results := startEngine(t, &config.ScenarioConfig{
+		Executor: "externally-controlled",
+		Rate:     5,
+		MaxVUs:   5,
+	})
+	server := httptest.NewServer(NewServer(eng).Handler())
+	defer server.Close()
+
+	status, body := post(t, server.URL+"/v1/rate", `{"rate": 20}`)
+	require.Equal(t, http.StatusOK, status)
+	explore := body["scenarios"].(map[string]interface{})["explore"].(map[string]interface{})
+	assert.Equal(t, "rate", explore["mode"])
+	assert.Equal(t, float64(20), explore["rate"])
+
+	status, _ = post(t, server.URL+"/v1/rate", `{"rate": -1}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = post(t, server.URL+"/v1/rate", `not json`)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	resp, err := http.Get(server.URL + "/v1/status")
+	require.NoError(t, err)
+	var got engine.ControlStatus
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+	resp.Body.Close()
+	assert.True(t, got.Running)
+	assert.Equal(t, 20.0, got.Scenarios["explore"].Rate)
+
+	// Wrong method
+	resp, err = http.Get(server.URL + "/v1/stop")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	require.NoError(t, eng.StopFromControl(context.Background()))
+	result := <-results
+	require.Len(t, result.ControlEvents, 2)
+	assert.Equal(t, float64(20), result.ControlEvents[0].Value)
+}
+
+func TestServer_Start(t *testing.T) {
+	eng, err := engine.NewEngine(&config.TestConfig{
+		Name: "Idle",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"idle": {
+				Executor: "externally-controlled",
+				VUs:      1,
+				Requests: []config.RequestConfig{{Method: "GET", URL: "http://localhost"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	server := NewServer(eng)
+	require.NoError(t, server.Start("127.0.0.1:0"))
+	defer server.Close()
+
+	resp, err := http.Get("http://" + server.Addr() + "/v1/status")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var got engine.ControlStatus
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+	assert.False(t, got.Running)
+	assert.Empty(t, got.Scenarios)
+
+	// Port already in use
+	assert.Error(t, NewServer(eng).Start(server.Addr()))
+}
+
+func TestServer_RequiresJSON(t *testing.T) {
+	eng, results := startEngine(t, &config.ScenarioConfig{
+		Executor: "externally-controlled",
+		VUs:      1,
+		MaxVUs:   5,
+	})
+	server := httptest.NewServer(NewServer(eng).Handler())
+	defer server.Close()
+
+	// A form post, as a page on another site could send
+	resp, err := http.Post(server.URL+"/v1/stop", "application/x-www-form-urlencoded", strings.NewReader("a=b"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+
+	resp, err = http.Post(server.URL+"/v1/vus", "", strings.NewReader(`{"vus": 3}`))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	assert.True(t, eng.IsRunning(), "rejected requests must not stop the test")
+
+	// Parameters of the media type are allowed
+	resp, err = http.Post(server.URL+"/v1/stop", "application/json; charset=utf-8", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	// Only the stop was applied
+	result := <-results
+	require.Len(t, result.ControlEvents, 1)
+	assert.Equal(t, "stop", result.ControlEvents[0].Action)
+}
+
+func TestBindAddr(t *testing.T) {
+	tests := []struct {
+		addr    string
+		want    string
+		allIfcs bool
+	}{
+		{":6565", "127.0.0.1:6565", false},
+		{"6565", "127.0.0.1:6565", false},
+		{"localhost:6565", "localhost:6565", false},
+		{"10.0.0.5:6565", "10.0.0.5:6565", false},
+		{"0.0.0.0:6565", "0.0.0.0:6565", true},
+		{"[::]:6565", "[::]:6565", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.want, BindAddr(tt.addr))
+			assert.Equal(t, tt.allIfcs, BindsAllInterfaces(tt.addr))
+		})
+	}
+}
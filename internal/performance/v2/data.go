@@ -0,0 +1,244 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// DataMode selects how rows of a data source are handed to iterations.
+type DataMode string
+
+const (
+	// DataModeSequential hands the rows out in file order, one per iteration,
+	// across all VUs.
+	DataModeSequential DataMode = "sequential"
+	// DataModeRandom gives each iteration a random row.
+	DataModeRandom DataMode = "random"
+	// DataModeUnique gives each row to exactly one iteration across all VUs.
+	// It never wraps.
+	DataModeUnique DataMode = "unique"
+	// DataModePerVU gives each VU its own row, kept for all its iterations.
+	DataModePerVU DataMode = "per-vu"
+)
+
+// DataExhaustedAction is what happens when a data source runs out of rows.
+type DataExhaustedAction string
+
+const (
+	// DataExhaustedWrap starts again from the first row.
+	DataExhaustedWrap DataExhaustedAction = "wrap"
+	// DataExhaustedStop stops the scenario.
+	DataExhaustedStop DataExhaustedAction = "stop"
+	// DataExhaustedAbort aborts the whole test.
+	DataExhaustedAbort DataExhaustedAction = "abort"
+)
+
+// ErrDataExhausted is returned by an iteration that could not get a data row.
+var ErrDataExhausted = errors.New("data source exhausted")
+
+// DataSource hands out the rows of a data file to a scenario's VUs.
+//
+// The rows are loaded once and shared read-only by all VUs. A row's fields
+// are available to requests as {{data.<column>}}.
+type DataSource struct {
+	// Name identifies the source in messages (usually the file path)
+	Name string
+
+	// Rows are the records of the data file, keyed by column
+	Rows []map[string]string
+
+	// Mode selects how rows are handed out
+	Mode DataMode
+
+	// OnExhausted is what happens when rows run out
+	OnExhausted DataExhaustedAction
+
+	// Exhausted is called once, when the source first runs out of rows
+	// and OnExhausted is stop or abort. It must not block on the VUs.
+	Exhausted func(action DataExhaustedAction)
+
+	next     atomic.Int64 // shared cursor for sequential, unique and per-vu modes
+	once     sync.Once
+	finished atomic.Bool
+}
+
+// NewDataSource creates a data source over rows.
+//
+// An empty mode defaults to sequential and an empty action to wrap, or to
+// stop in unique mode.
+func NewDataSource(name string, rows []map[string]string, mode DataMode, onExhausted DataExhaustedAction) *DataSource {
+	if mode == "" {
+		mode = DataModeSequential
+	}
+	if onExhausted == "" {
+		onExhausted = DataExhaustedWrap
+		if mode == DataModeUnique {
+			onExhausted = DataExhaustedStop
+		}
+	}
+	return &DataSource{
+		Name:        name,
+		Rows:        rows,
+		Mode:        mode,
+		OnExhausted: onExhausted,
+	}
+}
+
+// rowFor returns the row for the VU's next iteration, or ErrDataExhausted.
+func (d *DataSource) rowFor(vu *VirtualUser) (map[string]string, error) {
+	if d.finished.Load() {
+		return nil, ErrDataExhausted
+	}
+
+	n := int64(len(d.Rows))
+	if n == 0 {
+		return nil, d.exhaust()
+	}
+
+	var index int64
+	switch d.Mode {
+	case DataModeRandom:
+		return d.Rows[rand.Int63n(n)], nil
+	case DataModeUnique:
+		index = d.next.Add(1) - 1
+	case DataModePerVU:
+		if vu.dataRow != nil {
+			return vu.dataRow, nil
+		}
+		index = d.next.Add(1) - 1
+	default:
+		index = d.next.Add(1) - 1
+	}
+
+	if index >= n {
+		// A unique row is never handed out twice
+		if d.OnExhausted != DataExhaustedWrap || d.Mode == DataModeUnique {
+			return nil, d.exhaust()
+		}
+		index %= n
+	}
+	return d.Rows[index], nil
+}
+
+// exhaust marks the source as finished and notifies the engine once.
+func (d *DataSource) exhaust() error {
+	d.finished.Store(true)
+	d.once.Do(func() {
+		if d.Exhausted != nil {
+			d.Exhausted(d.OnExhausted)
+		}
+	})
+	return fmt.Errorf("%w: %s", ErrDataExhausted, d.Name)
+}
+
+// LoadDataFile reads the rows of a CSV or JSON data file.
+//
+// A CSV file must start with a header row naming the columns. A JSON file
+// must contain an array of objects; non-string values are converted to
+// their JSON text.
+func LoadDataFile(path, format string) ([]map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data file: %w", err)
+	}
+
+	var rows []map[string]string
+	switch format {
+	case "csv":
+		rows, err = parseCSVData(content)
+	case "json":
+		rows, err = parseJSONData(content)
+	default:
+		return nil, fmt.Errorf("unsupported data format: %s", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse data file %s: %w", path, err)
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("data file %s contains no rows", path)
+	}
+	return rows, nil
+}
+
+// parseCSVData parses CSV content with a header row.
+func parseCSVData(content []byte) ([]map[string]string, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []map[string]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			row[column] = record[i]
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseJSONData parses a JSON array of objects.
+func parseJSONData(content []byte) ([]map[string]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	var records []map[string]interface{}
+	if err := decoder.Decode(&records); err != nil {
+		return nil, err
+	}
+
+	rows := make([]map[string]string, 0, len(records))
+	for _, record := range records {
+		row := make(map[string]string, len(record))
+		for key, value := range record {
+			row[key] = jsonDataValue(value)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// jsonDataValue formats a decoded JSON value for variable substitution.
+func jsonDataValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(encoded)
+	}
+}
@@ -0,0 +1,356 @@
+package v2_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	v2 "github.com/wesleyorama2/lunge/internal/performance/v2"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+)
+
+// createDataTestServer creates a server that records the "user" query
+// parameter of every request.
+func createDataTestServer(mu *sync.Mutex, users *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		*users = append(*users, r.URL.Query().Get("user"))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+// createDataTestScenario creates a scenario that sends {{data.user}}.
+func createDataTestScenario(serverURL string, source *v2.DataSource) *v2.Scenario {
+	return &v2.Scenario{
+		Name: "data-test",
+		Data: source,
+		Requests: []*v2.RequestConfig{
+			{Name: "request", Method: "GET", URL: serverURL + "?user={{data.user}}"},
+		},
+	}
+}
+
+func dataRows(users ...string) []map[string]string {
+	rows := make([]map[string]string, len(users))
+	for i, user := range users {
+		rows[i] = map[string]string{"user": user}
+	}
+	return rows
+}
+
+func TestLoadDataFile(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		file    string
+		format  string
+		content string
+		want    []map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "csv",
+			file:    "users.csv",
+			format:  "csv",
+			content: "user,password\nalice,secret1\nbob, \"with, comma\"\n",
+			want: []map[string]string{
+				{"user": "alice", "password": "secret1"},
+				{"user": "bob", "password": "with, comma"},
+			},
+		},
+		{
+			name:    "json",
+			file:    "users.json",
+			format:  "json",
+			content: `[{"user": "alice", "id": 1, "admin": true, "tags": ["a"]}, {"user": "bob", "id": 2.5, "admin": null}]`,
+			want: []map[string]string{
+				{"user": "alice", "id": "1", "admin": "true", "tags": `["a"]`},
+				{"user": "bob", "id": "2.5", "admin": ""},
+			},
+		},
+		{
+			name:    "csv header only",
+			file:    "empty.csv",
+			format:  "csv",
+			content: "user,password\n",
+			wantErr: true,
+		},
+		{
+			name:    "csv ragged row",
+			file:    "ragged.csv",
+			format:  "csv",
+			content: "user,password\nalice\n",
+			wantErr: true,
+		},
+		{
+			name:    "json not an array",
+			file:    "object.json",
+			format:  "json",
+			content: `{"user": "alice"}`,
+			wantErr: true,
+		},
+		{
+			name:    "unsupported format",
+			file:    "users.xml",
+			format:  "xml",
+			content: "<users/>",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tmpDir, tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write data file: %v", err)
+			}
+
+			rows, err := v2.LoadDataFile(path, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadDataFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(rows) != len(tt.want) {
+				t.Fatalf("LoadDataFile() returned %d rows, want %d", len(rows), len(tt.want))
+			}
+			for i, want := range tt.want {
+				for key, value := range want {
+					if rows[i][key] != value {
+						t.Errorf("row %d %s = %q, want %q", i, key, rows[i][key], value)
+					}
+				}
+			}
+		})
+	}
+
+	if _, err := v2.LoadDataFile(filepath.Join(tmpDir, "missing.csv"), "csv"); err == nil {
+		t.Error("LoadDataFile() should return error for a missing file")
+	}
+}
+
+func TestDataSource_Modes(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        v2.DataMode
+		onExhausted v2.DataExhaustedAction
+		want        [2][]string // users sent by VU 1 and VU 2, run alternately
+	}{
+		{
+			name: "sequential walks rows across VUs",
+			mode: v2.DataModeSequential,
+			want: [2][]string{{"a", "c", "b", "a"}, {"b", "a", "c", "b"}},
+		},
+		{
+			name: "per-vu keeps a row per VU",
+			mode: v2.DataModePerVU,
+			want: [2][]string{{"a", "a", "a", "a"}, {"b", "b", "b", "b"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var users []string
+			server := createDataTestServer(&mu, &users)
+			defer server.Close()
+
+			metricsEngine := metrics.NewEngine()
+			defer metricsEngine.Stop()
+
+			source := v2.NewDataSource("users", dataRows("a", "b", "c"), tt.mode, tt.onExhausted)
+			scenario := createDataTestScenario(server.URL, source)
+			vus := []*v2.VirtualUser{
+				v2.NewVirtualUser(1, scenario, server.Client(), metricsEngine),
+				v2.NewVirtualUser(2, scenario, server.Client(), metricsEngine),
+			}
+
+			var got [2][]string
+			for i := 0; i < 4; i++ {
+				for v, vu := range vus {
+					if err := vu.RunIteration(context.Background()); err != nil {
+						t.Fatalf("RunIteration() error = %v", err)
+					}
+					mu.Lock()
+					got[v] = append(got[v], users[len(users)-1])
+					mu.Unlock()
+				}
+			}
+
+			for v := range got {
+				for i := range tt.want[v] {
+					if got[v][i] != tt.want[v][i] {
+						t.Errorf("VU %d sent %v, want %v", v+1, got[v], tt.want[v])
+						break
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDataSource_Random(t *testing.T) {
+	var mu sync.Mutex
+	var users []string
+	server := createDataTestServer(&mu, &users)
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	source := v2.NewDataSource("users", dataRows("a", "b", "c"), v2.DataModeRandom, v2.DataExhaustedStop)
+	vu := createTestVU(createDataTestScenario(server.URL, source), metricsEngine)
+
+	// Random mode never runs out of rows
+	for i := 0; i < 20; i++ {
+		if err := vu.RunIteration(context.Background()); err != nil {
+			t.Fatalf("RunIteration() error = %v", err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, user := range users {
+		if user != "a" && user != "b" && user != "c" {
+			t.Errorf("unexpected user %q", user)
+		}
+	}
+}
+
+func TestDataSource_Exhausted(t *testing.T) {
+	var mu sync.Mutex
+	var users []string
+	server := createDataTestServer(&mu, &users)
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	var calls atomic.Int32
+	var action v2.DataExhaustedAction
+	source := v2.NewDataSource("users", dataRows("a", "b"), v2.DataModeUnique, v2.DataExhaustedStop)
+	source.Exhausted = func(a v2.DataExhaustedAction) {
+		calls.Add(1)
+		action = a
+	}
+
+	scenario := createDataTestScenario(server.URL, source)
+	vu1 := v2.NewVirtualUser(1, scenario, server.Client(), metricsEngine)
+	vu2 := v2.NewVirtualUser(2, scenario, server.Client(), metricsEngine)
+
+	for _, vu := range []*v2.VirtualUser{vu1, vu2} {
+		if err := vu.RunIteration(context.Background()); err != nil {
+			t.Fatalf("RunIteration() error = %v", err)
+		}
+	}
+
+	err := vu1.RunIteration(context.Background())
+	if !errors.Is(err, v2.ErrDataExhausted) {
+		t.Errorf("RunIteration() error = %v, want ErrDataExhausted", err)
+	}
+	if vu1.GetState() != v2.VUStateStopping {
+		t.Errorf("VU state = %v, want stopping", vu1.GetState())
+	}
+
+	// Other VUs of the scenario stop too, without notifying again
+	if err := vu2.RunIteration(context.Background()); !errors.Is(err, v2.ErrDataExhausted) {
+		t.Errorf("second VU RunIteration() error = %v, want ErrDataExhausted", err)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("Exhausted called %d times, want 1", calls.Load())
+	}
+	if action != v2.DataExhaustedStop {
+		t.Errorf("Exhausted action = %q, want %q", action, v2.DataExhaustedStop)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(users) != 2 {
+		t.Errorf("server received %d requests, want 2", len(users))
+	}
+}
+
+func TestDataSource_UniqueRunsOut(t *testing.T) {
+	var mu sync.Mutex
+	var users []string
+	server := createDataTestServer(&mu, &users)
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	// Unique mode stops by default instead of wrapping
+	var calls atomic.Int32
+	source := v2.NewDataSource("users", dataRows("a", "b", "c"), v2.DataModeUnique, "")
+	source.Exhausted = func(v2.DataExhaustedAction) {
+		calls.Add(1)
+	}
+
+	scenario := createDataTestScenario(server.URL, source)
+	vus := []*v2.VirtualUser{
+		v2.NewVirtualUser(1, scenario, server.Client(), metricsEngine),
+		v2.NewVirtualUser(2, scenario, server.Client(), metricsEngine),
+	}
+
+	var exhausted int
+	for i := 0; i < 3; i++ {
+		for _, vu := range vus {
+			// A VU that ran out of data stops
+			if vu.GetState() == v2.VUStateStopping {
+				continue
+			}
+			err := vu.RunIteration(context.Background())
+			if errors.Is(err, v2.ErrDataExhausted) {
+				exhausted++
+			} else if err != nil {
+				t.Fatalf("RunIteration() error = %v", err)
+			}
+		}
+	}
+
+	if exhausted == 0 {
+		t.Error("no iteration ran out of data")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Exhausted called %d times, want 1", calls.Load())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	seen := make(map[string]bool)
+	for _, user := range users {
+		if seen[user] {
+			t.Errorf("row %q was used more than once", user)
+		}
+		seen[user] = true
+	}
+	if len(users) != 3 {
+		t.Errorf("server received %d requests, want 3 (one per row)", len(users))
+	}
+}
+
+func TestNewDataSource_Defaults(t *testing.T) {
+	source := v2.NewDataSource("users", dataRows("a"), "", "")
+	if source.Mode != v2.DataModeSequential {
+		t.Errorf("Mode = %q, want %q", source.Mode, v2.DataModeSequential)
+	}
+	if source.OnExhausted != v2.DataExhaustedWrap {
+		t.Errorf("OnExhausted = %q, want %q", source.OnExhausted, v2.DataExhaustedWrap)
+	}
+
+	unique := v2.NewDataSource("users", dataRows("a"), v2.DataModeUnique, "")
+	if unique.OnExhausted != v2.DataExhaustedStop {
+		t.Errorf("unique OnExhausted = %q, want %q", unique.OnExhausted, v2.DataExhaustedStop)
+	}
+}
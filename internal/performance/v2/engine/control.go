@@ -0,0 +1,199 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/wesleyorama2/lunge/internal/performance/v2/executor"
+)
+
+// Control actions recorded in ControlEvent.Action.
+const (
+	ControlPause  = "pause"
+	ControlResume = "resume"
+	ControlVUs    = "vus"
+	ControlRate   = "rate"
+	ControlStop   = "stop"
+)
+
+var (
+	// ErrNotRunning is returned by control methods when no test is running.
+	ErrNotRunning = errors.New("test is not running")
+
+	// ErrScenarioNotFound is returned by control methods for an unknown scenario.
+	ErrScenarioNotFound = errors.New("scenario not found")
+
+	// ErrNotControllable is returned by control methods for a scenario whose
+	// executor is not externally-controlled.
+	ErrNotControllable = errors.New("scenario is not externally controlled")
+)
+
+// ControlEvent records a change made to a running test through the
+// control methods.
+type ControlEvent struct {
+	Time     time.Time     `json:"time"`
+	Elapsed  time.Duration `json:"elapsed"` // Since the start of the test
+	Scenario string        `json:"scenario,omitempty"`
+	Action   string        `json:"action"`
+	Value    float64       `json:"value,omitempty"` // VUs or iterations/second
+}
+
+// String describes the change, e.g. "explore: vus 20".
+func (ev ControlEvent) String() string {
+	var desc string
+	switch ev.Action {
+	case ControlVUs:
+		desc = fmt.Sprintf("vus %d", int(ev.Value))
+	case ControlRate:
+		desc = fmt.Sprintf("rate %g/s", ev.Value)
+	default:
+		desc = ev.Action
+	}
+	if ev.Scenario == "" {
+		return desc
+	}
+	return ev.Scenario + ": " + desc
+}
+
+// ControlStatus is the control state of a running test.
+type ControlStatus struct {
+	Running   bool                              `json:"running"`
+	Elapsed   time.Duration                     `json:"elapsed"`
+	Scenarios map[string]executor.ControlStatus `json:"scenarios"`
+}
+
+// Pause stops new iterations of an externally-controlled scenario from
+// starting. An empty scenario name selects the only such scenario.
+func (e *Engine) Pause(scenario string) error {
+	return e.control(scenario, ControlPause, 0, executor.Controller.Pause)
+}
+
+// Resume lets iterations of a paused scenario start again.
+func (e *Engine) Resume(scenario string) error {
+	return e.control(scenario, ControlResume, 0, executor.Controller.Resume)
+}
+
+// ScaleVUs sets the VU count of an externally-controlled scenario.
+func (e *Engine) ScaleVUs(scenario string, vus int) error {
+	return e.control(scenario, ControlVUs, float64(vus), func(c executor.Controller) error {
+		return c.SetVUs(vus)
+	})
+}
+
+// SetRate sets the arrival rate of an externally-controlled scenario, in
+// iterations per second.
+func (e *Engine) SetRate(scenario string, rate float64) error {
+	return e.control(scenario, ControlRate, rate, func(c executor.Controller) error {
+		return c.SetRate(rate)
+	})
+}
+
+// StopFromControl records a stop event and stops the test, like
+// StopWithReason.
+func (e *Engine) StopFromControl(ctx context.Context) error {
+	e.mu.Lock()
+	if !e.running {
+		e.mu.Unlock()
+		return ErrNotRunning
+	}
+	e.recordControlEventLocked("", ControlStop, 0)
+	e.mu.Unlock()
+
+	return e.StopWithReason(ctx, "stopped by control API")
+}
+
+// ControlStatus returns the control state of each externally-controlled
+// scenario.
+func (e *Engine) ControlStatus() *ControlStatus {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	status := &ControlStatus{
+		Running:   e.running,
+		Scenarios: make(map[string]executor.ControlStatus),
+	}
+	if e.running {
+		status.Elapsed = time.Since(e.startTime)
+	}
+	for name, runner := range e.scenarios {
+		if c, ok := runner.Executor.(executor.Controller); ok {
+			status.Scenarios[name] = c.ControlStatus()
+		}
+	}
+	return status
+}
+
+// ControlEvents returns the control events recorded so far in this run.
+func (e *Engine) ControlEvents() []ControlEvent {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return append([]ControlEvent(nil), e.controlEvents...)
+}
+
+// control applies a change to a scenario's controller and records it.
+func (e *Engine) control(scenario, action string, value float64, apply func(executor.Controller) error) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.running {
+		return ErrNotRunning
+	}
+
+	name, c, err := e.controllerLocked(scenario)
+	if err != nil {
+		return err
+	}
+	if err := apply(c); err != nil {
+		return fmt.Errorf("scenario %s: %w", name, err)
+	}
+
+	e.recordControlEventLocked(name, action, value)
+	return nil
+}
+
+// controllerLocked resolves a scenario name to its controller. Called with
+// mu held.
+func (e *Engine) controllerLocked(scenario string) (string, executor.Controller, error) {
+	if scenario != "" {
+		runner, exists := e.scenarios[scenario]
+		if !exists {
+			return "", nil, fmt.Errorf("%w: %s", ErrScenarioNotFound, scenario)
+		}
+		c, ok := runner.Executor.(executor.Controller)
+		if !ok {
+			return "", nil, fmt.Errorf("%w: %s", ErrNotControllable, scenario)
+		}
+		return scenario, c, nil
+	}
+
+	var names []string
+	for name, runner := range e.scenarios {
+		if _, ok := runner.Executor.(executor.Controller); ok {
+			names = append(names, name)
+		}
+	}
+	switch len(names) {
+	case 0:
+		return "", nil, fmt.Errorf("%w: no scenario uses the externally-controlled executor", ErrNotControllable)
+	case 1:
+		return names[0], e.scenarios[names[0]].Executor.(executor.Controller), nil
+	default:
+		sort.Strings(names)
+		return "", nil, fmt.Errorf("scenario is required: %v are externally controlled", names)
+	}
+}
+
+// recordControlEventLocked appends a control event. Called with mu held.
+func (e *Engine) recordControlEventLocked(scenario, action string, value float64) {
+	now := time.Now()
+	e.controlEvents = append(e.controlEvents, ControlEvent{
+		Time:     now,
+		Elapsed:  now.Sub(e.startTime),
+		Scenario: scenario,
+		Action:   action,
+		Value:    value,
+	})
+}
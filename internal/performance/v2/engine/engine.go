@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -34,19 +35,43 @@ type Engine struct {
 	// Configuration
 	config *config.TestConfig
 
-	// Metrics engine (shared across all scenarios)
+	// Metrics engine with the merged view of all scenarios; each scenario
+	// records into its own child engine
 	metricsEngine *metrics.Engine
 
 	// HTTP client configuration
 	httpConfig v2.HTTPClientConfig
 
+	// Timeout of requests that don't set their own
+	requestTimeout time.Duration
+
 	// Scenario runners
 	scenarios map[string]*ScenarioRunner
 	mu        sync.RWMutex
 
+	// Metrics engines aggregating the scenarios that match each tag set
+	// referenced by a threshold, keyed by tagSetKey
+	tagMetrics map[string]*metrics.Engine
+
 	// State
 	startTime time.Time
 	running   bool
+
+	// Early stop state, guarded by mu
+	stopped     bool
+	abortReason string
+	dataAborted bool             // The test was aborted by a data source running out of rows
+	abortIndex  int              // Index in thresholdEntries of the threshold that aborted the test
+	abortResult *ThresholdResult // Result of that threshold when it failed
+
+	// Changes made through the control methods, guarded by mu
+	controlEvents []ControlEvent
+
+	// Listeners attached to the metrics engine of each run, guarded by mu
+	listeners []metrics.Listener
+
+	// How often abortOnFail thresholds are evaluated while running
+	abortEvalInterval time.Duration
 }
 
 // ScenarioRunner manages the execution of a single scenario.
@@ -56,6 +81,7 @@ type ScenarioRunner struct {
 	Executor  executor.Executor
 	Scheduler *v2.VUScheduler
 	Scenario  *v2.Scenario
+	Metrics   *metrics.Engine // Scenario-only metrics (child of the global engine)
 	Result    *ScenarioResult
 }
 
@@ -69,12 +95,24 @@ type ScenarioResult struct {
 	Metrics      *metrics.Snapshot       `json:"metrics"`
 	TimeSeries   []*metrics.TimeBucket   `json:"timeSeries,omitempty"`
 	RequestStats map[string]RequestStats `json:"requestStats,omitempty"`
+	Mix          []MixStats              `json:"mix,omitempty"` // Weighted flows only
 	Error        error                   `json:"error,omitempty"`
 }
 
+// MixStats compares the target and actual share of iterations of one
+// request or group in a weighted flow.
+type MixStats struct {
+	Name       string  `json:"name"`
+	Weight     int     `json:"weight"`
+	Target     float64 `json:"target"` // Fraction of iterations (0.0 - 1.0)
+	Iterations int64   `json:"iterations"`
+	Actual     float64 `json:"actual"` // Fraction of iterations (0.0 - 1.0)
+}
+
 // RequestStats contains statistics for a specific request.
 type RequestStats struct {
 	Name    string               `json:"name"`
+	Group   string               `json:"group,omitempty"` // Group the request belongs to, if any
 	Count   int64                `json:"count"`
 	Latency metrics.LatencyStats `json:"latency"`
 }
@@ -99,19 +137,51 @@ type TestResult struct {
 	Passed     bool              `json:"passed"`
 	Thresholds []ThresholdResult `json:"thresholds,omitempty"`
 
+	// Aborted is true if the test was stopped before it completed, e.g. by
+	// an abortOnFail threshold; AbortReason says why
+	Aborted     bool   `json:"aborted,omitempty"`
+	AbortReason string `json:"abortReason,omitempty"`
+
+	// DataExhausted is true if a data source with onExhausted: abort
+	// stopped the test
+	DataExhausted bool `json:"dataExhausted,omitempty"`
+
+	// Setup and teardown outcomes (nil if not configured)
+	Setup    *LifecycleResult `json:"setup,omitempty"`
+	Teardown *LifecycleResult `json:"teardown,omitempty"`
+
+	// ControlEvents lists the changes made to externally-controlled
+	// scenarios while the test ran, in order
+	ControlEvents []ControlEvent `json:"controlEvents,omitempty"`
+
 	// Error if the test failed catastrophically
 	Error error `json:"error,omitempty"`
 }
 
+// ScenarioNames returns the names of the result's scenarios, sorted.
+func (r *TestResult) ScenarioNames() []string {
+	names := make([]string, 0, len(r.Scenarios))
+	for name := range r.Scenarios {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // ThresholdResult contains the result of a threshold evaluation.
 type ThresholdResult struct {
-	Metric     string `json:"metric"`
-	Expression string `json:"expression"`
-	Passed     bool   `json:"passed"`
-	Value      string `json:"value"`
-	Message    string `json:"message,omitempty"`
+	Metric      string `json:"metric"`
+	Expression  string `json:"expression"`
+	Passed      bool   `json:"passed"`
+	Value       string `json:"value"`
+	Message     string `json:"message,omitempty"`
+	AbortOnFail bool   `json:"abortOnFail,omitempty"`
+	Aborted     bool   `json:"aborted,omitempty"` // This threshold stopped the test early
 }
 
+// defaultAbortEvalInterval is how often abortOnFail thresholds are evaluated.
+const defaultAbortEvalInterval = time.Second
+
 // NewEngine creates a new v2 performance engine.
 //
 // Parameters:
@@ -145,10 +215,17 @@ func NewEngine(cfg *config.TestConfig) (*Engine, error) {
 		httpConfig.MaxIdleConnsPerHost = 100
 	}
 
+	// Each request is bounded by its own context instead of the shared
+	// client, so a request's timeout can be longer than the default
+	requestTimeout := httpConfig.Timeout
+	httpConfig.Timeout = 0
+
 	return &Engine{
-		config:     cfg,
-		httpConfig: httpConfig,
-		scenarios:  make(map[string]*ScenarioRunner),
+		config:            cfg,
+		httpConfig:        httpConfig,
+		requestTimeout:    requestTimeout,
+		scenarios:         make(map[string]*ScenarioRunner),
+		abortEvalInterval: defaultAbortEvalInterval,
 	}, nil
 }
 
@@ -167,6 +244,18 @@ func (e *Engine) Run(ctx context.Context) (*TestResult, error) {
 	}
 	e.running = true
 	e.startTime = time.Now()
+	e.stopped = false
+	e.abortReason = ""
+	e.dataAborted = false
+	e.abortResult = nil
+	e.controlEvents = nil
+
+	// Create global metrics engine; set under the lock since the getters
+	// may be polled from other goroutines
+	e.metricsEngine = metrics.NewEngine()
+	for _, l := range e.listeners {
+		e.metricsEngine.AddListener(l)
+	}
 	e.mu.Unlock()
 
 	defer func() {
@@ -175,9 +264,8 @@ func (e *Engine) Run(ctx context.Context) (*TestResult, error) {
 		e.mu.Unlock()
 	}()
 
-	// Create shared metrics engine
-	e.metricsEngine = metrics.NewEngine()
 	defer e.metricsEngine.Stop()
+	defer e.stopScenarioMetrics()
 
 	// Set initial phase
 	e.metricsEngine.SetPhase(metrics.PhaseInit)
@@ -187,16 +275,65 @@ func (e *Engine) Run(ctx context.Context) (*TestResult, error) {
 		return nil, fmt.Errorf("failed to initialize scenarios: %w", err)
 	}
 
-	// Run scenarios
+	// Run setup before any scenario starts; its variables are shared
+	// read-only by every VU
+	setupData, setupResult, err := e.runSetup(ctx)
+	if err != nil {
+		// Clean up whatever setup created before it failed
+		teardownResult, _ := e.runTeardown(ctx, setupData)
+
+		result := &TestResult{
+			Name:        e.config.Name,
+			Description: e.config.Description,
+			StartTime:   e.startTime,
+			EndTime:     time.Now(),
+			Duration:    time.Since(e.startTime),
+			Metrics:     e.metricsEngine.GetSnapshot(),
+			Aborted:     true,
+			AbortReason: err.Error(),
+			Setup:       setupResult,
+			Teardown:    teardownResult,
+			Error:       err,
+		}
+		return result, err
+	}
+	for _, runner := range e.scenarios {
+		runner.Scenario.SetupData = setupData
+	}
+
+	// Watch abortOnFail thresholds while the scenarios run
+	watchDone := make(chan struct{})
+	var watchWg sync.WaitGroup
+	if e.hasAbortThresholds() {
+		watchWg.Add(1)
+		go func() {
+			defer watchWg.Done()
+			e.watchAbortThresholds(ctx, watchDone)
+		}()
+	}
+
+	// Run scenarios, unless the test was stopped during setup
 	var scenarioResults map[string]*ScenarioResult
 	var runErr error
 
-	if e.config.Options != nil && e.config.Options.Sequential {
+	switch {
+	case e.isStopped():
+		scenarioResults = make(map[string]*ScenarioResult)
+	case e.config.Options != nil && e.config.Options.Sequential:
 		scenarioResults, runErr = e.runScenariosSequentially(ctx)
-	} else {
+	default:
 		scenarioResults, runErr = e.runScenariosConcurrently(ctx)
 	}
 
+	close(watchDone)
+	watchWg.Wait()
+
+	// Teardown runs whatever happened to the scenarios
+	teardownResult, teardownErr := e.runTeardown(ctx, setupData)
+	if teardownErr != nil && runErr == nil {
+		runErr = teardownErr
+	}
+
 	// Get final metrics
 	finalMetrics := e.metricsEngine.GetSnapshot()
 	timeSeries := e.metricsEngine.GetTimeSeries()
@@ -222,20 +359,55 @@ func (e *Engine) Run(ctx context.Context) (*TestResult, error) {
 		TimeSeries:  timeSeries,
 		Passed:      passed,
 		Thresholds:  thresholdResults,
+		Setup:       setupResult,
+		Teardown:    teardownResult,
 		Error:       runErr,
 	}
 
+	e.mu.RLock()
+	result.Aborted = e.stopped
+	result.AbortReason = e.abortReason
+	result.DataExhausted = e.dataAborted
+	result.ControlEvents = append([]ControlEvent(nil), e.controlEvents...)
+	e.mu.RUnlock()
+
 	return result, runErr
 }
 
 // initializeScenarios creates executors and schedulers for all scenarios.
 func (e *Engine) initializeScenarios(ctx context.Context) error {
+	tagSets := e.thresholdTagSets()
+	dataFiles := make(map[string][]map[string]string)
+
 	for name, scenarioConfig := range e.config.Scenarios {
 		// Create the scenario (requests to execute)
 		scenario := e.createScenario(name, scenarioConfig)
 
+		// Load the scenario's data file; rows are shared by all its VUs
+		if dc := scenarioConfig.Data; dc != nil {
+			rows, loaded := dataFiles[dc.File]
+			if !loaded {
+				var err error
+				rows, err = v2.LoadDataFile(dc.File, dc.EffectiveFormat())
+				if err != nil {
+					return fmt.Errorf("failed to load data for scenario %s: %w", name, err)
+				}
+				dataFiles[dc.File] = rows
+			}
+			scenario.Data = v2.NewDataSource(dc.File, rows, v2.DataMode(dc.Mode), v2.DataExhaustedAction(dc.OnExhausted))
+		}
+
+		// Each scenario records into its own metrics engine, which feeds
+		// the global one
+		scenarioMetrics := e.metricsEngine.NewChild()
+		for key, tags := range tagSets {
+			if matchesTags(scenarioConfig.Tags, tags) {
+				e.tagMetrics[key].AddChild(scenarioMetrics)
+			}
+		}
+
 		// Create scheduler
-		scheduler := v2.NewVUScheduler(scenario, e.metricsEngine, e.httpConfig)
+		scheduler := v2.NewVUScheduler(scenario, scenarioMetrics, e.httpConfig)
 
 		// Create and initialize executor
 		exec, execConfig, err := executor.CreateExecutorFromScenarioConfig(ctx, name, scenarioConfig)
@@ -254,26 +426,140 @@ func (e *Engine) initializeScenarios(ctx context.Context) error {
 			Executor:  exec,
 			Scheduler: scheduler,
 			Scenario:  scenario,
+			Metrics:   scenarioMetrics,
+		}
+
+		if scenario.Data != nil {
+			scenario.Data.Exhausted = e.dataExhaustedHandler(ctx, runner)
 		}
 
+		e.mu.Lock()
 		e.scenarios[name] = runner
+		e.mu.Unlock()
 	}
 
 	return nil
 }
 
+// dataExhaustedHandler returns the handler called when a scenario's data
+// source runs out of rows.
+//
+// The handler is called from a VU, and stopping waits for the VUs, so it
+// stops asynchronously.
+func (e *Engine) dataExhaustedHandler(ctx context.Context, runner *ScenarioRunner) func(v2.DataExhaustedAction) {
+	return func(action v2.DataExhaustedAction) {
+		switch action {
+		case v2.DataExhaustedStop:
+			go runner.Executor.Stop(ctx)
+		case v2.DataExhaustedAbort:
+			reason := fmt.Sprintf("scenario %s ran out of data in %s", runner.Name, runner.Scenario.Data.Name)
+
+			// Record the cause unless the test is already stopping
+			e.mu.Lock()
+			if e.running && !e.stopped {
+				e.stopped = true
+				e.abortReason = reason
+				e.dataAborted = true
+			}
+			e.mu.Unlock()
+
+			go e.StopWithReason(ctx, reason)
+		}
+	}
+}
+
+// thresholdTagSets creates a metrics engine for every tag set that a
+// threshold scopes by, and returns the tag sets by key.
+func (e *Engine) thresholdTagSets() map[string]map[string]string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.tagMetrics = make(map[string]*metrics.Engine)
+	tagSets := make(map[string]map[string]string)
+
+	if e.config.Thresholds == nil {
+		return tagSets
+	}
+
+	for key := range e.config.Thresholds.Custom {
+		metric, err := config.ParseThresholdMetric(key)
+		if err != nil {
+			continue
+		}
+		tags := scopeTags(metric.Scope)
+		if len(tags) == 0 {
+			continue
+		}
+		setKey := tagSetKey(tags)
+		if _, exists := tagSets[setKey]; !exists {
+			tagSets[setKey] = tags
+			e.tagMetrics[setKey] = metrics.NewEngine()
+		}
+	}
+
+	return tagSets
+}
+
+// scopeTags returns the tag entries of a threshold scope, i.e. everything
+// except the scenario and request name.
+func scopeTags(scope map[string]string) map[string]string {
+	tags := make(map[string]string)
+	for k, v := range scope {
+		if k != "scenario" && k != "name" {
+			tags[k] = v
+		}
+	}
+	return tags
+}
+
+// tagSetKey returns a canonical key for a set of tags, e.g. "env:prod,team:web".
+func tagSetKey(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, k+":"+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// matchesTags reports whether a scenario's tags include all of want.
+func matchesTags(have, want map[string]string) bool {
+	for k, v := range want {
+		if have[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
 // createScenario creates a Scenario from the config.
 func (e *Engine) createScenario(name string, sc *config.ScenarioConfig) *v2.Scenario {
+	scenario := e.createRequestScenario(name, sc.Tags, sc.ExpectedStatuses, sc.Requests)
+	scenario.LatencyFromIntendedStart = sc.LatencyFromIntendedStart
+	if sc.Flow != "" {
+		scenario.Flow = v2.Flow(sc.Flow)
+	}
+	scenario.Seed = sc.Seed
+	return scenario
+}
+
+// createRequestScenario creates a Scenario that runs the given requests,
+// with the global variables and the given tags as variables.
+//
+// Requests without expected statuses of their own use expectedStatuses, or
+// the global ones if that is empty.
+func (e *Engine) createRequestScenario(name string, tags map[string]string, expectedStatuses config.StatusList, requests []config.RequestConfig) *v2.Scenario {
 	scenario := &v2.Scenario{
-		Name:      name,
-		Variables: make(map[string]string),
+		Name:        name,
+		Variables:   make(map[string]string),
+		Traceparent: e.config.Settings.Traceparent,
 	}
 
 	// Merge global variables with scenario tags
 	for k, v := range e.config.Variables {
 		scenario.Variables[k] = v
 	}
-	for k, v := range sc.Tags {
+	for k, v := range tags {
 		scenario.Variables[k] = v
 	}
 
@@ -283,22 +569,100 @@ func (e *Engine) createScenario(name string, sc *config.ScenarioConfig) *v2.Scen
 		scenario.Variables["baseURL"] = e.config.Settings.BaseURL
 	}
 
-	// Convert requests
-	for i, req := range sc.Requests {
+	if len(expectedStatuses) == 0 {
+		expectedStatuses = e.config.Settings.ExpectedStatuses
+	}
+
+	scenario.Requests = e.convertRequests(name, "", expectedStatuses, requests)
+	return scenario
+}
+
+// weightedMix returns the target and actual mix of a weighted flow's
+// steps, in configuration order, given how often each was picked.
+func weightedMix(steps []*v2.RequestConfig, selections map[string]int64) []MixStats {
+	totalWeight := 0
+	var totalPicks int64
+	for _, step := range steps {
+		totalWeight += step.Weight
+		totalPicks += selections[step.StepName()]
+	}
+
+	mix := make([]MixStats, 0, len(steps))
+	for _, step := range steps {
+		stats := MixStats{
+			Name:       step.StepName(),
+			Weight:     step.Weight,
+			Iterations: selections[step.StepName()],
+		}
+		if totalWeight > 0 {
+			stats.Target = float64(step.Weight) / float64(totalWeight)
+		}
+		if totalPicks > 0 {
+			stats.Actual = float64(stats.Iterations) / float64(totalPicks)
+		}
+		mix = append(mix, stats)
+	}
+	return mix
+}
+
+// convertCondition converts a when or until condition to its runtime form.
+func convertCondition(cond *config.ConditionConfig) *v2.ConditionConfig {
+	if cond == nil {
+		return nil
+	}
+	return &v2.ConditionConfig{
+		Source:    cond.Source,
+		Variable:  cond.Variable,
+		Condition: cond.Condition,
+		Value:     cond.Value,
+	}
+}
+
+// convertRequests converts configured requests, and the groups among them,
+// to their runtime form.
+//
+// Unnamed requests are named after parent, the scenario or group they are
+// in; group is the full name of the enclosing group, if any.
+func (e *Engine) convertRequests(parent, group string, expectedStatuses config.StatusList, requests []config.RequestConfig) []*v2.RequestConfig {
+	converted := make([]*v2.RequestConfig, 0, len(requests))
+	for i, req := range requests {
+		if req.IsGroup() {
+			name := req.Group
+			if group != "" {
+				name = group + config.GroupSeparator + req.Group
+			}
+			converted = append(converted, &v2.RequestConfig{
+				Group: &v2.Group{
+					Name:     name,
+					Requests: e.convertRequests(req.Group, name, expectedStatuses, req.Requests),
+				},
+				When:   convertCondition(req.When),
+				Repeat: req.Repeat,
+				Until:  convertCondition(req.Until),
+				Weight: req.Weight,
+			})
+			continue
+		}
+
 		reqConfig := &v2.RequestConfig{
 			Name:    req.Name,
 			Method:  req.Method,
 			URL:     req.URL,
 			Headers: req.Headers,
 			Body:    req.Body,
+			When:    convertCondition(req.When),
+			Repeat:  req.Repeat,
+			Until:   convertCondition(req.Until),
+			Weight:  req.Weight,
 		}
 
 		// Assign default name if not provided
 		if reqConfig.Name == "" {
-			reqConfig.Name = fmt.Sprintf("%s_request_%d", name, i+1)
+			reqConfig.Name = fmt.Sprintf("%s_request_%d", parent, i+1)
 		}
 
-		// Parse timeout
+		// Parse timeout, falling back to the global one
+		reqConfig.Timeout = e.requestTimeout
 		if req.Timeout != "" {
 			if dur, err := config.ParseDurationString(req.Timeout); err == nil {
 				reqConfig.Timeout = dur
@@ -312,6 +676,17 @@ func (e *Engine) createScenario(name string, sc *config.ScenarioConfig) *v2.Scen
 			}
 		}
 
+		// Resolve expected statuses, the most specific level winning
+		statuses := expectedStatuses
+		if len(req.ExpectedStatuses) > 0 {
+			statuses = req.ExpectedStatuses
+		}
+		for _, status := range statuses {
+			if min, max, err := config.ParseStatusRange(status); err == nil {
+				reqConfig.ExpectedStatuses = append(reqConfig.ExpectedStatuses, v2.StatusRange{Min: min, Max: max})
+			}
+		}
+
 		// Convert extracts
 		for _, ext := range req.Extract {
 			reqConfig.Extract = append(reqConfig.Extract, v2.ExtractConfig{
@@ -322,10 +697,21 @@ func (e *Engine) createScenario(name string, sc *config.ScenarioConfig) *v2.Scen
 			})
 		}
 
-		scenario.Requests = append(scenario.Requests, reqConfig)
+		// Convert assertions
+		for _, a := range req.Assertions {
+			reqConfig.Assertions = append(reqConfig.Assertions, v2.AssertionConfig{
+				Type:      a.Type,
+				Condition: a.Condition,
+				Value:     a.Value,
+				Path:      a.Path,
+				Message:   a.Message,
+			})
+		}
+
+		converted = append(converted, reqConfig)
 	}
 
-	return scenario
+	return converted
 }
 
 // runScenariosConcurrently runs all scenarios in parallel.
@@ -371,6 +757,11 @@ func (e *Engine) runScenariosSequentially(ctx context.Context) (map[string]*Scen
 		default:
 		}
 
+		// Don't start further scenarios once the test has been stopped
+		if e.isStopped() {
+			return results, nil
+		}
+
 		result, err := e.runScenario(ctx, runner)
 		results[name] = result
 
@@ -386,18 +777,27 @@ func (e *Engine) runScenariosSequentially(ctx context.Context) (map[string]*Scen
 func (e *Engine) runScenario(ctx context.Context, runner *ScenarioRunner) (*ScenarioResult, error) {
 	startTime := time.Now()
 
+	// Start the scenario's clock now rather than at initialization, so
+	// elapsed time and rates are correct when scenarios run sequentially
+	runner.Metrics.Reset()
+
 	// Run the executor
-	err := runner.Executor.Run(ctx, runner.Scheduler, e.metricsEngine)
+	err := runner.Executor.Run(ctx, runner.Scheduler, runner.Metrics)
 
 	duration := time.Since(startTime)
 	stats := runner.Executor.GetStats()
 
+	// Emit the scenario's final time-series bucket
+	runner.Metrics.Stop()
+
 	// Get request-specific stats
 	requestStats := make(map[string]RequestStats)
-	perRequestStats := e.metricsEngine.GetRequestStats()
+	perRequestStats := runner.Metrics.GetRequestStats()
+	requestGroups := runner.Scenario.RequestGroups()
 	for reqName, latencyStats := range perRequestStats {
 		requestStats[reqName] = RequestStats{
 			Name:    reqName,
+			Group:   requestGroups[reqName],
 			Count:   latencyStats.Count,
 			Latency: latencyStats,
 		}
@@ -409,10 +809,14 @@ func (e *Engine) runScenario(ctx context.Context, runner *ScenarioRunner) (*Scen
 		Duration:     duration,
 		Iterations:   stats.Iterations,
 		ActiveVUs:    stats.ActiveVUs,
-		Metrics:      e.metricsEngine.GetSnapshot(),
+		Metrics:      runner.Metrics.GetSnapshot(),
+		TimeSeries:   runner.Metrics.GetTimeSeries(),
 		RequestStats: requestStats,
 		Error:        err,
 	}
+	if runner.Scenario.Flow == v2.FlowWeighted {
+		result.Mix = weightedMix(runner.Scenario.Requests, runner.Metrics.GetSelections())
+	}
 
 	// Shutdown scheduler
 	runner.Scheduler.Shutdown(30 * time.Second)
@@ -421,39 +825,270 @@ func (e *Engine) runScenario(ctx context.Context, runner *ScenarioRunner) (*Scen
 	return result, err
 }
 
+// stopScenarioMetrics stops the metrics engines of all scenarios,
+// including any that never ran, and those of threshold tag sets.
+func (e *Engine) stopScenarioMetrics() {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for _, runner := range e.scenarios {
+		if runner.Metrics != nil {
+			runner.Metrics.Stop()
+		}
+	}
+	for _, tagMetrics := range e.tagMetrics {
+		tagMetrics.Stop()
+	}
+}
+
+// thresholdEntry is a configured threshold together with the metric key
+// it applies to.
+type thresholdEntry struct {
+	key       string
+	threshold config.Threshold
+}
+
+// thresholdEntries returns all configured thresholds in reporting order:
+// the built-in metrics first, then scoped thresholds sorted by key.
+func (e *Engine) thresholdEntries() []thresholdEntry {
+	t := e.config.Thresholds
+	if t == nil {
+		return nil
+	}
+
+	var entries []thresholdEntry
+	add := func(key string, thresholds []config.Threshold) {
+		for _, threshold := range thresholds {
+			entries = append(entries, thresholdEntry{key: key, threshold: threshold})
+		}
+	}
+
+	add(config.MetricHTTPReqDuration, t.HTTPReqDuration)
+	add(config.MetricHTTPReqFailed, t.HTTPReqFailed)
+	add(config.MetricHTTPReqs, t.HTTPReqs)
+	add(config.MetricDroppedIterations, t.DroppedIterations)
+
+	keys := make([]string, 0, len(t.Custom))
+	for key := range t.Custom {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		add(key, t.Custom[key])
+	}
+
+	return entries
+}
+
 // evaluateThresholds evaluates all configured thresholds.
 func (e *Engine) evaluateThresholds(snapshot *metrics.Snapshot) []ThresholdResult {
-	if e.config.Thresholds == nil {
+	entries := e.thresholdEntries()
+	if len(entries) == 0 {
 		return nil
 	}
 
-	var results []ThresholdResult
+	e.mu.RLock()
+	abortIndex, abortResult := e.abortIndex, e.abortResult
+	e.mu.RUnlock()
 
-	// Evaluate http_req_duration thresholds
-	for _, expr := range e.config.Thresholds.HTTPReqDuration {
-		result := e.evaluateDurationThreshold(expr, snapshot)
+	results := make([]ThresholdResult, 0, len(entries))
+	for i, entry := range entries {
+		// The threshold that aborted the test is reported as it was when it
+		// failed, even if it would pass against the final metrics
+		if abortResult != nil && i == abortIndex {
+			results = append(results, *abortResult)
+			continue
+		}
+
+		result, err := e.evaluateThreshold(entry, snapshot)
+		if err != nil {
+			result = ThresholdResult{
+				Metric:      entry.key,
+				Expression:  entry.threshold.Expression,
+				AbortOnFail: entry.threshold.AbortOnFail,
+				Message:     err.Error(),
+			}
+		}
 		results = append(results, result)
 	}
 
-	// Evaluate http_req_failed thresholds
-	for _, expr := range e.config.Thresholds.HTTPReqFailed {
-		result := e.evaluateFailedThreshold(expr, snapshot)
-		results = append(results, result)
+	return results
+}
+
+// evaluateThreshold evaluates one threshold. Unscoped thresholds use the
+// given snapshot; scoped ones use the matching sub-metrics.
+//
+// An error means there are no metrics to evaluate the threshold against.
+func (e *Engine) evaluateThreshold(entry thresholdEntry, snapshot *metrics.Snapshot) (ThresholdResult, error) {
+	metric, err := config.ParseThresholdMetric(entry.key)
+	if err != nil {
+		return ThresholdResult{}, err
 	}
 
-	// Evaluate http_reqs thresholds
-	for _, expr := range e.config.Thresholds.HTTPReqs {
-		result := e.evaluateRequestsThreshold(expr, snapshot)
-		results = append(results, result)
+	if len(metric.Scope) > 0 {
+		snapshot, err = e.scopedSnapshot(metric)
+		if err != nil {
+			return ThresholdResult{}, err
+		}
 	}
 
-	return results
+	expr := entry.threshold.Expression
+	var result ThresholdResult
+	switch metric.Name {
+	case config.MetricHTTPReqDuration:
+		result = e.evaluateDurationThreshold(expr, snapshot)
+	case config.MetricHTTPReqBlocked, config.MetricHTTPReqLookingUp, config.MetricHTTPReqConnecting,
+		config.MetricHTTPReqTLSHandshaking, config.MetricHTTPReqSending, config.MetricHTTPReqWaiting,
+		config.MetricHTTPReqReceiving:
+		if snapshot.Timings == nil {
+			return ThresholdResult{}, fmt.Errorf("no %s timings were recorded", metric.Name)
+		}
+		result = evaluateLatencyThreshold(metric.Name, expr, phaseStats(metric.Name, snapshot.Timings))
+	case config.MetricHTTPReqFailed:
+		result = e.evaluateFailedThreshold(expr, snapshot)
+	case config.MetricHTTPReqs:
+		result = e.evaluateRequestsThreshold(expr, snapshot)
+	case config.MetricDroppedIterations:
+		result = e.evaluateDroppedThreshold(expr, snapshot)
+	}
+
+	result.Metric = entry.key
+	result.AbortOnFail = entry.threshold.AbortOnFail
+	return result, nil
+}
+
+// watchAbortThresholds periodically evaluates abortOnFail thresholds
+// against live metrics until done is closed, and stops the test on the
+// first breach.
+func (e *Engine) watchAbortThresholds(ctx context.Context, done <-chan struct{}) {
+	ticker := time.NewTicker(e.abortEvalInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if e.checkAbortThresholds(ctx) {
+				return
+			}
+		}
+	}
+}
+
+// checkAbortThresholds evaluates the abortOnFail thresholds whose
+// delayAbortEval has passed, and stops the test if one fails.
+// It returns true if the test was stopped.
+func (e *Engine) checkAbortThresholds(ctx context.Context) bool {
+	elapsed := time.Since(e.startTime)
+	snapshot := e.metricsEngine.GetSnapshot()
+
+	for i, entry := range e.thresholdEntries() {
+		if !entry.threshold.AbortOnFail || elapsed < time.Duration(entry.threshold.DelayAbortEval) {
+			continue
+		}
+
+		result, err := e.evaluateThreshold(entry, snapshot)
+		if err != nil || result.Passed {
+			// No data yet, or within the threshold
+			continue
+		}
+
+		result.Aborted = true
+		e.mu.Lock()
+		e.abortIndex = i
+		e.abortResult = &result
+		e.mu.Unlock()
+
+		reason := fmt.Sprintf("threshold %s %s failed", result.Metric, result.Expression)
+		if result.Value != "" {
+			reason += fmt.Sprintf(" (actual: %s)", result.Value)
+		}
+		e.StopWithReason(ctx, reason)
+		return true
+	}
+
+	return false
+}
+
+// scopedSnapshot returns the metrics a scoped threshold applies to.
+func (e *Engine) scopedSnapshot(metric config.ThresholdMetric) (*metrics.Snapshot, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	source := e.metricsEngine
+	var snapshot *metrics.Snapshot
+	tags := scopeTags(metric.Scope)
+
+	if scenario, ok := metric.Scope["scenario"]; ok {
+		runner, exists := e.scenarios[scenario]
+		if !exists {
+			return nil, fmt.Errorf("unknown scenario: %s", scenario)
+		}
+		if !matchesTags(runner.Config.Tags, tags) {
+			return nil, fmt.Errorf("scenario %s does not have tags %s", scenario, tagSetKey(tags))
+		}
+		source = runner.Metrics
+		if runner.Result != nil {
+			snapshot = runner.Result.Metrics
+		}
+	} else if len(tags) > 0 {
+		source = e.tagMetrics[tagSetKey(tags)]
+		if source == nil {
+			return nil, fmt.Errorf("no scenario has tags %s", tagSetKey(tags))
+		}
+	}
+
+	if name, ok := metric.Scope["name"]; ok {
+		if metric.Name == config.MetricDroppedIterations {
+			return nil, fmt.Errorf("dropped_iterations cannot be scoped by request name")
+		}
+		snapshot = source.GetRequestSnapshot(name)
+		if snapshot == nil {
+			return nil, fmt.Errorf("no requests named %s were recorded", name)
+		}
+		return snapshot, nil
+	}
+
+	if snapshot == nil {
+		snapshot = source.GetSnapshot()
+	}
+	return snapshot, nil
 }
 
 // evaluateDurationThreshold evaluates a duration threshold expression.
 func (e *Engine) evaluateDurationThreshold(expr string, snapshot *metrics.Snapshot) ThresholdResult {
+	return evaluateLatencyThreshold(config.MetricHTTPReqDuration, expr, snapshot.Latency)
+}
+
+// phaseStats returns the statistics of the request phase a phase metric
+// names.
+func phaseStats(metric string, timings *metrics.TimingStats) metrics.LatencyStats {
+	switch metric {
+	case config.MetricHTTPReqBlocked:
+		return timings.Blocked
+	case config.MetricHTTPReqLookingUp:
+		return timings.DNS
+	case config.MetricHTTPReqConnecting:
+		return timings.Connect
+	case config.MetricHTTPReqTLSHandshaking:
+		return timings.TLS
+	case config.MetricHTTPReqSending:
+		return timings.Sending
+	case config.MetricHTTPReqWaiting:
+		return timings.Waiting
+	default:
+		return timings.Receiving
+	}
+}
+
+// evaluateLatencyThreshold evaluates a threshold expression such as
+// "p95 < 500ms" against latency statistics.
+func evaluateLatencyThreshold(name, expr string, stats metrics.LatencyStats) ThresholdResult {
 	result := ThresholdResult{
-		Metric:     "http_req_duration",
+		Metric:     name,
 		Expression: expr,
 	}
 
@@ -468,19 +1103,19 @@ func (e *Engine) evaluateDurationThreshold(expr string, snapshot *metrics.Snapsh
 	var actualValue time.Duration
 	switch metric {
 	case "min":
-		actualValue = snapshot.Latency.Min
+		actualValue = stats.Min
 	case "max":
-		actualValue = snapshot.Latency.Max
+		actualValue = stats.Max
 	case "avg", "med":
-		actualValue = snapshot.Latency.Mean
+		actualValue = stats.Mean
 	case "p50":
-		actualValue = snapshot.Latency.P50
+		actualValue = stats.P50
 	case "p90":
-		actualValue = snapshot.Latency.P90
+		actualValue = stats.P90
 	case "p95":
-		actualValue = snapshot.Latency.P95
+		actualValue = stats.P95
 	case "p99":
-		actualValue = snapshot.Latency.P99
+		actualValue = stats.P99
 	default:
 		result.Message = fmt.Sprintf("unknown metric: %s", metric)
 		return result
@@ -579,6 +1214,50 @@ func (e *Engine) evaluateRequestsThreshold(expr string, snapshot *metrics.Snapsh
 	return result
 }
 
+// evaluateDroppedThreshold evaluates a dropped iteration count/rate threshold expression.
+func (e *Engine) evaluateDroppedThreshold(expr string, snapshot *metrics.Snapshot) ThresholdResult {
+	result := ThresholdResult{
+		Metric:     "dropped_iterations",
+		Expression: expr,
+	}
+
+	// Parse expression like "count == 0" or "rate < 1"
+	metric, op, valueStr, err := parseThresholdExpression(expr)
+	if err != nil {
+		result.Message = fmt.Sprintf("failed to parse expression: %v", err)
+		return result
+	}
+
+	thresholdValue, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		result.Message = fmt.Sprintf("failed to parse threshold value: %v", err)
+		return result
+	}
+
+	var actualValue float64
+	switch metric {
+	case "count":
+		actualValue = float64(snapshot.DroppedIterations)
+	case "rate":
+		// Dropped iterations per second over the whole test
+		if snapshot.Elapsed > 0 {
+			actualValue = float64(snapshot.DroppedIterations) / snapshot.Elapsed.Seconds()
+		}
+	default:
+		result.Message = fmt.Sprintf("dropped_iterations only supports 'count' or 'rate' metrics, got: %s", metric)
+		return result
+	}
+
+	result.Value = fmt.Sprintf("%.2f", actualValue)
+	result.Passed = compareValues(actualValue, op, thresholdValue)
+
+	if !result.Passed {
+		result.Message = fmt.Sprintf("%s is %.2f, threshold: %s %.2f", metric, actualValue, op, thresholdValue)
+	}
+
+	return result
+}
+
 // parseThresholdExpression parses an expression like "p95 < 500ms".
 func parseThresholdExpression(expr string) (metric, op, value string, err error) {
 	expr = strings.TrimSpace(expr)
@@ -620,18 +1299,75 @@ func (e *Engine) GetConfig() *config.TestConfig {
 
 // GetMetrics returns the current metrics snapshot.
 func (e *Engine) GetMetrics() *metrics.Snapshot {
-	if e.metricsEngine == nil {
+	source := e.globalMetrics()
+	if source == nil {
 		return nil
 	}
-	return e.metricsEngine.GetSnapshot()
+	return source.GetSnapshot()
+}
+
+// GetScenarioMetrics returns the current metrics snapshot of each scenario.
+func (e *Engine) GetScenarioMetrics() map[string]*metrics.Snapshot {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	snapshots := make(map[string]*metrics.Snapshot)
+	for name, runner := range e.scenarios {
+		if runner.Metrics != nil {
+			snapshots[name] = runner.Metrics.GetSnapshot()
+		}
+	}
+	return snapshots
+}
+
+// AddListener registers a listener for the request samples and time
+// buckets of every scenario, e.g. to stream them to an external system.
+// Listeners added during a run take effect from the next run.
+func (e *Engine) AddListener(l metrics.Listener) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.listeners = append(e.listeners, l)
+}
+
+// GetScenarioMetricsEngines returns the metrics engine of each scenario,
+// for exporters that need more than a snapshot.
+func (e *Engine) GetScenarioMetricsEngines() map[string]*metrics.Engine {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	engines := make(map[string]*metrics.Engine)
+	for name, runner := range e.scenarios {
+		if runner.Metrics != nil {
+			engines[name] = runner.Metrics
+		}
+	}
+	return engines
 }
 
 // GetTimeSeries returns the time series data.
 func (e *Engine) GetTimeSeries() []*metrics.TimeBucket {
-	if e.metricsEngine == nil {
+	source := e.globalMetrics()
+	if source == nil {
 		return nil
 	}
-	return e.metricsEngine.GetTimeSeries()
+	return source.GetTimeSeries()
+}
+
+// GetLatestBucket returns the most recent time bucket, or nil if none has
+// been emitted yet.
+func (e *Engine) GetLatestBucket() *metrics.TimeBucket {
+	source := e.globalMetrics()
+	if source == nil {
+		return nil
+	}
+	return source.GetLatestBucket()
+}
+
+// globalMetrics returns the metrics engine of the current or last run.
+func (e *Engine) globalMetrics() *metrics.Engine {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.metricsEngine
 }
 
 // IsRunning returns true if the engine is currently running.
@@ -643,13 +1379,24 @@ func (e *Engine) IsRunning() bool {
 
 // Stop gracefully stops the engine and all running scenarios.
 func (e *Engine) Stop(ctx context.Context) error {
-	e.mu.RLock()
+	return e.StopWithReason(ctx, "stopped")
+}
+
+// StopWithReason gracefully stops the engine and all running scenarios,
+// and marks the test result as aborted with the given reason. If the test
+// is stopped more than once, the first reason is kept.
+func (e *Engine) StopWithReason(ctx context.Context, reason string) error {
+	e.mu.Lock()
 	if !e.running {
-		e.mu.RUnlock()
+		e.mu.Unlock()
 		return nil
 	}
+	if !e.stopped {
+		e.stopped = true
+		e.abortReason = reason
+	}
 	scenarios := e.scenarios
-	e.mu.RUnlock()
+	e.mu.Unlock()
 
 	var lastErr error
 	for _, runner := range scenarios {
@@ -661,6 +1408,23 @@ func (e *Engine) Stop(ctx context.Context) error {
 	return lastErr
 }
 
+// isStopped reports whether the test has been stopped early.
+func (e *Engine) isStopped() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.stopped
+}
+
+// hasAbortThresholds reports whether any threshold has abortOnFail set.
+func (e *Engine) hasAbortThresholds() bool {
+	for _, entry := range e.thresholdEntries() {
+		if entry.threshold.AbortOnFail {
+			return true
+		}
+	}
+	return false
+}
+
 // GetProgress returns the overall test progress (0.0 to 1.0).
 func (e *Engine) GetProgress() float64 {
 	e.mu.RLock()
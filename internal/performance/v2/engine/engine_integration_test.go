@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/wesleyorama2/lunge/internal/performance/v2/config"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
 )
 
 // Test server types for different scenarios
@@ -435,6 +437,78 @@ func TestEngineIntegration_RampingArrivalRate(t *testing.T) {
 	t.Logf("  Iterations: %d", scenarioResult.Iterations)
 }
 
+// ============================================================================
+// Iteration-Based Executor Tests
+// ============================================================================
+
+func TestEngineIntegration_PerVUIterations(t *testing.T) {
+	server := createTestServer(serverNormal)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Per-VU Iterations Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"smoke": {
+				Executor:    "per-vu-iterations",
+				VUs:         3,
+				Iterations:  4,
+				MaxDuration: "30s",
+				Requests: []config.RequestConfig{
+					{Name: "get", Method: "GET", URL: server.URL},
+				},
+			},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(12), result.Scenarios["smoke"].Iterations)
+	assert.Equal(t, int64(12), result.Metrics.TotalRequests)
+	assert.Less(t, result.Duration, 5*time.Second, "should finish as soon as iterations complete")
+}
+
+func TestEngineIntegration_SharedIterations(t *testing.T) {
+	server := createTestServer(serverNormal)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Shared Iterations Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"seed": {
+				Executor:   "shared-iterations",
+				VUs:        4,
+				Iterations: 20,
+				Requests: []config.RequestConfig{
+					{Name: "create", Method: "POST", URL: server.URL},
+				},
+			},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(20), result.Scenarios["seed"].Iterations)
+	assert.Equal(t, int64(20), result.Metrics.TotalRequests)
+
+	stats := engine.GetScenarioStats()["seed"]
+	require.NotNil(t, stats)
+	assert.Len(t, stats.VUIterations, 4)
+}
+
 // ============================================================================
 // Multi-Scenario Tests
 // ============================================================================
@@ -534,6 +608,70 @@ func TestEngineIntegration_MultiScenario_DifferentExecutors(t *testing.T) {
 	t.Logf("Different Executors Test - Total Requests: %d", result.Metrics.TotalRequests)
 }
 
+func TestEngineIntegration_MultiScenario_IsolatedMetrics(t *testing.T) {
+	server := createTestServer(serverNormal)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Isolated Scenario Metrics Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"fast": {
+				Executor: "constant-vus",
+				VUs:      2,
+				Duration: "2s",
+				Requests: []config.RequestConfig{
+					{Name: "fast_req", Method: "GET", URL: server.URL + "/fast"},
+				},
+			},
+			"slow": {
+				Executor:        "constant-arrival-rate",
+				Rate:            5,
+				Duration:        "2s",
+				PreAllocatedVUs: 1,
+				MaxVUs:          2,
+				Requests: []config.RequestConfig{
+					{Name: "slow_req", Method: "GET", URL: server.URL + "/slow"},
+				},
+			},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	fast := result.Scenarios["fast"]
+	slow := result.Scenarios["slow"]
+	require.NotNil(t, fast)
+	require.NotNil(t, slow)
+
+	// Each scenario reports only its own requests
+	assert.Greater(t, fast.Metrics.TotalRequests, slow.Metrics.TotalRequests)
+	assert.Equal(t, result.Metrics.TotalRequests, fast.Metrics.TotalRequests+slow.Metrics.TotalRequests,
+		"Global totals should be the sum of the scenarios")
+	assert.Equal(t, result.Metrics.Latency.Count, fast.Metrics.Latency.Count+slow.Metrics.Latency.Count)
+
+	assert.Contains(t, fast.RequestStats, "fast_req")
+	assert.NotContains(t, fast.RequestStats, "slow_req")
+	assert.Contains(t, slow.RequestStats, "slow_req")
+	assert.NotContains(t, slow.RequestStats, "fast_req")
+
+	// Each scenario has its own time series and phase
+	assert.NotEmpty(t, fast.TimeSeries)
+	assert.NotEmpty(t, slow.TimeSeries)
+	assert.Equal(t, metrics.PhaseDone, fast.Metrics.CurrentPhase)
+	assert.Equal(t, metrics.PhaseDone, slow.Metrics.CurrentPhase)
+	assert.Equal(t, metrics.PhaseDone, result.Metrics.CurrentPhase)
+
+	t.Logf("Isolated Metrics Test - fast: %d, slow: %d, total: %d",
+		fast.Metrics.TotalRequests, slow.Metrics.TotalRequests, result.Metrics.TotalRequests)
+}
+
 // ============================================================================
 // Threshold Tests
 // ============================================================================
@@ -555,12 +693,12 @@ func TestEngineIntegration_Thresholds_Passing(t *testing.T) {
 			},
 		},
 		Thresholds: &config.ThresholdsConfig{
-			HTTPReqDuration: []string{
-				"p95 < 1s",    // Should pass - server has ~10ms latency
-				"avg < 500ms", // Should pass
+			HTTPReqDuration: []config.Threshold{
+				{Expression: "p95 < 1s"},    // Should pass - server has ~10ms latency
+				{Expression: "avg < 500ms"}, // Should pass
 			},
-			HTTPReqFailed: []string{
-				"rate < 0.1", // Should pass - no errors expected
+			HTTPReqFailed: []config.Threshold{
+				{Expression: "rate < 0.1"}, // Should pass - no errors expected
 			},
 		},
 	}
@@ -601,8 +739,8 @@ func TestEngineIntegration_Thresholds_Failing(t *testing.T) {
 			},
 		},
 		Thresholds: &config.ThresholdsConfig{
-			HTTPReqFailed: []string{
-				"rate < 0.01", // Should fail - server returns errors
+			HTTPReqFailed: []config.Threshold{
+				{Expression: "rate < 0.01"}, // Should fail - server returns errors
 			},
 		},
 	}
@@ -639,8 +777,8 @@ func TestEngineIntegration_Thresholds_RequestCount(t *testing.T) {
 			},
 		},
 		Thresholds: &config.ThresholdsConfig{
-			HTTPReqs: []string{
-				"count > 10", // Should have more than 10 requests in 2s with 2 VUs
+			HTTPReqs: []config.Threshold{
+				{Expression: "count > 10"}, // Should have more than 10 requests in 2s with 2 VUs
 			},
 		},
 	}
@@ -660,6 +798,308 @@ func TestEngineIntegration_Thresholds_RequestCount(t *testing.T) {
 	t.Logf("Request Count Test - Total: %d requests", result.Metrics.TotalRequests)
 }
 
+func TestEngineIntegration_Thresholds_DroppedIterations(t *testing.T) {
+	server := createTestServer(serverSlow)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Dropped Iterations Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"test": {
+				Executor:                 "constant-arrival-rate",
+				Rate:                     10,
+				Duration:                 "2s",
+				PreAllocatedVUs:          1,
+				MaxVUs:                   1, // One VU cannot keep up with 500ms responses
+				LatencyFromIntendedStart: true,
+				Requests: []config.RequestConfig{
+					{Method: "GET", URL: server.URL},
+				},
+			},
+		},
+		Thresholds: &config.ThresholdsConfig{
+			DroppedIterations: []config.Threshold{{Expression: "count == 0"}},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	assert.False(t, result.Passed, "Should fail dropped_iterations threshold")
+	assert.Greater(t, result.Metrics.DroppedIterations, int64(0), "Should have dropped iterations")
+	require.Len(t, result.Thresholds, 1)
+	assert.Equal(t, "dropped_iterations", result.Thresholds[0].Metric)
+	assert.False(t, result.Thresholds[0].Passed)
+
+	// Dropped iterations are part of the time series
+	var seriesDropped int64
+	for _, bucket := range result.TimeSeries {
+		seriesDropped += bucket.IntervalDroppedIterations
+	}
+	assert.Greater(t, seriesDropped, int64(0), "Time series should record dropped iterations")
+
+	t.Logf("Dropped Iterations Test - %d dropped, %d requests", result.Metrics.DroppedIterations, result.Metrics.TotalRequests)
+}
+
+func TestEngineIntegration_Thresholds_Scoped(t *testing.T) {
+	okServer := createTestServer(serverNormal)
+	defer okServer.Close()
+	errServer := createTestServer(serverError)
+	defer errServer.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Scoped Thresholds Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"browse": {
+				Executor: "constant-vus",
+				VUs:      2,
+				Duration: "1s",
+				Tags:     map[string]string{"env": "prod"},
+				Requests: []config.RequestConfig{
+					{Name: "Home", Method: "GET", URL: okServer.URL},
+				},
+			},
+			"api_integration": {
+				Executor: "constant-vus",
+				VUs:      1,
+				Duration: "1s",
+				Tags:     map[string]string{"env": "staging"},
+				Requests: []config.RequestConfig{
+					{Name: "Broken", Method: "GET", URL: errServer.URL},
+				},
+			},
+		},
+		Thresholds: &config.ThresholdsConfig{
+			HTTPReqs: []config.Threshold{{Expression: "count > 0"}},
+			Custom: map[string][]config.Threshold{
+				"http_req_failed{scenario:browse}":   {{Expression: "rate < 0.1"}},
+				"http_req_duration{name:Home}":       {{Expression: "p95 < 1s"}, {Expression: "max < 1ms"}},
+				"http_req_failed{env:prod}":          {{Expression: "rate < 0.1"}},
+				"http_reqs{env:staging,name:Broken}": {{Expression: "count > 0"}},
+				"api_integration_failed":             {{Expression: "rate < 0.1"}},
+			},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	// Built-in thresholds come first, then scoped ones sorted by key,
+	// one result per expression
+	require.Len(t, result.Thresholds, 7)
+	assert.Equal(t, "http_reqs", result.Thresholds[0].Metric)
+
+	byExpr := make(map[string]ThresholdResult)
+	for _, tr := range result.Thresholds[1:] {
+		byExpr[tr.Metric+" "+tr.Expression] = tr
+	}
+
+	browse := byExpr["http_req_failed{scenario:browse} rate < 0.1"]
+	assert.True(t, browse.Passed, "browse has no errors: %s", browse.Message)
+	prod := byExpr["http_req_failed{env:prod} rate < 0.1"]
+	assert.True(t, prod.Passed, "prod-tagged scenarios have no errors: %s", prod.Message)
+	assert.True(t, byExpr["http_req_duration{name:Home} p95 < 1s"].Passed)
+	assert.False(t, byExpr["http_req_duration{name:Home} max < 1ms"].Passed, "Home takes ~10ms")
+	assert.False(t, byExpr["api_integration_failed rate < 0.1"].Passed, "api_integration only gets errors")
+
+	staging := byExpr["http_reqs{env:staging,name:Broken} count > 0"]
+	assert.True(t, staging.Passed)
+	assert.Equal(t, fmt.Sprintf("%.2f", float64(result.Scenarios["api_integration"].Metrics.TotalRequests)), staging.Value,
+		"Tag scope should only count the matching scenario")
+
+	assert.False(t, result.Passed)
+}
+
+func TestEngineIntegration_Thresholds_PhaseTimings(t *testing.T) {
+	server := createTestServer(serverNormal)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Phase Timing Thresholds Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"test": {
+				Executor:    "per-vu-iterations",
+				VUs:         2,
+				Iterations:  5,
+				MaxDuration: "30s",
+				Requests: []config.RequestConfig{
+					{Name: "Home", Method: "GET", URL: server.URL},
+				},
+			},
+		},
+		Thresholds: &config.ThresholdsConfig{
+			Custom: map[string][]config.Threshold{
+				"http_req_waiting":                   {{Expression: "p95 < 5s"}, {Expression: "p95 < 1ms"}},
+				"http_req_waiting{name:Home}":        {{Expression: "p50 > 5ms"}},
+				"http_req_connecting{scenario:test}": {{Expression: "max < 5s"}},
+			},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	require.NotNil(t, result.Metrics.Timings)
+	assert.Equal(t, int64(10), result.Metrics.Timings.Waiting.Count)
+	assert.GreaterOrEqual(t, result.Metrics.Timings.Waiting.P50, 9*time.Millisecond, "the server takes ~10ms to respond")
+
+	byExpr := make(map[string]ThresholdResult)
+	for _, tr := range result.Thresholds {
+		byExpr[tr.Metric+" "+tr.Expression] = tr
+	}
+	require.Len(t, byExpr, 4)
+	assert.True(t, byExpr["http_req_waiting p95 < 5s"].Passed)
+	assert.False(t, byExpr["http_req_waiting p95 < 1ms"].Passed, "waiting takes ~10ms")
+	assert.True(t, byExpr["http_req_waiting{name:Home} p50 > 5ms"].Passed, byExpr["http_req_waiting{name:Home} p50 > 5ms"].Message)
+	assert.True(t, byExpr["http_req_connecting{scenario:test} max < 5s"].Passed)
+	assert.False(t, result.Passed)
+}
+
+func TestEngineIntegration_Thresholds_AbortOnFail(t *testing.T) {
+	server := createTestServer(serverError)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Abort On Fail Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"test": {
+				Executor: "constant-vus",
+				VUs:      2,
+				Duration: "30s",
+				Requests: []config.RequestConfig{
+					{Method: "GET", URL: server.URL},
+				},
+			},
+		},
+		Thresholds: &config.ThresholdsConfig{
+			HTTPReqDuration: []config.Threshold{{Expression: "p95 < 10s"}},
+			HTTPReqFailed:   []config.Threshold{{Expression: "rate < 0.1", AbortOnFail: true}},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+	engine.abortEvalInterval = 100 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	assert.Less(t, result.Duration, 10*time.Second, "Test should stop long before its 30s duration")
+	assert.True(t, result.Aborted)
+	assert.Contains(t, result.AbortReason, "http_req_failed rate < 0.1")
+	assert.False(t, result.Passed)
+
+	require.Len(t, result.Thresholds, 2)
+	assert.True(t, result.Thresholds[0].Passed)
+	assert.False(t, result.Thresholds[0].Aborted)
+	assert.False(t, result.Thresholds[1].Passed)
+	assert.True(t, result.Thresholds[1].AbortOnFail)
+	assert.True(t, result.Thresholds[1].Aborted, "The breached threshold should be marked as the abort cause")
+
+	t.Logf("Abort On Fail Test - stopped after %v: %s", result.Duration, result.AbortReason)
+}
+
+func TestEngineIntegration_Thresholds_DelayAbortEval(t *testing.T) {
+	server := createTestServer(serverError)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Delay Abort Eval Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"test": {
+				Executor: "constant-vus",
+				VUs:      1,
+				Duration: "30s",
+				Requests: []config.RequestConfig{
+					{Method: "GET", URL: server.URL},
+				},
+			},
+		},
+		Thresholds: &config.ThresholdsConfig{
+			HTTPReqFailed: []config.Threshold{{
+				Expression:     "rate < 0.1",
+				AbortOnFail:    true,
+				DelayAbortEval: config.Duration(1500 * time.Millisecond),
+			}},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+	engine.abortEvalInterval = 100 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	assert.True(t, result.Aborted)
+	assert.GreaterOrEqual(t, result.Duration, 1500*time.Millisecond, "Abort should wait for delayAbortEval")
+	assert.Less(t, result.Duration, 10*time.Second)
+}
+
+func TestEngineIntegration_Thresholds_AbortOnFail_NotBreached(t *testing.T) {
+	server := createTestServer(serverNormal)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Abort On Fail Passing Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"test": {
+				Executor: "constant-vus",
+				VUs:      1,
+				Duration: "1s",
+				Requests: []config.RequestConfig{
+					{Name: "Home", Method: "GET", URL: server.URL},
+				},
+			},
+		},
+		Thresholds: &config.ThresholdsConfig{
+			Custom: map[string][]config.Threshold{
+				// No request with this name is ever made, so there is nothing to abort on
+				"http_req_failed{name:Missing}": {{Expression: "rate < 0.1", AbortOnFail: true}},
+				"http_req_duration{name:Home}":  {{Expression: "p95 < 10s", AbortOnFail: true}},
+			},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+	engine.abortEvalInterval = 100 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	assert.False(t, result.Aborted)
+	assert.Empty(t, result.AbortReason)
+	assert.GreaterOrEqual(t, result.Duration, time.Second)
+}
+
 // ============================================================================
 // Error Handling Tests
 // ============================================================================
@@ -757,8 +1197,8 @@ func TestEngineIntegration_SlowServer(t *testing.T) {
 			},
 		},
 		Thresholds: &config.ThresholdsConfig{
-			HTTPReqDuration: []string{
-				"p95 < 100ms", // Should fail - server has 500ms latency
+			HTTPReqDuration: []config.Threshold{
+				{Expression: "p95 < 100ms"}, // Should fail - server has 500ms latency
 			},
 		},
 	}
@@ -777,7 +1217,422 @@ func TestEngineIntegration_SlowServer(t *testing.T) {
 	assert.True(t, result.Metrics.Latency.P95 > 400*time.Millisecond,
 		"P95 latency should be high")
 
-	t.Logf("Slow Server Test - P95: %v", result.Metrics.Latency.P95)
+	t.Logf("Slow Server Test - P95: %v", result.Metrics.Latency.P95)
+}
+
+func TestEngineIntegration_RequestTimeout(t *testing.T) {
+	server := createTestServer(serverSlow)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Request Timeout Test",
+		Settings: config.GlobalSettings{
+			Timeout: config.Duration(100 * time.Millisecond),
+		},
+		Scenarios: map[string]*config.ScenarioConfig{
+			"test": {
+				Executor:    "per-vu-iterations",
+				VUs:         1,
+				Iterations:  2,
+				MaxDuration: "30s",
+				Requests: []config.RequestConfig{
+					// Bounded by the global timeout
+					{Name: "default", Method: "GET", URL: server.URL},
+					// Its own timeout overrides the shorter global one
+					{Name: "long", Method: "GET", URL: server.URL, Timeout: "2s"},
+				},
+			},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(4), result.Metrics.TotalRequests)
+	assert.Equal(t, int64(2), result.Metrics.FailedRequests)
+	assert.Equal(t, int64(2), result.Metrics.TimedOutRequests)
+	assert.Equal(t, map[string]int64{"default": 2}, result.Metrics.TimedOutRequestsByName)
+	assert.Equal(t, int64(2), result.Scenarios["test"].Metrics.TimedOutRequests)
+}
+
+func TestEngineIntegration_ExpectedStatuses(t *testing.T) {
+	server := createTestServer(serverError)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Expected Statuses Test",
+		Settings: config.GlobalSettings{
+			ExpectedStatuses: config.StatusList{"500"},
+		},
+		// Setup fails on an unexpected status, so this checks the global default
+		Setup: []config.RequestConfig{
+			{Name: "setup", Method: "GET", URL: server.URL},
+		},
+		Scenarios: map[string]*config.ScenarioConfig{
+			"lenient": {
+				Executor:    "per-vu-iterations",
+				VUs:         1,
+				Iterations:  2,
+				MaxDuration: "30s",
+				Requests: []config.RequestConfig{
+					{Name: "global", Method: "GET", URL: server.URL},
+					{Name: "own", Method: "GET", URL: server.URL, ExpectedStatuses: config.StatusList{"200"}},
+				},
+			},
+			"strict": {
+				Executor:         "per-vu-iterations",
+				VUs:              1,
+				Iterations:       2,
+				MaxDuration:      "30s",
+				ExpectedStatuses: config.StatusList{"200-299"},
+				Requests: []config.RequestConfig{
+					{Name: "scenario", Method: "GET", URL: server.URL},
+				},
+			},
+		},
+		Thresholds: &config.ThresholdsConfig{
+			Custom: map[string][]config.Threshold{
+				"http_req_failed{scenario:lenient}": {{Expression: "rate <= 0.5"}},
+			},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	lenient := result.Scenarios["lenient"].Metrics
+	assert.Equal(t, int64(2), lenient.SuccessRequests)
+	assert.Equal(t, int64(2), lenient.FailedRequests)
+	assert.InDelta(t, 0.5, lenient.ErrorRate, 0.001)
+
+	strict := result.Scenarios["strict"].Metrics
+	assert.Equal(t, int64(0), strict.SuccessRequests)
+	assert.Equal(t, int64(2), strict.FailedRequests)
+
+	assert.True(t, result.Passed, "http_req_failed should follow the expected statuses")
+}
+
+func TestEngineIntegration_Groups(t *testing.T) {
+	server := createTestServer(serverNormal)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Groups Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"shop": {
+				Executor:    "per-vu-iterations",
+				VUs:         1,
+				Iterations:  2,
+				MaxDuration: "30s",
+				Requests: []config.RequestConfig{
+					{Name: "home", Method: "GET", URL: server.URL},
+					{
+						Group:  "checkout flow",
+						Repeat: 2,
+						Requests: []config.RequestConfig{
+							{Name: "cart", Method: "GET", URL: server.URL},
+							{Group: "payment", Requests: []config.RequestConfig{
+								{Method: "GET", URL: server.URL},
+							}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(10), result.Metrics.TotalRequests)
+
+	require.Len(t, result.Metrics.Groups, 2)
+	assert.Equal(t, "checkout flow", result.Metrics.Groups[0].Name)
+	assert.Equal(t, int64(4), result.Metrics.Groups[0].Duration.Count)
+	assert.Equal(t, "checkout flow::payment", result.Metrics.Groups[1].Name)
+	assert.Equal(t, int64(4), result.Metrics.Groups[1].Duration.Count)
+	assert.Len(t, result.Scenarios["shop"].Metrics.Groups, 2)
+
+	stats := result.Scenarios["shop"].RequestStats
+	assert.Equal(t, "", stats["home"].Group)
+	assert.Equal(t, "checkout flow", stats["cart"].Group)
+	assert.Equal(t, "checkout flow::payment", stats["payment_request_1"].Group)
+	assert.Equal(t, int64(4), stats["payment_request_1"].Count)
+}
+
+func TestEngineIntegration_ConditionsAndLoops(t *testing.T) {
+	server := createTestServer(serverNormal)
+	defer server.Close()
+
+	counter := []config.ExtractConfig{{Name: "n", Source: "body", Path: "$.request"}}
+	cfg := &config.TestConfig{
+		Name: "Conditions and Loops Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"flow": {
+				Executor:    "per-vu-iterations",
+				VUs:         1,
+				Iterations:  2,
+				MaxDuration: "30s",
+				Requests: []config.RequestConfig{
+					{
+						Name:    "poll",
+						Method:  "GET",
+						URL:     server.URL,
+						Extract: counter,
+						Repeat:  10,
+						Until:   &config.ConditionConfig{Variable: "n", Condition: "gte", Value: "3"},
+					},
+					{
+						Name:   "bonus",
+						Method: "GET",
+						URL:    server.URL,
+						When:   &config.ConditionConfig{Variable: "n", Condition: "eq", Value: "3"},
+					},
+					{
+						Group:  "verify",
+						Repeat: 2,
+						When:   &config.ConditionConfig{Source: "status", Condition: "eq", Value: "200"},
+						Requests: []config.RequestConfig{
+							{Name: "check", Method: "GET", URL: server.URL},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	// First iteration: poll x3, bonus, check x2; second: poll, check x2
+	assert.Equal(t, int64(9), result.Metrics.TotalRequests)
+
+	stats := result.Scenarios["flow"].RequestStats
+	assert.Equal(t, int64(4), stats["poll"].Count)
+	assert.Equal(t, int64(1), stats["bonus"].Count)
+	assert.Equal(t, int64(4), stats["check"].Count)
+
+	require.Len(t, result.Metrics.Groups, 1)
+	assert.Equal(t, int64(4), result.Metrics.Groups[0].Duration.Count)
+}
+
+func TestEngineIntegration_WeightedFlow(t *testing.T) {
+	server := createTestServer(serverNormal)
+	defer server.Close()
+
+	seed := int64(7)
+	cfg := &config.TestConfig{
+		Name: "Weighted Flow Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"mix": {
+				Executor:    "per-vu-iterations",
+				VUs:         2,
+				Iterations:  100,
+				MaxDuration: "30s",
+				Flow:        "weighted",
+				Seed:        &seed,
+				Requests: []config.RequestConfig{
+					{Name: "search", Method: "GET", URL: server.URL, Weight: 70},
+					{Name: "view", Method: "GET", URL: server.URL, Weight: 20},
+					{Group: "purchase", Weight: 10, Requests: []config.RequestConfig{
+						{Name: "cart", Method: "GET", URL: server.URL},
+						{Name: "pay", Method: "GET", URL: server.URL},
+					}},
+				},
+			},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	mix := result.Scenarios["mix"].Mix
+	require.Len(t, mix, 3)
+
+	var iterations int64
+	for i, name := range []string{"search", "view", "purchase"} {
+		assert.Equal(t, name, mix[i].Name)
+		iterations += mix[i].Iterations
+	}
+	assert.Equal(t, int64(200), iterations)
+	assert.InDelta(t, 0.7, mix[0].Target, 0.001)
+	assert.InDelta(t, 0.1, mix[2].Target, 0.001)
+	assert.InDelta(t, 0.7, mix[0].Actual, 0.1)
+	assert.InDelta(t, float64(mix[0].Iterations)/200, mix[0].Actual, 0.001)
+
+	// A purchase runs both of its requests
+	stats := result.Scenarios["mix"].RequestStats
+	assert.Equal(t, mix[2].Iterations, stats["pay"].Count)
+	assert.Equal(t, 200+mix[2].Iterations, result.Metrics.TotalRequests)
+}
+
+func TestEngineIntegration_ExternallyControlled(t *testing.T) {
+	server := createTestServer(serverNormal)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Externally Controlled Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"explore": {
+				Executor: "externally-controlled",
+				VUs:      1,
+				MaxVUs:   5,
+				Requests: []config.RequestConfig{{Name: "explore", Method: "GET", URL: server.URL}},
+			},
+			"background": {
+				Executor: "constant-vus",
+				VUs:      1,
+				Duration: "30s",
+				Requests: []config.RequestConfig{{Name: "background", Method: "GET", URL: server.URL}},
+			},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+	require.ErrorIs(t, engine.Pause(""), ErrNotRunning)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var result *TestResult
+	done := make(chan struct{})
+	go func() {
+		result, err = engine.Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool {
+		return len(engine.ControlStatus().Scenarios) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, engine.ScaleVUs("", 3))
+	assert.ErrorIs(t, engine.ScaleVUs("missing", 1), ErrScenarioNotFound)
+	assert.ErrorIs(t, engine.ScaleVUs("background", 1), ErrNotControllable)
+	assert.Error(t, engine.SetRate("explore", 5), "VU mode has no rate")
+	assert.Equal(t, 3, engine.ControlStatus().Scenarios["explore"].VUs)
+
+	require.NoError(t, engine.Pause("explore"))
+	assert.True(t, engine.ControlStatus().Scenarios["explore"].Paused)
+	require.NoError(t, engine.Resume(""))
+
+	time.Sleep(200 * time.Millisecond)
+	require.NoError(t, engine.StopFromControl(context.Background()))
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Run() did not return after StopFromControl()")
+	}
+	require.NoError(t, err)
+
+	assert.True(t, result.Aborted)
+	assert.Equal(t, "stopped by control API", result.AbortReason)
+	assert.True(t, result.Scenarios["explore"].Iterations > 0)
+
+	// Only changes that were applied are recorded
+	require.Len(t, result.ControlEvents, 4)
+	var actions []string
+	for _, event := range result.ControlEvents {
+		actions = append(actions, event.Action)
+	}
+	assert.Equal(t, []string{ControlVUs, ControlPause, ControlResume, ControlStop}, actions)
+	assert.Equal(t, "explore", result.ControlEvents[0].Scenario)
+	assert.Equal(t, float64(3), result.ControlEvents[0].Value)
+	assert.True(t, result.ControlEvents[3].Elapsed >= result.ControlEvents[0].Elapsed)
+}
+
+// sampleCounter counts the samples and buckets it receives, per scenario.
+type sampleCounter struct {
+	mu        sync.Mutex
+	scenarios map[string]int
+	buckets   int
+}
+
+func (c *sampleCounter) AddSample(s metrics.Sample) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.scenarios[s.Scenario]++
+}
+
+func (c *sampleCounter) AddBucket(*metrics.TimeBucket) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.buckets++
+}
+
+func TestEngineIntegration_Listener(t *testing.T) {
+	server := createTestServer(serverNormal)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Listener Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"first": {
+				Executor: "constant-vus",
+				VUs:      1,
+				Duration: "300ms",
+				Requests: []config.RequestConfig{{Name: "first", Method: "GET", URL: server.URL}},
+			},
+			"second": {
+				Executor: "constant-vus",
+				VUs:      1,
+				Duration: "300ms",
+				Requests: []config.RequestConfig{{Name: "second", Method: "GET", URL: server.URL}},
+			},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	counter := &sampleCounter{scenarios: make(map[string]int)}
+	engine.AddListener(counter)
+
+	result, err := engine.Run(context.Background())
+	require.NoError(t, err)
+
+	counter.mu.Lock()
+	defer counter.mu.Unlock()
+
+	// Every request reaches the listener once, with its scenario
+	assert.Equal(t, int(result.Scenarios["first"].Metrics.TotalRequests), counter.scenarios["first"])
+	assert.Equal(t, int(result.Scenarios["second"].Metrics.TotalRequests), counter.scenarios["second"])
+	assert.Len(t, counter.scenarios, 2)
+
+	// Buckets come from the global metrics engine only, including the
+	// final one emitted when it stops after the result is built
+	assert.GreaterOrEqual(t, counter.buckets, len(result.TimeSeries)+1)
 }
 
 // ============================================================================
@@ -935,6 +1790,454 @@ func TestEngineIntegration_ContextCancellation(t *testing.T) {
 	t.Logf("Context Cancellation Test - Stopped in %v", elapsed)
 }
 
+// ============================================================================
+// Setup and Teardown Tests
+// ============================================================================
+
+// createLifecycleTestServer creates a server with a login endpoint issuing a
+// token, a protected endpoint and a cleanup endpoint. It counts protected
+// requests made with the token and cleanup requests.
+func createLifecycleTestServer(authorized, cleanups *atomic.Int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"token": "setup-token"}`))
+		case "/fail":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/cleanup/setup-token":
+			cleanups.Add(1)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			if r.Header.Get("Authorization") == "Bearer setup-token" {
+				authorized.Add(1)
+			}
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+}
+
+func TestEngineIntegration_SetupTeardown(t *testing.T) {
+	var authorized, cleanups atomic.Int64
+	server := createLifecycleTestServer(&authorized, &cleanups)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Setup Teardown Test",
+		Settings: config.GlobalSettings{
+			BaseURL: server.URL,
+		},
+		Setup: []config.RequestConfig{
+			{
+				Name:    "login",
+				Method:  "POST",
+				URL:     "{{baseUrl}}/login",
+				Extract: []config.ExtractConfig{{Name: "token", Source: "body", Path: "$.token"}},
+			},
+		},
+		Scenarios: map[string]*config.ScenarioConfig{
+			"test": {
+				Executor:   "per-vu-iterations",
+				VUs:        2,
+				Iterations: 5,
+				Requests: []config.RequestConfig{
+					{
+						Method:  "GET",
+						URL:     "{{baseUrl}}/api",
+						Headers: map[string]string{"Authorization": "Bearer {{token}}"},
+					},
+				},
+			},
+		},
+		Teardown: []config.RequestConfig{
+			{Name: "cleanup", Method: "DELETE", URL: "{{baseUrl}}/cleanup/{{token}}"},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(10), authorized.Load(), "Every scenario request should use the setup token")
+	assert.Equal(t, int64(1), cleanups.Load(), "Teardown should run once with the setup token")
+	assert.Equal(t, int64(10), result.Metrics.TotalRequests, "Setup and teardown should not count as test requests")
+
+	require.NotNil(t, result.Setup)
+	assert.Equal(t, int64(1), result.Setup.Requests)
+	assert.Empty(t, result.Setup.Error)
+	require.NotNil(t, result.Teardown)
+	assert.Equal(t, int64(1), result.Teardown.Requests)
+	assert.Empty(t, result.Teardown.Error)
+}
+
+func TestEngineIntegration_SetupFailure(t *testing.T) {
+	var authorized, cleanups atomic.Int64
+	server := createLifecycleTestServer(&authorized, &cleanups)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Setup Failure Test",
+		Settings: config.GlobalSettings{
+			BaseURL: server.URL,
+		},
+		Setup: []config.RequestConfig{
+			{
+				Name:    "login",
+				Method:  "POST",
+				URL:     "{{baseUrl}}/login",
+				Extract: []config.ExtractConfig{{Name: "token", Source: "body", Path: "$.token"}},
+			},
+			{Name: "create_tenant", Method: "POST", URL: "{{baseUrl}}/fail"},
+		},
+		Scenarios: map[string]*config.ScenarioConfig{
+			"test": {
+				Executor:   "per-vu-iterations",
+				VUs:        1,
+				Iterations: 5,
+				Requests: []config.RequestConfig{
+					{Method: "GET", URL: "{{baseUrl}}/api"},
+				},
+			},
+		},
+		Teardown: []config.RequestConfig{
+			{Method: "DELETE", URL: "{{baseUrl}}/cleanup/{{token}}"},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.Error(t, err)
+	require.NotNil(t, result)
+
+	assert.True(t, result.Aborted)
+	assert.False(t, result.Passed)
+	assert.Contains(t, result.AbortReason, "setup failed: request create_tenant failed")
+	assert.Equal(t, int64(0), result.Metrics.TotalRequests, "No scenario should run after a failed setup")
+	assert.Equal(t, int64(1), cleanups.Load(), "Teardown should run with the data extracted before setup failed")
+	require.NotNil(t, result.Setup)
+	assert.Equal(t, int64(2), result.Setup.Requests)
+	assert.NotEmpty(t, result.Setup.Error)
+	require.NotNil(t, result.Teardown)
+	assert.Empty(t, result.Teardown.Error)
+}
+
+func TestEngineIntegration_TeardownAfterCancellation(t *testing.T) {
+	var authorized, cleanups atomic.Int64
+	server := createLifecycleTestServer(&authorized, &cleanups)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Teardown After Cancellation Test",
+		Settings: config.GlobalSettings{
+			BaseURL: server.URL,
+		},
+		Setup: []config.RequestConfig{
+			{
+				Method:  "POST",
+				URL:     "{{baseUrl}}/login",
+				Extract: []config.ExtractConfig{{Name: "token", Source: "body", Path: "$.token"}},
+			},
+		},
+		Scenarios: map[string]*config.ScenarioConfig{
+			"test": {
+				Executor: "constant-vus",
+				VUs:      1,
+				Duration: "30s",
+				Requests: []config.RequestConfig{
+					{Method: "GET", URL: "{{baseUrl}}/api"},
+				},
+			},
+		},
+		Teardown: []config.RequestConfig{
+			{Method: "DELETE", URL: "{{baseUrl}}/cleanup/{{token}}"},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		cancel()
+	}()
+
+	_, _ = engine.Run(ctx)
+
+	assert.Equal(t, int64(1), cleanups.Load(), "Teardown should still run after cancellation")
+}
+
+func TestEngineIntegration_StopDuringSetup(t *testing.T) {
+	var scenarioRequests, cleanups atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow-setup":
+			time.Sleep(300 * time.Millisecond)
+		case "/cleanup":
+			cleanups.Add(1)
+		default:
+			scenarioRequests.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Stop During Setup Test",
+		Setup: []config.RequestConfig{
+			{Method: "POST", URL: server.URL + "/slow-setup"},
+		},
+		Scenarios: map[string]*config.ScenarioConfig{
+			"test": {
+				Executor: "constant-vus",
+				VUs:      1,
+				Duration: "30s",
+				Requests: []config.RequestConfig{
+					{Method: "GET", URL: server.URL + "/api"},
+				},
+			},
+		},
+		Teardown: []config.RequestConfig{
+			{Method: "DELETE", URL: server.URL + "/cleanup"},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		engine.StopWithReason(context.Background(), "interrupted by SIGINT")
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	assert.Less(t, result.Duration, 5*time.Second)
+	assert.True(t, result.Aborted)
+	assert.Equal(t, "interrupted by SIGINT", result.AbortReason)
+	assert.Equal(t, int64(0), scenarioRequests.Load(), "Scenarios should not start after a stop during setup")
+	assert.Equal(t, int64(1), cleanups.Load(), "Teardown should still run")
+}
+
+func TestEngineIntegration_StopLetsInFlightRequestsFinish(t *testing.T) {
+	tests := []struct {
+		name     string
+		scenario *config.ScenarioConfig
+	}{
+		{
+			name: "constant-vus",
+			scenario: &config.ScenarioConfig{
+				Executor:     "constant-vus",
+				VUs:          2,
+				Duration:     "30s",
+				GracefulStop: "5s",
+			},
+		},
+		{
+			name: "ramping-vus",
+			scenario: &config.ScenarioConfig{
+				Executor:     "ramping-vus",
+				Stages:       []config.StageConfig{{Duration: "100ms", Target: 2}, {Duration: "30s", Target: 2}},
+				GracefulStop: "5s",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var started atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				started.Add(1)
+				time.Sleep(500 * time.Millisecond)
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			tt.scenario.Requests = []config.RequestConfig{{Name: "slow", Method: "GET", URL: server.URL}}
+			engine, err := NewEngine(&config.TestConfig{
+				Name:      "Stop In Flight Test",
+				Scenarios: map[string]*config.ScenarioConfig{"test": tt.scenario},
+			})
+			require.NoError(t, err)
+
+			// Stop as Ctrl+C does while the first requests are in flight
+			go func() {
+				for started.Load() < 2 {
+					time.Sleep(10 * time.Millisecond)
+				}
+				engine.StopWithReason(context.Background(), "interrupted by SIGINT")
+			}()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			result, err := engine.Run(ctx)
+			require.NoError(t, err)
+
+			assert.True(t, result.Aborted)
+			assert.Less(t, result.Duration, 5*time.Second)
+			assert.Equal(t, started.Load(), result.Metrics.TotalRequests, "Every started request should be recorded")
+			assert.Equal(t, result.Metrics.TotalRequests, result.Metrics.SuccessRequests, "In-flight requests should complete rather than be cancelled")
+			assert.Equal(t, int64(0), result.Metrics.FailedRequests)
+		})
+	}
+}
+
+// ============================================================================
+// Data File Tests
+// ============================================================================
+
+// writeDataFile writes a CSV data file with an id column of n rows.
+func writeDataFile(t *testing.T, n int) string {
+	t.Helper()
+	content := "id,name\n"
+	for i := 1; i <= n; i++ {
+		content += fmt.Sprintf("%d,user%d\n", i, i)
+	}
+	path := filepath.Join(t.TempDir(), "users.csv")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+// createDataTestServer creates a server that counts requests by path.
+func createDataTestServer(mu *sync.Mutex, paths map[string]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths[r.URL.Path]++
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestEngineIntegration_Data_UniqueStop(t *testing.T) {
+	var mu sync.Mutex
+	paths := make(map[string]int)
+	server := createDataTestServer(&mu, paths)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Data Unique Stop Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"test": {
+				Executor:   "shared-iterations",
+				VUs:        3,
+				Iterations: 50,
+				Data: &config.DataConfig{
+					File:        writeDataFile(t, 5),
+					Mode:        "unique",
+					OnExhausted: "stop",
+				},
+				Requests: []config.RequestConfig{
+					{Method: "GET", URL: server.URL + "/users/{{data.id}}/{{data.name}}"},
+				},
+			},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	assert.False(t, result.Aborted, "Running out of data with stop should not abort the test")
+	assert.Equal(t, int64(5), result.Metrics.TotalRequests)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, paths, 5, "Every row should be used")
+	for i := 1; i <= 5; i++ {
+		assert.Equal(t, 1, paths[fmt.Sprintf("/users/%d/user%d", i, i)], "Row %d should be used exactly once", i)
+	}
+}
+
+func TestEngineIntegration_Data_Abort(t *testing.T) {
+	var mu sync.Mutex
+	paths := make(map[string]int)
+	server := createDataTestServer(&mu, paths)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Data Abort Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"test": {
+				Executor: "constant-vus",
+				VUs:      2,
+				Duration: "30s",
+				Data: &config.DataConfig{
+					File:        writeDataFile(t, 3),
+					OnExhausted: "abort",
+				},
+				Requests: []config.RequestConfig{
+					{Method: "GET", URL: server.URL + "/users/{{data.id}}"},
+				},
+			},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	assert.Less(t, result.Duration, 10*time.Second, "Test should stop long before its 30s duration")
+	assert.True(t, result.Aborted)
+	assert.Contains(t, result.AbortReason, "scenario test ran out of data")
+	assert.True(t, result.DataExhausted)
+
+	// Each VU walks the rows in order, so no row is used more than twice
+	mu.Lock()
+	defer mu.Unlock()
+	for path, count := range paths {
+		assert.LessOrEqual(t, count, 2, "%s requested too often", path)
+	}
+}
+
+func TestEngineIntegration_Data_MissingFile(t *testing.T) {
+	cfg := &config.TestConfig{
+		Name: "Data Missing File Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"test": {
+				Executor: "constant-vus",
+				VUs:      1,
+				Duration: "1s",
+				Data:     &config.DataConfig{File: filepath.Join(t.TempDir(), "missing.csv")},
+				Requests: []config.RequestConfig{
+					{Method: "GET", URL: "http://localhost/{{data.id}}"},
+				},
+			},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	_, err = engine.Run(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to load data for scenario test")
+}
+
 // ============================================================================
 // Time Series Data Tests
 // ============================================================================
@@ -1155,3 +2458,54 @@ func TestEngineIntegration_Variables(t *testing.T) {
 	assert.True(t, result.Metrics.TotalRequests > 0, "Should have made requests with variable substitution")
 	t.Logf("Variables Test - Requests: %d", result.Metrics.TotalRequests)
 }
+
+func TestEngineIntegration_Assertions(t *testing.T) {
+	server := createTestServer(serverNormal)
+	defer server.Close()
+
+	cfg := &config.TestConfig{
+		Name: "Assertions Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"test": {
+				Executor: "constant-vus",
+				VUs:      1,
+				Duration: "1s",
+				Requests: []config.RequestConfig{
+					{
+						Name:   "get_status",
+						Method: "GET",
+						URL:    server.URL,
+						Assertions: []config.AssertionConfig{
+							{Type: "status", Condition: "eq", Value: "200"},
+							{Type: "body", Condition: "eq", Value: "ok", Path: "$.status"},
+							{Type: "body", Condition: "eq", Value: "missing", Path: "$.status", Message: "wrong status"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	engine, err := NewEngine(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := engine.Run(ctx)
+	require.NoError(t, err)
+
+	checks := result.Metrics.Checks
+	require.Len(t, checks, 3)
+
+	for _, check := range checks {
+		total := check.Passes + check.Fails
+		assert.True(t, total > 0, "check %s should be evaluated", check.Name)
+		assert.LessOrEqual(t, total, result.Metrics.TotalRequests)
+		if check.Name == "get_status: wrong status" {
+			assert.Equal(t, int64(0), check.Passes)
+		} else {
+			assert.Equal(t, int64(0), check.Fails, "check %s should pass", check.Name)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v2 "github.com/wesleyorama2/lunge/internal/performance/v2"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/config"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+)
+
+// defaultLifecycleTimeout is the setup and teardown timeout when none is configured.
+const defaultLifecycleTimeout = 60 * time.Second
+
+// LifecycleResult contains the outcome of the setup or teardown phase.
+type LifecycleResult struct {
+	Duration time.Duration `json:"duration"`
+	Requests int64         `json:"requests"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// runSetup runs the setup requests, if any, and returns the variables they
+// extracted. If setup fails, the variables extracted before the failure are
+// still returned so teardown can clean up after them.
+func (e *Engine) runSetup(ctx context.Context) (map[string]string, *LifecycleResult, error) {
+	if len(e.config.Setup) == 0 {
+		return nil, nil, nil
+	}
+
+	var timeout string
+	if e.config.Options != nil {
+		timeout = e.config.Options.SetupTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, lifecycleTimeout(timeout))
+	defer cancel()
+
+	return e.runLifecycle(ctx, "setup", e.config.Setup, nil)
+}
+
+// runTeardown runs the teardown requests, if any, with the setup data
+// available.
+//
+// Teardown is only bounded by its timeout, not by ctx, so it still runs
+// after the test has been cancelled.
+func (e *Engine) runTeardown(ctx context.Context, setupData map[string]string) (*LifecycleResult, error) {
+	if len(e.config.Teardown) == 0 {
+		return nil, nil
+	}
+
+	var timeout string
+	if e.config.Options != nil {
+		timeout = e.config.Options.TeardownTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), lifecycleTimeout(timeout))
+	defer cancel()
+
+	_, result, err := e.runLifecycle(ctx, "teardown", e.config.Teardown, setupData)
+	return result, err
+}
+
+// runLifecycle runs requests once, in order, on a dedicated VU and returns
+// the variables it extracted, including on failure.
+//
+// Lifecycle requests are recorded in their own metrics engine so they don't
+// count towards the test's metrics or thresholds.
+func (e *Engine) runLifecycle(ctx context.Context, phase string, requests []config.RequestConfig, setupData map[string]string) (map[string]string, *LifecycleResult, error) {
+	scenario := e.createRequestScenario(phase, nil, nil, requests)
+	scenario.SetupData = setupData
+
+	phaseMetrics := metrics.NewEngine()
+	defer phaseMetrics.Stop()
+
+	scheduler := v2.NewVUScheduler(scenario, phaseMetrics, e.httpConfig)
+	defer scheduler.Shutdown(time.Second)
+
+	vu := scheduler.SpawnVU()
+	defer vu.MarkStopped()
+
+	startTime := time.Now()
+	err := vu.RunOnce(ctx)
+
+	result := &LifecycleResult{
+		Duration: time.Since(startTime),
+		Requests: phaseMetrics.GetSnapshot().TotalRequests,
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return vu.Variables(), result, fmt.Errorf("%s failed: %w", phase, err)
+	}
+
+	return vu.Variables(), result, nil
+}
+
+// lifecycleTimeout parses a setup or teardown timeout, falling back to
+// the default.
+func lifecycleTimeout(value string) time.Duration {
+	if value != "" {
+		if d, err := config.ParseDurationString(value); err == nil && d > 0 {
+			return d
+		}
+	}
+	return defaultLifecycleTimeout
+}
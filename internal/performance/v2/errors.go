@@ -0,0 +1,61 @@
+package v2
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+)
+
+// classifyError returns the category and message of a failed request's
+// error.
+//
+// The message leaves out the method and URL that net/http adds, so the
+// same failure on different URLs is counted once.
+func classifyError(result *RequestResult) (metrics.ErrorCategory, string) {
+	err := result.Error
+
+	message := err.Error()
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		message = strings.Replace(message, urlErr.Error(), urlErr.Err.Error(), 1)
+	}
+
+	return errorCategory(result, err), message
+}
+
+// errorCategory classifies why a request failed.
+func errorCategory(result *RequestResult, err error) metrics.ErrorCategory {
+	if result.TimedOut {
+		return metrics.ErrorTimeout
+	}
+
+	var dnsErr *net.DNSError
+	var recordErr tls.RecordHeaderError
+	var certErr *tls.CertificateVerificationError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return metrics.ErrorConnectionRefused
+	case errors.As(err, &dnsErr):
+		return metrics.ErrorDNS
+	case errors.As(err, &recordErr), errors.As(err, &certErr), errors.As(err, &authorityErr),
+		errors.As(err, &hostnameErr), errors.As(err, &invalidErr), strings.Contains(err.Error(), "tls: "):
+		return metrics.ErrorTLS
+	case result.StatusCode > 0:
+		// The response arrived but its body could not be read
+		return metrics.ErrorBodyRead
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return metrics.ErrorConnectionReset
+	}
+	return metrics.ErrorOther
+}
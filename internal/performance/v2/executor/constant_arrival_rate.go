@@ -2,6 +2,7 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -22,6 +23,8 @@ import (
 // The executor uses a LeakyBucket to precisely schedule iterations and
 // maintains a pool of VUs to execute them. If VUs are exhausted and
 // iterations are backing up, the executor spawns more VUs up to MaxVUs.
+// Once MaxVUs are all busy, iterations that come due are dropped rather
+// than queued, and counted as dropped_iterations.
 //
 // Use cases:
 //   - Testing system behavior under constant load
@@ -53,12 +56,12 @@ type ConstantArrivalRate struct {
 	// State
 	startTime  time.Time
 	iterations atomic.Int64
+	dropped    atomic.Int64
 	running    atomic.Bool
 
 	// Cancellation
-	cancelMu   sync.Mutex // Protects cancelFunc
-	cancelFunc context.CancelFunc
-	wg         sync.WaitGroup
+	stop *gracefulStop
+	wg   sync.WaitGroup
 
 	// Stats
 	mu sync.RWMutex
@@ -66,7 +69,9 @@ type ConstantArrivalRate struct {
 
 // NewConstantArrivalRate creates a new constant arrival rate executor.
 func NewConstantArrivalRate() *ConstantArrivalRate {
-	return &ConstantArrivalRate{}
+	return &ConstantArrivalRate{
+		stop: newGracefulStop(),
+	}
 }
 
 // Type returns the executor type.
@@ -113,13 +118,16 @@ func (e *ConstantArrivalRate) Run(ctx context.Context, scheduler *v2.VUScheduler
 	e.vuPool = make(chan *v2.VirtualUser, e.config.MaxVUs)
 	e.allVUs = make([]*v2.VirtualUser, 0, e.config.MaxVUs)
 
-	// Create cancellable context with duration timeout
-	runCtx, cancel := context.WithTimeout(ctx, e.config.Duration)
-	e.cancelMu.Lock()
-	e.cancelFunc = cancel
-	e.cancelMu.Unlock()
+	// The run context interrupts in-flight requests; it is only cancelled
+	// by the parent context or once gracefulStop expires.
+	runCtx, cancel := e.stop.runContext(ctx)
 	defer cancel()
 
+	// Iterations are scheduled until the duration expires or the executor
+	// is stopped
+	schedCtx, stopScheduling := context.WithTimeout(runCtx, e.config.Duration)
+	defer stopScheduling()
+
 	// Pre-allocate VUs
 	for i := 0; i < e.config.PreAllocatedVUs; i++ {
 		vu := scheduler.SpawnVU()
@@ -132,18 +140,23 @@ func (e *ConstantArrivalRate) Run(ctx context.Context, scheduler *v2.VUScheduler
 	e.metrics.SetPhase(metrics.PhaseSteady)
 	e.metrics.SetActiveVUs(e.config.PreAllocatedVUs)
 
-	// Run the iteration scheduler
-	e.wg.Add(1)
-	go e.iterationScheduler(runCtx)
-
-	// Wait for context to complete
-	<-runCtx.Done()
+	// Run the iteration scheduler, unless stopped before starting
+	e.vuPoolMu.Lock()
+	if !e.stop.stopping() {
+		e.wg.Add(1)
+		go e.iterationScheduler(schedCtx, runCtx)
+	}
+	e.vuPoolMu.Unlock()
 
-	// Wait for all in-flight iterations to complete
-	e.wg.Wait()
+	// Wait for the duration to expire or the executor to stop
+	select {
+	case <-schedCtx.Done():
+	case <-e.stop.stopped():
+	}
+	stopScheduling()
 
-	// Graceful shutdown
-	e.gracefulShutdown()
+	// In-flight iterations get up to gracefulStop to finish
+	e.stop.shutdown(e.config.EffectiveGracefulStop(), e.stopVUs, &e.wg)
 
 	// Mark as done
 	e.metrics.SetPhase(metrics.PhaseDone)
@@ -152,33 +165,43 @@ func (e *ConstantArrivalRate) Run(ctx context.Context, scheduler *v2.VUScheduler
 	return nil
 }
 
-// iterationScheduler schedules iterations at the configured rate.
-func (e *ConstantArrivalRate) iterationScheduler(ctx context.Context) {
+// iterationScheduler schedules iterations until ctx is done. Iterations
+// run under runCtx, so they outlive the schedule until the graceful stop
+// ends. It schedules iterations at the configured rate.
+func (e *ConstantArrivalRate) iterationScheduler(ctx, runCtx context.Context) {
 	defer e.wg.Done()
 
 	for {
 		// Wait for next iteration slot
-		err := e.bucket.Wait(ctx)
+		scheduled, err := e.bucket.WaitNext(ctx)
 		if err != nil {
 			// Context cancelled - stop scheduling
 			return
 		}
 
+		// No iteration starts once stopping
+		if e.stop.stopping() {
+			return
+		}
+
 		// Try to get a VU from the pool
-		vu := e.getVU(ctx)
+		vu := e.getVU()
 		if vu == nil {
-			// Context cancelled while waiting for VU
-			return
+			// All VUs busy at MaxVUs - the iteration cannot start on time
+			e.dropped.Add(1)
+			e.metrics.RecordDroppedIteration()
+			continue
 		}
 
 		// Schedule iteration on the VU
 		e.wg.Add(1)
-		go e.runIteration(ctx, vu)
+		go e.runIteration(runCtx, vu, scheduled)
 	}
 }
 
 // getVU gets an available VU from the pool, spawning a new one if needed.
-func (e *ConstantArrivalRate) getVU(ctx context.Context) *v2.VirtualUser {
+// It returns nil if every VU is busy and MaxVUs has been reached.
+func (e *ConstantArrivalRate) getVU() *v2.VirtualUser {
 	// Try to get from pool (non-blocking)
 	select {
 	case vu := <-e.vuPool:
@@ -201,12 +224,12 @@ func (e *ConstantArrivalRate) getVU(ctx context.Context) *v2.VirtualUser {
 	}
 	e.vuPoolMu.Unlock()
 
-	// At max VUs - wait for one to become available
+	// At max VUs - take one only if it was returned in the meantime
 	select {
-	case <-ctx.Done():
-		return nil
 	case vu := <-e.vuPool:
 		return vu
+	default:
+		return nil
 	}
 }
 
@@ -227,46 +250,26 @@ func (e *ConstantArrivalRate) returnVU(vu *v2.VirtualUser) {
 }
 
 // runIteration runs a single iteration on a VU.
-func (e *ConstantArrivalRate) runIteration(ctx context.Context, vu *v2.VirtualUser) {
+func (e *ConstantArrivalRate) runIteration(ctx context.Context, vu *v2.VirtualUser, scheduled time.Time) {
 	defer e.wg.Done()
 	defer e.returnVU(vu)
 
-	// Run the iteration
-	err := vu.RunIteration(ctx)
-	if err != nil {
-		// Error logged but not fatal - iteration still counts
+	// Run the iteration; one the stop cut short is not counted
+	err := vu.RunIterationAt(ctx, scheduled)
+	if errors.Is(err, v2.ErrIterationInterrupted) || ctx.Err() != nil {
+		return
 	}
 
 	e.iterations.Add(1)
 }
 
-// gracefulShutdown waits for all VUs to finish their current iteration.
-func (e *ConstantArrivalRate) gracefulShutdown() {
+// stopVUs asks every VU in the pool to stop after its current request.
+func (e *ConstantArrivalRate) stopVUs() {
 	e.vuPoolMu.Lock()
-	// Request all VUs to stop
+	defer e.vuPoolMu.Unlock()
 	for _, vu := range e.allVUs {
 		vu.RequestStop()
 	}
-	e.vuPoolMu.Unlock()
-
-	// Wait with timeout
-	graceful := e.config.GracefulStop
-	if graceful == 0 {
-		graceful = 30 * time.Second
-	}
-
-	done := make(chan struct{})
-	go func() {
-		e.wg.Wait()
-		close(done)
-	}()
-
-	select {
-	case <-done:
-		// All iterations completed
-	case <-time.After(graceful):
-		// Timeout expired
-	}
 }
 
 // GetProgress returns current progress (0.0 to 1.0).
@@ -302,28 +305,25 @@ func (e *ConstantArrivalRate) GetStats() *Stats {
 	}
 
 	return &Stats{
-		StartTime:     e.startTime,
-		CurrentTime:   time.Now(),
-		Elapsed:       elapsed,
-		TotalDuration: e.config.Duration,
-		ActiveVUs:     int(e.currentVUs.Load()),
-		TargetVUs:     e.config.MaxVUs,
-		Iterations:    e.iterations.Load(),
-		CurrentRate:   e.config.Rate,
-		TargetRate:    e.config.Rate,
+		StartTime:         e.startTime,
+		CurrentTime:       time.Now(),
+		Elapsed:           elapsed,
+		TotalDuration:     e.config.Duration,
+		ActiveVUs:         int(e.currentVUs.Load()),
+		TargetVUs:         e.config.MaxVUs,
+		Iterations:        e.iterations.Load(),
+		CurrentRate:       e.config.Rate,
+		TargetRate:        e.config.Rate,
+		DroppedIterations: e.dropped.Load(),
 	}
 }
 
 // Stop gracefully stops the executor.
+//
+// No new iterations start; in-flight iterations get up to gracefulStop
+// to finish.
 func (e *ConstantArrivalRate) Stop(ctx context.Context) error {
-	e.cancelMu.Lock()
-	if e.cancelFunc != nil {
-		e.cancelFunc()
-	}
-	e.cancelMu.Unlock()
-
-	e.gracefulShutdown()
-	return nil
+	return e.stop.stop(ctx, e.config.EffectiveGracefulStop(), e.stopVUs, &e.wg)
 }
 
 // Ensure ConstantArrivalRate implements Executor
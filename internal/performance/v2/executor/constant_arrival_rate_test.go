@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -605,3 +606,108 @@ func BenchmarkConstantArrivalRate_IterationScheduling(b *testing.B) {
 		cancel()
 	}
 }
+
+func TestConstantArrivalRate_DroppedIterations(t *testing.T) {
+	var requests atomic.Int64
+	server := createIterationsTestServer(200*time.Millisecond, &requests)
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scheduler := v2.NewVUScheduler(createArrivalRateTestScenario(server.URL), metricsEngine, v2.DefaultHTTPClientConfig())
+
+	e := executor.NewConstantArrivalRate()
+
+	// 50 iterations/s with a single VU and 200ms responses: most iterations
+	// cannot start on time and must be dropped rather than queued
+	config := &executor.Config{
+		Type:            executor.TypeConstantArrivalRate,
+		Rate:            50.0,
+		Duration:        500 * time.Millisecond,
+		PreAllocatedVUs: 1,
+		MaxVUs:          1,
+	}
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := e.Run(ctx, scheduler, metricsEngine); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	stats := e.GetStats()
+	if stats.DroppedIterations < 10 {
+		t.Errorf("DroppedIterations = %d, want at least 10", stats.DroppedIterations)
+	}
+	if stats.Iterations > 4 {
+		t.Errorf("Iterations = %d, want at most 4 with one VU", stats.Iterations)
+	}
+	if got := metricsEngine.GetDroppedIterations(); got != stats.DroppedIterations {
+		t.Errorf("metrics DroppedIterations = %d, want %d", got, stats.DroppedIterations)
+	}
+}
+
+func TestConstantArrivalRate_StopLetsInFlightRequestsFinish(t *testing.T) {
+	var started, completed atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started.Add(1)
+		select {
+		case <-time.After(500 * time.Millisecond):
+			completed.Add(1)
+			w.WriteHeader(http.StatusOK)
+		case <-r.Context().Done():
+			// Request aborted by the client
+		}
+	}))
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scheduler := v2.NewVUScheduler(createArrivalRateTestScenario(server.URL), metricsEngine, v2.DefaultHTTPClientConfig())
+
+	e := executor.NewConstantArrivalRate()
+	config := &executor.Config{
+		Type:            executor.TypeConstantArrivalRate,
+		Rate:            10.0,
+		Duration:        10 * time.Second,
+		PreAllocatedVUs: 2,
+		MaxVUs:          2,
+		GracefulStop:    5 * time.Second,
+	}
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		e.Run(context.Background(), scheduler, metricsEngine)
+		close(done)
+	}()
+
+	// Stop while the first requests are in flight
+	time.Sleep(250 * time.Millisecond)
+	if err := e.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() did not complete after Stop()")
+	}
+
+	if started.Load() == 0 {
+		t.Fatal("no request was in flight when stopping")
+	}
+	if completed.Load() != started.Load() {
+		t.Errorf("%d of %d in-flight requests completed, want all", completed.Load(), started.Load())
+	}
+	if stats := e.GetStats(); stats.Iterations != started.Load() {
+		t.Errorf("Iterations = %d, want %d", stats.Iterations, started.Load())
+	}
+}
@@ -2,8 +2,8 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/rand"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -16,7 +16,9 @@ import (
 //
 // This is the simplest executor: spawn N VUs and let them run iterations
 // until the duration expires. Each VU runs as fast as it can (closed model),
-// optionally with pacing between iterations.
+// optionally with pacing between iterations. When the duration expires or
+// the executor is stopped, VUs stop starting new iterations and in-flight
+// iterations get up to gracefulStop to finish before being interrupted.
 //
 // Use cases:
 //   - Basic load testing
@@ -33,9 +35,13 @@ type ConstantVUs struct {
 	iterations atomic.Int64
 	running    atomic.Bool
 
+	// VU tracking (for graceful stop)
+	vus   []*v2.VirtualUser
+	vusMu sync.Mutex
+
 	// Cancellation
-	cancelFunc context.CancelFunc
-	wg         sync.WaitGroup
+	stop *gracefulStop
+	wg   sync.WaitGroup
 
 	// Stats
 	mu sync.RWMutex
@@ -43,7 +49,9 @@ type ConstantVUs struct {
 
 // NewConstantVUs creates a new constant VUs executor.
 func NewConstantVUs() *ConstantVUs {
-	return &ConstantVUs{}
+	return &ConstantVUs{
+		stop: newGracefulStop(),
+	}
 }
 
 // Type returns the executor type.
@@ -72,23 +80,33 @@ func (e *ConstantVUs) Run(ctx context.Context, scheduler *v2.VUScheduler, metric
 	e.running.Store(true)
 	e.startTime = time.Now()
 
-	// Create cancellable context with duration timeout
-	runCtx, cancel := context.WithTimeout(ctx, e.config.Duration)
-	e.cancelFunc = cancel
+	// The run context interrupts in-flight requests; it is only cancelled
+	// by the parent context or once gracefulStop expires.
+	runCtx, cancel := e.stop.runContext(ctx)
 	defer cancel()
 
 	// Set phase to steady (constant VUs has no ramp)
 	e.metrics.SetPhase(metrics.PhaseSteady)
 
-	// Spawn all VUs
-	for i := 0; i < e.config.VUs; i++ {
+	// Spawn all VUs, unless stopped before starting
+	e.vusMu.Lock()
+	for i := 0; i < e.config.VUs && !e.stop.stopping(); i++ {
 		vu := scheduler.SpawnVU()
+		e.vus = append(e.vus, vu)
 		e.wg.Add(1)
 		go e.runVU(runCtx, vu)
 	}
+	e.vusMu.Unlock()
 
-	// Wait for all VUs to complete
-	e.wg.Wait()
+	duration := time.NewTimer(e.config.Duration)
+	defer duration.Stop()
+
+	select {
+	case <-duration.C:
+	case <-e.stop.stopped():
+	case <-ctx.Done():
+	}
+	e.stop.shutdown(e.config.EffectiveGracefulStop(), e.stopVUs, &e.wg)
 
 	// Mark as done
 	e.metrics.SetPhase(metrics.PhaseDone)
@@ -97,7 +115,7 @@ func (e *ConstantVUs) Run(ctx context.Context, scheduler *v2.VUScheduler, metric
 	return nil
 }
 
-// runVU runs a single VU until the context is cancelled.
+// runVU runs a single VU until stopped.
 func (e *ConstantVUs) runVU(ctx context.Context, vu *v2.VirtualUser) {
 	defer e.wg.Done()
 	defer vu.MarkStopped()
@@ -113,6 +131,8 @@ func (e *ConstantVUs) runVU(ctx context.Context, vu *v2.VirtualUser) {
 		select {
 		case <-ctx.Done():
 			return
+		case <-e.stop.stopped():
+			return
 		default:
 		}
 
@@ -124,8 +144,9 @@ func (e *ConstantVUs) runVU(ctx context.Context, vu *v2.VirtualUser) {
 		// Run one iteration
 		err := vu.RunIteration(ctx)
 		if err != nil {
-			// Context cancelled or VU stopping - exit gracefully
-			if ctx.Err() != nil || vu.GetState() == v2.VUStateStopping {
+			// Context cancelled or VU stopping - exit gracefully, without
+			// counting an iteration the stop cut short
+			if ctx.Err() != nil || vu.GetState() == v2.VUStateStopping || errors.Is(err, v2.ErrIterationInterrupted) {
 				return
 			}
 			// Other errors - continue to next iteration
@@ -135,35 +156,17 @@ func (e *ConstantVUs) runVU(ctx context.Context, vu *v2.VirtualUser) {
 
 		// Apply pacing between iterations
 		if e.config.Pacing != nil {
-			e.applyPacing(ctx)
+			applyPacing(ctx, e.config.Pacing, e.stop.stopped(), nil)
 		}
 	}
 }
 
-// applyPacing waits between iterations according to pacing config.
-func (e *ConstantVUs) applyPacing(ctx context.Context) {
-	if e.config.Pacing == nil || e.config.Pacing.Type == PacingNone {
-		return
-	}
-
-	var wait time.Duration
-	switch e.config.Pacing.Type {
-	case PacingConstant:
-		wait = e.config.Pacing.Duration
-	case PacingRandom:
-		diff := e.config.Pacing.Max - e.config.Pacing.Min
-		if diff > 0 {
-			wait = e.config.Pacing.Min + time.Duration(rand.Int63n(int64(diff)))
-		} else {
-			wait = e.config.Pacing.Min
-		}
-	}
-
-	if wait > 0 {
-		select {
-		case <-ctx.Done():
-		case <-time.After(wait):
-		}
+// stopVUs asks every VU to stop after its current request.
+func (e *ConstantVUs) stopVUs() {
+	e.vusMu.Lock()
+	defer e.vusMu.Unlock()
+	for _, vu := range e.vus {
+		vu.RequestStop()
 	}
 }
 
@@ -211,31 +214,11 @@ func (e *ConstantVUs) GetStats() *Stats {
 }
 
 // Stop gracefully stops the executor.
+//
+// VUs stop starting new iterations immediately; in-flight iterations
+// get up to gracefulStop to finish.
 func (e *ConstantVUs) Stop(ctx context.Context) error {
-	if e.cancelFunc != nil {
-		e.cancelFunc()
-	}
-
-	// Wait for VUs with timeout
-	done := make(chan struct{})
-	go func() {
-		e.wg.Wait()
-		close(done)
-	}()
-
-	graceful := e.config.GracefulStop
-	if graceful == 0 {
-		graceful = 30 * time.Second
-	}
-
-	select {
-	case <-done:
-		return nil
-	case <-time.After(graceful):
-		return fmt.Errorf("graceful stop timeout after %v", graceful)
-	case <-ctx.Done():
-		return ctx.Err()
-	}
+	return e.stop.stop(ctx, e.config.EffectiveGracefulStop(), e.stopVUs, &e.wg)
 }
 
 // Ensure ConstantVUs implements Executor
@@ -30,8 +30,18 @@ const (
 
 	// TypeSharedIterations shares a total iteration count across VUs.
 	TypeSharedIterations Type = "shared-iterations"
+
+	// TypeExternallyControlled runs a VU count or rate changed while running.
+	TypeExternallyControlled Type = "externally-controlled"
 )
 
+// DefaultMaxDuration is the default maxDuration cap for iteration-based executors.
+const DefaultMaxDuration = 10 * time.Minute
+
+// DefaultGracefulStop is how long in-flight iterations get to finish when
+// an executor stops, if gracefulStop is unset.
+const DefaultGracefulStop = 30 * time.Second
+
 // Executor defines the interface for load generation strategies.
 //
 // Executors control HOW load is generated - whether by managing a pool
@@ -64,6 +74,45 @@ type Executor interface {
 	Stop(ctx context.Context) error
 }
 
+// Controller is implemented by executors whose load can be changed while
+// they run. Each method returns an error if the change does not apply to
+// the executor's current mode or state.
+type Controller interface {
+	// Pause stops new iterations from starting.
+	Pause() error
+
+	// Resume lets iterations start again after Pause.
+	Resume() error
+
+	// SetVUs scales the number of VUs.
+	SetVUs(vus int) error
+
+	// SetRate sets the arrival rate in iterations per second.
+	SetRate(rate float64) error
+
+	// ControlStatus returns the current control state.
+	ControlStatus() ControlStatus
+}
+
+// Control modes of a Controller.
+const (
+	ControlModeVUs  = "vus"
+	ControlModeRate = "rate"
+)
+
+// ControlStatus is the control state of a Controller.
+type ControlStatus struct {
+	// Mode is ControlModeVUs or ControlModeRate
+	Mode   string `json:"mode"`
+	Paused bool   `json:"paused"`
+
+	// VUs is the target VU count in VU mode, or the VUs allocated so far
+	// in rate mode
+	VUs    int     `json:"vus"`
+	MaxVUs int     `json:"maxVUs"`
+	Rate   float64 `json:"rate,omitempty"` // iterations/second (rate mode)
+}
+
 // Config contains configuration for an executor.
 type Config struct {
 	// Name is the name of this executor instance
@@ -77,6 +126,9 @@ type Config struct {
 	Duration   time.Duration `json:"duration,omitempty" yaml:"duration,omitempty"`
 	Iterations int64         `json:"iterations,omitempty" yaml:"iterations,omitempty"`
 
+	// MaxDuration caps the run time of iteration-based executors
+	MaxDuration time.Duration `json:"maxDuration,omitempty" yaml:"maxDuration,omitempty"`
+
 	// Arrival-rate executors
 	Rate            float64 `json:"rate,omitempty" yaml:"rate,omitempty"` // iterations/second
 	PreAllocatedVUs int     `json:"preAllocatedVUs,omitempty" yaml:"preAllocatedVUs,omitempty"`
@@ -144,6 +196,10 @@ type Stats struct {
 	Iterations      int64 `json:"iterations"`
 	TotalIterations int64 `json:"totalIterations"` // For per-vu-iterations / shared-iterations
 
+	// VUIterations is the number of iterations completed by each VU, keyed by VU ID
+	// (for shared-iterations)
+	VUIterations map[int]int64 `json:"vuIterations,omitempty"`
+
 	// Stage info (for ramping executors)
 	CurrentStage     int    `json:"currentStage"`
 	CurrentStageName string `json:"currentStageName"`
@@ -152,6 +208,10 @@ type Stats struct {
 	// Rate info (for arrival-rate executors)
 	CurrentRate float64 `json:"currentRate"`
 	TargetRate  float64 `json:"targetRate"`
+
+	// DroppedIterations counts iterations that could not start because
+	// every VU was busy at MaxVUs (arrival-rate executors)
+	DroppedIterations int64 `json:"droppedIterations,omitempty"`
 }
 
 // Validate validates the executor configuration.
@@ -194,6 +254,9 @@ func (c *Config) Validate() error {
 		if c.Iterations <= 0 {
 			return &ValidationError{Field: "iterations", Message: "iterations must be > 0"}
 		}
+		if c.MaxDuration < 0 {
+			return &ValidationError{Field: "maxDuration", Message: "maxDuration cannot be negative"}
+		}
 
 	case TypeSharedIterations:
 		if c.VUs <= 0 {
@@ -202,6 +265,29 @@ func (c *Config) Validate() error {
 		if c.Iterations <= 0 {
 			return &ValidationError{Field: "iterations", Message: "iterations must be > 0"}
 		}
+		if c.MaxDuration < 0 {
+			return &ValidationError{Field: "maxDuration", Message: "maxDuration cannot be negative"}
+		}
+
+	case TypeExternallyControlled:
+		if c.Rate < 0 {
+			return &ValidationError{Field: "rate", Message: "rate cannot be negative"}
+		}
+		if c.VUs < 0 {
+			return &ValidationError{Field: "vus", Message: "vus cannot be negative"}
+		}
+		if c.MaxVUs < 0 {
+			return &ValidationError{Field: "maxVUs", Message: "maxVUs cannot be negative"}
+		}
+		if c.Rate == 0 && c.VUs == 0 && c.MaxVUs == 0 {
+			return &ValidationError{Field: "maxVUs", Message: "vus or maxVUs must be > 0"}
+		}
+		if c.Rate == 0 && c.MaxVUs > 0 && c.VUs > c.MaxVUs {
+			return &ValidationError{Field: "vus", Message: "vus cannot be greater than maxVUs"}
+		}
+		if c.Duration < 0 {
+			return &ValidationError{Field: "duration", Message: "duration cannot be negative"}
+		}
 
 	default:
 		return &ValidationError{Field: "type", Message: "unknown executor type: " + string(c.Type)}
@@ -216,6 +302,10 @@ func (c *Config) TotalDuration() time.Duration {
 	case TypeConstantVUs, TypeConstantArrivalRate:
 		return c.Duration
 
+	case TypeExternallyControlled:
+		// Runs until stopped if no duration is set
+		return c.Duration
+
 	case TypeRampingVUs, TypeRampingArrivalRate:
 		var total time.Duration
 		for _, stage := range c.Stages {
@@ -224,14 +314,30 @@ func (c *Config) TotalDuration() time.Duration {
 		return total
 
 	case TypePerVUIterations, TypeSharedIterations:
-		// Duration not applicable - runs until iterations complete
-		return 0
+		// Runs until iterations complete; maxDuration is only an upper bound
+		return c.MaxDuration
 
 	default:
 		return 0
 	}
 }
 
+// EffectiveMaxDuration returns the maxDuration cap, or DefaultMaxDuration if unset.
+func (c *Config) EffectiveMaxDuration() time.Duration {
+	if c.MaxDuration > 0 {
+		return c.MaxDuration
+	}
+	return DefaultMaxDuration
+}
+
+// EffectiveGracefulStop returns gracefulStop, or DefaultGracefulStop if unset.
+func (c *Config) EffectiveGracefulStop() time.Duration {
+	if c.GracefulStop > 0 {
+		return c.GracefulStop
+	}
+	return DefaultGracefulStop
+}
+
 // ValidationError represents a configuration validation error.
 type ValidationError struct {
 	Field   string
@@ -0,0 +1,624 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	v2 "github.com/wesleyorama2/lunge/internal/performance/v2"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/rate"
+)
+
+// ExternallyControlled runs load that is changed while the test runs.
+//
+// Unlike the other executors, which follow a plan fixed at Init, this
+// executor starts at the configured load and then waits for instructions
+// through its Controller methods: pause, resume, scale the VUs or set the
+// arrival rate. It runs in one of two modes:
+//
+//   - VU mode (no rate): VUs run iterations as fast as they can, like
+//     constant-vus, and the VU count can be scaled up to MaxVUs.
+//   - Rate mode (rate > 0): iterations start at the arrival rate, like
+//     constant-arrival-rate, on a pool of up to MaxVUs VUs.
+//
+// Duration is optional; without one, the executor runs until stopped.
+//
+// Use cases:
+//   - Exploratory capacity testing
+//   - Finding the breaking point interactively
+//   - Holding load while investigating the system under test
+//
+// Example:
+//
+//	config:
+//	  type: externally-controlled
+//	  vus: 5                 # Start with 5 VUs
+//	  maxVUs: 100            # Allow scaling up to 100 VUs
+type ExternallyControlled struct {
+	config    *Config
+	scheduler *v2.VUScheduler
+	metrics   *metrics.Engine
+
+	// Rate limiter (rate mode)
+	bucket      *rate.LeakyBucket
+	rateChanged chan struct{} // Wakes the scheduler when the rate changes
+
+	// VU pool management (rate mode)
+	vuPool     chan *v2.VirtualUser // Available VUs ready to execute
+	allVUs     []*v2.VirtualUser    // All VUs (for cleanup)
+	currentVUs atomic.Int32         // Current total VU count
+	vuPoolMu   sync.Mutex
+
+	// Control state, guarded by controlMu
+	controlMu sync.Mutex
+	targetVUs int             // VU mode
+	rate      float64         // Rate mode
+	resumed   chan struct{}   // Non-nil while paused; closed on resume
+	runCtx    context.Context // Context new VUs run under, once running
+	finished  bool
+
+	// State
+	startTime  time.Time
+	activeVUs  atomic.Int32
+	iterations atomic.Int64
+	dropped    atomic.Int64
+	running    atomic.Bool
+
+	// Cancellation
+	stop *gracefulStop
+	wg   sync.WaitGroup
+
+	// Stats
+	mu sync.RWMutex
+}
+
+// NewExternallyControlled creates a new externally controlled executor.
+func NewExternallyControlled() *ExternallyControlled {
+	return &ExternallyControlled{
+		rateChanged: make(chan struct{}, 1),
+		stop:        newGracefulStop(),
+	}
+}
+
+// Type returns the executor type.
+func (e *ExternallyControlled) Type() Type {
+	return TypeExternallyControlled
+}
+
+// Init initializes the executor with configuration.
+func (e *ExternallyControlled) Init(ctx context.Context, config *Config) error {
+	if config.Type != TypeExternallyControlled {
+		return fmt.Errorf("invalid config type: expected %s, got %s", TypeExternallyControlled, config.Type)
+	}
+
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	if config.Rate > 0 {
+		// Set defaults for VU pool
+		if config.PreAllocatedVUs <= 0 {
+			config.PreAllocatedVUs = 1
+		}
+		if config.MaxVUs < config.PreAllocatedVUs {
+			config.MaxVUs = config.PreAllocatedVUs
+		}
+	} else if config.MaxVUs < config.VUs {
+		config.MaxVUs = config.VUs
+	}
+
+	e.config = config
+	e.targetVUs = config.VUs
+	e.rate = config.Rate
+	return nil
+}
+
+// rateMode reports whether the executor runs at an arrival rate.
+func (e *ExternallyControlled) rateMode() bool {
+	return e.config.Rate > 0
+}
+
+// Run starts the executor and blocks until it is stopped or its duration,
+// if any, expires.
+func (e *ExternallyControlled) Run(ctx context.Context, scheduler *v2.VUScheduler, metricsEngine *metrics.Engine) error {
+	e.scheduler = scheduler
+	e.metrics = metricsEngine
+	e.running.Store(true)
+
+	// Stats are read by the control API while running
+	e.mu.Lock()
+	e.startTime = time.Now()
+	e.mu.Unlock()
+
+	// The run context interrupts in-flight requests; it is only cancelled
+	// by the parent context or once gracefulStop expires.
+	runCtx, cancel := e.stop.runContext(ctx)
+	defer cancel()
+
+	// Iterations are scheduled until the executor stops (rate mode)
+	schedCtx, stopScheduling := context.WithCancel(runCtx)
+	defer stopScheduling()
+
+	// The duration, if any, ends the run like a stop
+	var expired <-chan time.Time
+	if e.config.Duration > 0 {
+		timer := time.NewTimer(e.config.Duration)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	// Set phase to steady (the load only changes on request)
+	e.metrics.SetPhase(metrics.PhaseSteady)
+
+	if e.rateMode() {
+		e.startRate(schedCtx, runCtx)
+	} else {
+		e.startVUs(runCtx)
+	}
+
+	select {
+	case <-expired:
+	case <-e.stop.stopped():
+	case <-ctx.Done():
+	}
+	stopScheduling()
+
+	// In-flight iterations get up to gracefulStop to finish
+	e.stop.shutdown(e.config.EffectiveGracefulStop(), e.stopVUs, &e.wg)
+
+	// Mark as done
+	e.metrics.SetPhase(metrics.PhaseDone)
+	e.running.Store(false)
+
+	return nil
+}
+
+// startVUs spawns the initial VUs of VU mode.
+func (e *ExternallyControlled) startVUs(ctx context.Context) {
+	e.controlMu.Lock()
+	defer e.controlMu.Unlock()
+
+	// Stopped before it started
+	if e.finished {
+		return
+	}
+	e.runCtx = ctx
+	e.scheduler.ScaleVUs(ctx, e.targetVUs, 0, e.spawnVU)
+}
+
+// spawnVU starts a VU spawned by the scheduler. Called with controlMu held.
+func (e *ExternallyControlled) spawnVU(vu *v2.VirtualUser) {
+	e.wg.Add(1)
+	go e.runVU(e.runCtx, vu)
+}
+
+// runVU runs a single VU until the context is cancelled or it is scaled down.
+func (e *ExternallyControlled) runVU(ctx context.Context, vu *v2.VirtualUser) {
+	defer e.wg.Done()
+	defer vu.MarkStopped()
+
+	e.activeVUs.Add(1)
+	e.metrics.SetActiveVUs(int(e.activeVUs.Load())) // Update metrics engine
+	defer func() {
+		e.activeVUs.Add(-1)
+		e.metrics.SetActiveVUs(int(e.activeVUs.Load())) // Update metrics engine
+	}()
+
+	for {
+		// Hold between iterations while paused
+		if !e.waitResumed(ctx, vu.Stopping()) {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		// Check if VU was stopped
+		if vu.GetState() == v2.VUStateStopping || vu.GetState() == v2.VUStateStopped {
+			return
+		}
+
+		// Run one iteration
+		err := vu.RunIteration(ctx)
+		if err != nil {
+			// Interrupted by a scale down - not a completed iteration
+			if errors.Is(err, v2.ErrIterationInterrupted) {
+				return
+			}
+			// Context cancelled or VU stopping - exit gracefully
+			if ctx.Err() != nil || vu.GetState() == v2.VUStateStopping {
+				return
+			}
+			// Other errors - continue to next iteration
+		}
+
+		e.iterations.Add(1)
+	}
+}
+
+// startRate pre-allocates the VU pool of rate mode and starts scheduling
+// iterations until ctx is done. Iterations run under runCtx.
+func (e *ExternallyControlled) startRate(ctx, runCtx context.Context) {
+	e.controlMu.Lock()
+	e.runCtx = runCtx
+	e.bucket = rate.NewLeakyBucket(e.rate)
+	e.controlMu.Unlock()
+
+	// Initialize VU pool
+	e.vuPool = make(chan *v2.VirtualUser, e.config.MaxVUs)
+	e.allVUs = make([]*v2.VirtualUser, 0, e.config.MaxVUs)
+
+	// Pre-allocate VUs
+	for i := 0; i < e.config.PreAllocatedVUs; i++ {
+		vu := e.scheduler.SpawnVU()
+		e.allVUs = append(e.allVUs, vu)
+		e.vuPool <- vu
+		e.currentVUs.Add(1)
+	}
+	e.metrics.SetActiveVUs(e.config.PreAllocatedVUs)
+
+	// Unless stopped before starting
+	e.vuPoolMu.Lock()
+	if !e.stop.stopping() {
+		e.wg.Add(1)
+		go e.iterationScheduler(ctx, runCtx)
+	}
+	e.vuPoolMu.Unlock()
+}
+
+// iterationScheduler schedules iterations at the current rate until ctx
+// is done. Iterations run under runCtx, so they outlive the schedule until
+// the graceful stop ends.
+func (e *ExternallyControlled) iterationScheduler(ctx, runCtx context.Context) {
+	defer e.wg.Done()
+
+	for {
+		if !e.waitResumed(ctx, e.stop.stopped()) {
+			return
+		}
+
+		// Wait for next iteration slot. A rate change reschedules it, so
+		// a slot computed from a low rate doesn't hold up the new one.
+		scheduled := e.bucket.Next()
+		if wait := time.Until(scheduled); wait > 0 {
+			timer := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				// Context cancelled - stop scheduling
+				timer.Stop()
+				return
+			case <-e.rateChanged:
+				timer.Stop()
+				continue
+			case <-timer.C:
+			}
+		}
+
+		// Paused while waiting for the slot
+		if e.paused() {
+			continue
+		}
+
+		// No iteration starts once stopping
+		if e.stop.stopping() {
+			return
+		}
+
+		// Try to get a VU from the pool
+		vu := e.getVU()
+		if vu == nil {
+			// All VUs busy at MaxVUs - the iteration cannot start on time
+			e.dropped.Add(1)
+			e.metrics.RecordDroppedIteration()
+			continue
+		}
+
+		// Schedule iteration on the VU
+		e.wg.Add(1)
+		go e.runIteration(runCtx, vu, scheduled)
+	}
+}
+
+// getVU gets an available VU from the pool, spawning a new one if needed.
+// It returns nil if every VU is busy and MaxVUs has been reached.
+func (e *ExternallyControlled) getVU() *v2.VirtualUser {
+	// Try to get from pool (non-blocking)
+	select {
+	case vu := <-e.vuPool:
+		return vu
+	default:
+		// Pool empty - try to spawn a new VU
+	}
+
+	// Check if we can spawn more VUs
+	e.vuPoolMu.Lock()
+	if int(e.currentVUs.Load()) < e.config.MaxVUs {
+		vu := e.scheduler.SpawnVU()
+		e.allVUs = append(e.allVUs, vu)
+		e.currentVUs.Add(1)
+		e.metrics.SetActiveVUs(int(e.currentVUs.Load()))
+		e.vuPoolMu.Unlock()
+		return vu
+	}
+	e.vuPoolMu.Unlock()
+
+	// At max VUs - take one only if it was returned in the meantime
+	select {
+	case vu := <-e.vuPool:
+		return vu
+	default:
+		return nil
+	}
+}
+
+// returnVU returns a VU to the pool.
+func (e *ExternallyControlled) returnVU(vu *v2.VirtualUser) {
+	state := vu.GetState()
+	if state == v2.VUStateStopping || state == v2.VUStateStopped {
+		return
+	}
+
+	select {
+	case e.vuPool <- vu:
+	default:
+	}
+}
+
+// runIteration runs a single iteration on a VU.
+func (e *ExternallyControlled) runIteration(ctx context.Context, vu *v2.VirtualUser, scheduled time.Time) {
+	defer e.wg.Done()
+	defer e.returnVU(vu)
+
+	err := vu.RunIterationAt(ctx, scheduled)
+	if errors.Is(err, v2.ErrIterationInterrupted) || ctx.Err() != nil {
+		// Cut short by the end of the run - not a completed iteration
+		return
+	}
+	e.iterations.Add(1)
+}
+
+// stopVUs refuses further changes and asks every VU to stop after its
+// current request.
+func (e *ExternallyControlled) stopVUs() {
+	// No VUs may be spawned once stopping
+	e.controlMu.Lock()
+	e.finished = true
+	e.controlMu.Unlock()
+
+	if !e.rateMode() {
+		if e.scheduler != nil {
+			e.scheduler.StopAllVUs()
+		}
+		return
+	}
+
+	e.vuPoolMu.Lock()
+	defer e.vuPoolMu.Unlock()
+	for _, vu := range e.allVUs {
+		vu.RequestStop()
+	}
+}
+
+// waitResumed blocks while the executor is paused. It returns false if
+// the context is cancelled or stopping is closed first.
+func (e *ExternallyControlled) waitResumed(ctx context.Context, stopping <-chan struct{}) bool {
+	e.controlMu.Lock()
+	resumed := e.resumed
+	e.controlMu.Unlock()
+
+	if resumed == nil {
+		return true
+	}
+
+	select {
+	case <-resumed:
+		return true
+	case <-ctx.Done():
+		return false
+	case <-stopping:
+		return false
+	}
+}
+
+// paused reports whether the executor is paused.
+func (e *ExternallyControlled) paused() bool {
+	e.controlMu.Lock()
+	defer e.controlMu.Unlock()
+	return e.resumed != nil
+}
+
+// Pause stops new iterations from starting. Iterations already running
+// complete.
+func (e *ExternallyControlled) Pause() error {
+	e.controlMu.Lock()
+	defer e.controlMu.Unlock()
+
+	if e.finished {
+		return fmt.Errorf("executor has finished")
+	}
+	if e.resumed != nil {
+		return fmt.Errorf("already paused")
+	}
+	e.resumed = make(chan struct{})
+	return nil
+}
+
+// Resume lets iterations start again after Pause.
+func (e *ExternallyControlled) Resume() error {
+	e.controlMu.Lock()
+	defer e.controlMu.Unlock()
+
+	if e.finished {
+		return fmt.Errorf("executor has finished")
+	}
+	if e.resumed == nil {
+		return fmt.Errorf("not paused")
+	}
+	close(e.resumed)
+	e.resumed = nil
+
+	// Restart the schedule so the pause doesn't turn into a burst
+	if e.bucket != nil {
+		e.bucket.SetRate(e.rate)
+		e.notifyRateChanged()
+	}
+	return nil
+}
+
+// SetVUs scales the number of VUs (VU mode only).
+func (e *ExternallyControlled) SetVUs(vus int) error {
+	if e.rateMode() {
+		return fmt.Errorf("executor runs at an arrival rate; set the rate instead")
+	}
+	if vus < 0 {
+		return fmt.Errorf("vus cannot be negative")
+	}
+	if vus > e.config.MaxVUs {
+		return fmt.Errorf("vus cannot be greater than maxVUs (%d)", e.config.MaxVUs)
+	}
+
+	e.controlMu.Lock()
+	defer e.controlMu.Unlock()
+
+	if e.finished {
+		return fmt.Errorf("executor has finished")
+	}
+	e.targetVUs = vus
+	if e.runCtx != nil {
+		e.scheduler.ScaleVUs(e.runCtx, vus, 0, e.spawnVU)
+	}
+	return nil
+}
+
+// SetRate sets the arrival rate in iterations per second (rate mode only).
+func (e *ExternallyControlled) SetRate(iterationsPerSecond float64) error {
+	if !e.rateMode() {
+		return fmt.Errorf("executor runs a number of VUs; set the VUs instead")
+	}
+	if iterationsPerSecond <= 0 {
+		return fmt.Errorf("rate must be > 0")
+	}
+
+	e.controlMu.Lock()
+	defer e.controlMu.Unlock()
+
+	if e.finished {
+		return fmt.Errorf("executor has finished")
+	}
+	e.rate = iterationsPerSecond
+	if e.bucket != nil && e.resumed == nil {
+		e.bucket.SetRate(iterationsPerSecond)
+		e.notifyRateChanged()
+	}
+	return nil
+}
+
+// notifyRateChanged wakes the scheduler if it is waiting for a slot.
+func (e *ExternallyControlled) notifyRateChanged() {
+	select {
+	case e.rateChanged <- struct{}{}:
+	default:
+		// A wake-up is already pending
+	}
+}
+
+// ControlStatus returns the current control state.
+func (e *ExternallyControlled) ControlStatus() ControlStatus {
+	e.controlMu.Lock()
+	defer e.controlMu.Unlock()
+
+	status := ControlStatus{
+		Mode:   ControlModeVUs,
+		Paused: e.resumed != nil,
+		VUs:    e.targetVUs,
+		MaxVUs: e.config.MaxVUs,
+	}
+	if e.rateMode() {
+		status.Mode = ControlModeRate
+		status.VUs = int(e.currentVUs.Load())
+		status.Rate = e.rate
+	}
+	return status
+}
+
+// GetProgress returns current progress (0.0 to 1.0). Without a duration,
+// progress stays at 0 until the executor is stopped.
+func (e *ExternallyControlled) GetProgress() float64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if !e.running.Load() {
+		if e.startTime.IsZero() {
+			return 0.0
+		}
+		return 1.0
+	}
+
+	if e.config.Duration <= 0 {
+		return 0.0
+	}
+
+	progress := float64(time.Since(e.startTime)) / float64(e.config.Duration)
+	if progress > 1.0 {
+		progress = 1.0
+	}
+	return progress
+}
+
+// GetActiveVUs returns current active VU count.
+func (e *ExternallyControlled) GetActiveVUs() int {
+	if e.rateMode() {
+		return int(e.currentVUs.Load())
+	}
+	return int(e.activeVUs.Load())
+}
+
+// GetStats returns executor statistics.
+func (e *ExternallyControlled) GetStats() *Stats {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var elapsed time.Duration
+	if !e.startTime.IsZero() {
+		elapsed = time.Since(e.startTime)
+	}
+
+	status := e.ControlStatus()
+	stats := &Stats{
+		StartTime:         e.startTime,
+		CurrentTime:       time.Now(),
+		Elapsed:           elapsed,
+		TotalDuration:     e.config.Duration,
+		ActiveVUs:         e.GetActiveVUs(),
+		TargetVUs:         status.VUs,
+		Iterations:        e.iterations.Load(),
+		DroppedIterations: e.dropped.Load(),
+	}
+	if e.rateMode() {
+		stats.TargetVUs = status.MaxVUs
+		stats.CurrentRate = status.Rate
+		stats.TargetRate = status.Rate
+	}
+	return stats
+}
+
+// Stop gracefully stops the executor.
+//
+// No new iterations start; in-flight iterations get up to gracefulStop
+// to finish.
+func (e *ExternallyControlled) Stop(ctx context.Context) error {
+	return e.stop.stop(ctx, e.config.EffectiveGracefulStop(), e.stopVUs, &e.wg)
+}
+
+// Ensure ExternallyControlled implements Executor and Controller
+var (
+	_ Executor   = (*ExternallyControlled)(nil)
+	_ Controller = (*ExternallyControlled)(nil)
+)
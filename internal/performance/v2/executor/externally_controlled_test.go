@@ -0,0 +1,368 @@
+package executor_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v2 "github.com/wesleyorama2/lunge/internal/performance/v2"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/executor"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+)
+
+// createControlledTestServer creates a test HTTP server
+func createControlledTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+}
+
+// startControlled initializes an externally controlled executor and runs
+// it in the background. The returned function stops it and waits for Run.
+func startControlled(t *testing.T, config *executor.Config) (*executor.ExternallyControlled, func()) {
+	t.Helper()
+
+	server := createControlledTestServer()
+	metricsEngine := metrics.NewEngine()
+
+	scenario := &v2.Scenario{
+		Name: "controlled-test",
+		Requests: []*v2.RequestConfig{
+			{Name: "test-request", Method: "GET", URL: server.URL},
+		},
+	}
+	scheduler := v2.NewVUScheduler(scenario, metricsEngine, v2.DefaultHTTPClientConfig())
+
+	e := executor.NewExternallyControlled()
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		_ = e.Run(context.Background(), scheduler, metricsEngine)
+		close(done)
+	}()
+
+	stop := func() {
+		_ = e.Stop(context.Background())
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Error("Run() did not complete after Stop()")
+		}
+		metricsEngine.Stop()
+		server.Close()
+	}
+	return e, stop
+}
+
+// waitFor polls cond until it holds or the timeout expires.
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) bool {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestExternallyControlled_Type(t *testing.T) {
+	e := executor.NewExternallyControlled()
+	if e.Type() != executor.TypeExternallyControlled {
+		t.Errorf("Type() = %v, want %v", e.Type(), executor.TypeExternallyControlled)
+	}
+}
+
+func TestExternallyControlled_Init_InvalidType(t *testing.T) {
+	e := executor.NewExternallyControlled()
+
+	config := &executor.Config{
+		Type: executor.TypeConstantVUs, // Wrong type
+		VUs:  10,
+	}
+
+	if err := e.Init(context.Background(), config); err == nil {
+		t.Fatal("Init() expected error for wrong type, got nil")
+	}
+}
+
+func TestExternallyControlled_Init_Defaults(t *testing.T) {
+	e := executor.NewExternallyControlled()
+	config := &executor.Config{
+		Type: executor.TypeExternallyControlled,
+		VUs:  5,
+	}
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if config.MaxVUs != 5 {
+		t.Errorf("MaxVUs = %d, want 5 (defaults to vus)", config.MaxVUs)
+	}
+
+	e = executor.NewExternallyControlled()
+	config = &executor.Config{
+		Type: executor.TypeExternallyControlled,
+		Rate: 10,
+	}
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if config.PreAllocatedVUs != 1 || config.MaxVUs != 1 {
+		t.Errorf("PreAllocatedVUs = %d, MaxVUs = %d, want 1 and 1", config.PreAllocatedVUs, config.MaxVUs)
+	}
+
+	status := e.ControlStatus()
+	if status.Mode != executor.ControlModeRate || status.Rate != 10 {
+		t.Errorf("ControlStatus() = %+v, want rate mode at 10", status)
+	}
+}
+
+func TestExternallyControlled_ModeErrors(t *testing.T) {
+	vus := executor.NewExternallyControlled()
+	_ = vus.Init(context.Background(), &executor.Config{Type: executor.TypeExternallyControlled, VUs: 2, MaxVUs: 4})
+
+	if err := vus.SetRate(10); err == nil {
+		t.Error("SetRate() in VU mode expected error, got nil")
+	}
+	if err := vus.SetVUs(5); err == nil {
+		t.Error("SetVUs() above maxVUs expected error, got nil")
+	}
+	if err := vus.SetVUs(-1); err == nil {
+		t.Error("SetVUs() negative expected error, got nil")
+	}
+	if err := vus.Resume(); err == nil {
+		t.Error("Resume() when not paused expected error, got nil")
+	}
+
+	arrival := executor.NewExternallyControlled()
+	_ = arrival.Init(context.Background(), &executor.Config{Type: executor.TypeExternallyControlled, Rate: 5})
+
+	if err := arrival.SetVUs(3); err == nil {
+		t.Error("SetVUs() in rate mode expected error, got nil")
+	}
+	if err := arrival.SetRate(0); err == nil {
+		t.Error("SetRate(0) expected error, got nil")
+	}
+}
+
+func TestExternallyControlled_ScaleVUs(t *testing.T) {
+	e, stop := startControlled(t, &executor.Config{
+		Type:   executor.TypeExternallyControlled,
+		VUs:    2,
+		MaxVUs: 10,
+	})
+	defer stop()
+
+	if !waitFor(t, time.Second, func() bool { return e.GetActiveVUs() == 2 }) {
+		t.Fatalf("GetActiveVUs() = %d, want 2", e.GetActiveVUs())
+	}
+
+	if err := e.SetVUs(6); err != nil {
+		t.Fatalf("SetVUs(6) error = %v", err)
+	}
+	if !waitFor(t, time.Second, func() bool { return e.GetActiveVUs() == 6 }) {
+		t.Fatalf("After scaling up, GetActiveVUs() = %d, want 6", e.GetActiveVUs())
+	}
+
+	// Scaling down twice must converge on the last target
+	if err := e.SetVUs(3); err != nil {
+		t.Fatalf("SetVUs(3) error = %v", err)
+	}
+	if err := e.SetVUs(1); err != nil {
+		t.Fatalf("SetVUs(1) error = %v", err)
+	}
+	if !waitFor(t, time.Second, func() bool { return e.GetActiveVUs() == 1 }) {
+		t.Fatalf("After scaling down, GetActiveVUs() = %d, want 1", e.GetActiveVUs())
+	}
+
+	if status := e.ControlStatus(); status.VUs != 1 || status.MaxVUs != 10 {
+		t.Errorf("ControlStatus() = %+v, want 1 of 10 VUs", status)
+	}
+}
+
+func TestExternallyControlled_PauseResume(t *testing.T) {
+	e, stop := startControlled(t, &executor.Config{
+		Type: executor.TypeExternallyControlled,
+		VUs:  2,
+	})
+	defer stop()
+
+	if !waitFor(t, time.Second, func() bool { return e.GetStats().Iterations > 0 }) {
+		t.Fatal("no iterations before pausing")
+	}
+
+	if err := e.Pause(); err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	if err := e.Pause(); err == nil {
+		t.Error("Pause() when paused expected error, got nil")
+	}
+	if !e.ControlStatus().Paused {
+		t.Error("ControlStatus().Paused = false after Pause()")
+	}
+
+	// Let in-flight iterations finish, then no new ones may start
+	time.Sleep(50 * time.Millisecond)
+	paused := e.GetStats().Iterations
+	time.Sleep(100 * time.Millisecond)
+	if got := e.GetStats().Iterations; got != paused {
+		t.Errorf("Iterations while paused went from %d to %d", paused, got)
+	}
+
+	if err := e.Resume(); err != nil {
+		t.Fatalf("Resume() error = %v", err)
+	}
+	if !waitFor(t, time.Second, func() bool { return e.GetStats().Iterations > paused }) {
+		t.Error("no iterations after resuming")
+	}
+}
+
+func TestExternallyControlled_SetRate(t *testing.T) {
+	e, stop := startControlled(t, &executor.Config{
+		Type:            executor.TypeExternallyControlled,
+		Rate:            5,
+		PreAllocatedVUs: 2,
+		MaxVUs:          10,
+	})
+	defer stop()
+
+	time.Sleep(300 * time.Millisecond)
+	slow := e.GetStats().Iterations
+
+	if err := e.SetRate(100); err != nil {
+		t.Fatalf("SetRate(100) error = %v", err)
+	}
+	time.Sleep(300 * time.Millisecond)
+	fast := e.GetStats().Iterations - slow
+
+	// ~1-2 iterations at 5/s versus ~30 at 100/s
+	if fast < 10 {
+		t.Errorf("Iterations after raising the rate = %d, want at least 10 (before: %d)", fast, slow)
+	}
+	if stats := e.GetStats(); stats.CurrentRate != 100 {
+		t.Errorf("Stats.CurrentRate = %v, want 100", stats.CurrentRate)
+	}
+}
+
+func TestExternallyControlled_SetRateWakesScheduler(t *testing.T) {
+	e, stop := startControlled(t, &executor.Config{
+		Type:            executor.TypeExternallyControlled,
+		Rate:            0.1,
+		PreAllocatedVUs: 2,
+		MaxVUs:          10,
+	})
+	defer stop()
+
+	// At 0.1/s the scheduler is waiting on a slot 10s away
+	time.Sleep(100 * time.Millisecond)
+	if got := e.GetStats().Iterations; got != 0 {
+		t.Fatalf("Iterations before raising the rate = %d, want 0", got)
+	}
+
+	if err := e.SetRate(100); err != nil {
+		t.Fatalf("SetRate(100) error = %v", err)
+	}
+	if !waitFor(t, 500*time.Millisecond, func() bool { return e.GetStats().Iterations >= 10 }) {
+		t.Errorf("Iterations 500ms after raising the rate = %d, want at least 10", e.GetStats().Iterations)
+	}
+}
+
+func TestExternallyControlled_ScaleDownInterruptedIterationsNotCounted(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	// The scale down arrives during the think time between the two requests
+	scenario := &v2.Scenario{
+		Name: "interrupted",
+		Requests: []*v2.RequestConfig{
+			{Name: "first", Method: "GET", URL: server.URL, ThinkTime: 5 * time.Second},
+			{Name: "second", Method: "GET", URL: server.URL},
+		},
+	}
+	scheduler := v2.NewVUScheduler(scenario, metricsEngine, v2.DefaultHTTPClientConfig())
+
+	e := executor.NewExternallyControlled()
+	config := &executor.Config{
+		Type: executor.TypeExternallyControlled,
+		VUs:  2,
+	}
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		_ = e.Run(context.Background(), scheduler, metricsEngine)
+		close(done)
+	}()
+	defer func() {
+		_ = e.Stop(context.Background())
+		<-done
+	}()
+
+	if !waitFor(t, time.Second, func() bool { return requests.Load() == 2 }) {
+		t.Fatalf("server got %d requests, want 2 (one per VU)", requests.Load())
+	}
+	if err := e.SetVUs(0); err != nil {
+		t.Fatalf("SetVUs(0) error = %v", err)
+	}
+	if !waitFor(t, time.Second, func() bool { return e.GetActiveVUs() == 0 }) {
+		t.Fatalf("After scaling down, GetActiveVUs() = %d, want 0", e.GetActiveVUs())
+	}
+
+	if stats := e.GetStats(); stats.Iterations != 0 {
+		t.Errorf("Iterations = %d, want 0 (no iteration completed)", stats.Iterations)
+	}
+}
+
+func TestExternallyControlled_Duration(t *testing.T) {
+	server := createControlledTestServer()
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scenario := &v2.Scenario{
+		Name:     "controlled-test",
+		Requests: []*v2.RequestConfig{{Name: "test-request", Method: "GET", URL: server.URL}},
+	}
+	scheduler := v2.NewVUScheduler(scenario, metricsEngine, v2.DefaultHTTPClientConfig())
+
+	e := executor.NewExternallyControlled()
+	config := &executor.Config{
+		Type:     executor.TypeExternallyControlled,
+		VUs:      1,
+		Duration: 200 * time.Millisecond,
+	}
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	start := time.Now()
+	if err := e.Run(context.Background(), scheduler, metricsEngine); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Run() elapsed = %v, want ~200ms", elapsed)
+	}
+
+	if err := e.SetVUs(1); err == nil {
+		t.Error("SetVUs() after the run expected error, got nil")
+	}
+}
@@ -15,6 +15,9 @@ import (
 //   - "ramping-vus" - VU count ramps up/down according to stages
 //   - "constant-arrival-rate" - Fixed iteration rate (open model)
 //   - "ramping-arrival-rate" - Iteration rate ramps up/down
+//   - "per-vu-iterations" - Each VU runs a fixed number of iterations
+//   - "shared-iterations" - A pool of VUs shares a fixed total iteration count
+//   - "externally-controlled" - VU count or rate changed while the test runs
 //
 // Returns an uninitialized executor. Call Init() before Run().
 func NewExecutor(executorType Type) (Executor, error) {
@@ -28,9 +31,11 @@ func NewExecutor(executorType Type) (Executor, error) {
 	case TypeRampingArrivalRate:
 		return NewRampingArrivalRate(), nil
 	case TypePerVUIterations:
-		return nil, fmt.Errorf("per-vu-iterations executor not yet implemented")
+		return NewPerVUIterations(), nil
 	case TypeSharedIterations:
-		return nil, fmt.Errorf("shared-iterations executor not yet implemented")
+		return NewSharedIterations(), nil
+	case TypeExternallyControlled:
+		return NewExternallyControlled(), nil
 	default:
 		return nil, fmt.Errorf("unknown executor type: %s", executorType)
 	}
@@ -85,6 +90,7 @@ func convertScenarioToExecutorConfig(name string, sc *config.ScenarioConfig) (*C
 		Name:            name,
 		Type:            Type(sc.Executor),
 		VUs:             sc.VUs,
+		Iterations:      int64(sc.Iterations),
 		Rate:            sc.Rate,
 		PreAllocatedVUs: sc.PreAllocatedVUs,
 		MaxVUs:          sc.MaxVUs,
@@ -99,6 +105,15 @@ func convertScenarioToExecutorConfig(name string, sc *config.ScenarioConfig) (*C
 		cfg.Duration = dur
 	}
 
+	// Parse max duration
+	if sc.MaxDuration != "" {
+		dur, err := config.ParseDurationString(sc.MaxDuration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maxDuration: %w", err)
+		}
+		cfg.MaxDuration = dur
+	}
+
 	// Parse graceful stop
 	if sc.GracefulStop != "" {
 		dur, err := config.ParseDurationString(sc.GracefulStop)
@@ -162,10 +177,9 @@ func convertScenarioToExecutorConfig(name string, sc *config.ScenarioConfig) (*C
 // IsValidExecutorType returns true if the type is a valid executor type.
 func IsValidExecutorType(executorType string) bool {
 	switch Type(executorType) {
-	case TypeConstantVUs, TypeRampingVUs, TypeConstantArrivalRate, TypeRampingArrivalRate:
+	case TypeConstantVUs, TypeRampingVUs, TypeConstantArrivalRate, TypeRampingArrivalRate,
+		TypePerVUIterations, TypeSharedIterations, TypeExternallyControlled:
 		return true
-	case TypePerVUIterations, TypeSharedIterations:
-		return true // Valid but not yet implemented
 	default:
 		return false
 	}
@@ -178,8 +192,9 @@ func GetSupportedExecutors() []Type {
 		TypeRampingVUs,
 		TypeConstantArrivalRate,
 		TypeRampingArrivalRate,
-		// TypePerVUIterations,    // Not yet implemented
-		// TypeSharedIterations,   // Not yet implemented
+		TypePerVUIterations,
+		TypeSharedIterations,
+		TypeExternallyControlled,
 	}
 }
 
@@ -239,6 +254,39 @@ func GetExecutorDescription(executorType Type) *ExecutorDescription {
 				"Gradual load test warm-up",
 			},
 		}
+	case TypePerVUIterations:
+		return &ExecutorDescription{
+			Type:        TypePerVUIterations,
+			Name:        "Per-VU Iterations",
+			Description: "Each VU runs an exact number of iterations. The test ends when all iterations complete or maxDuration is reached.",
+			UseCases: []string{
+				"Deterministic smoke tests",
+				"Regression runs with a fixed amount of work",
+				"Per-user workflows that must run a set number of times",
+			},
+		}
+	case TypeSharedIterations:
+		return &ExecutorDescription{
+			Type:        TypeSharedIterations,
+			Name:        "Shared Iterations",
+			Description: "A pool of VUs drains a fixed total number of iterations as fast as possible. The test ends when all iterations complete or maxDuration is reached.",
+			UseCases: []string{
+				"Data seeding",
+				"Processing a fixed number of items (e.g., orders)",
+				"Measuring how long a fixed amount of work takes",
+			},
+		}
+	case TypeExternallyControlled:
+		return &ExecutorDescription{
+			Type:        TypeExternallyControlled,
+			Name:        "Externally Controlled",
+			Description: "Runs a number of VUs, or an iteration rate, that is paused, resumed and changed through the control API while the test runs.",
+			UseCases: []string{
+				"Exploratory capacity testing",
+				"Finding the breaking point interactively",
+				"Holding load while investigating the system under test",
+			},
+		}
 	default:
 		return nil
 	}
@@ -267,6 +315,8 @@ func CalculateMaxVUs(cfg *Config) int {
 		return maxVUs
 	case TypeConstantArrivalRate, TypeRampingArrivalRate:
 		return cfg.MaxVUs
+	case TypeExternallyControlled:
+		return max(cfg.VUs, cfg.MaxVUs)
 	default:
 		return cfg.VUs
 	}
@@ -60,17 +60,45 @@ func TestNewExecutor_RampingArrivalRate(t *testing.T) {
 	}
 }
 
-func TestNewExecutor_PerVUIterations_NotImplemented(t *testing.T) {
-	_, err := executor.NewExecutor(executor.TypePerVUIterations)
-	if err == nil {
-		t.Fatal("NewExecutor(TypePerVUIterations) expected error for not implemented, got nil")
+func TestNewExecutor_PerVUIterations(t *testing.T) {
+	e, err := executor.NewExecutor(executor.TypePerVUIterations)
+	if err != nil {
+		t.Fatalf("NewExecutor(TypePerVUIterations) error = %v", err)
+	}
+	if e == nil {
+		t.Fatal("NewExecutor(TypePerVUIterations) returned nil")
+	}
+	if e.Type() != executor.TypePerVUIterations {
+		t.Errorf("Type() = %v, want %v", e.Type(), executor.TypePerVUIterations)
 	}
 }
 
-func TestNewExecutor_SharedIterations_NotImplemented(t *testing.T) {
-	_, err := executor.NewExecutor(executor.TypeSharedIterations)
-	if err == nil {
-		t.Fatal("NewExecutor(TypeSharedIterations) expected error for not implemented, got nil")
+func TestNewExecutor_SharedIterations(t *testing.T) {
+	e, err := executor.NewExecutor(executor.TypeSharedIterations)
+	if err != nil {
+		t.Fatalf("NewExecutor(TypeSharedIterations) error = %v", err)
+	}
+	if e == nil {
+		t.Fatal("NewExecutor(TypeSharedIterations) returned nil")
+	}
+	if e.Type() != executor.TypeSharedIterations {
+		t.Errorf("Type() = %v, want %v", e.Type(), executor.TypeSharedIterations)
+	}
+}
+
+func TestNewExecutor_ExternallyControlled(t *testing.T) {
+	e, err := executor.NewExecutor(executor.TypeExternallyControlled)
+	if err != nil {
+		t.Fatalf("NewExecutor(TypeExternallyControlled) error = %v", err)
+	}
+	if e == nil {
+		t.Fatal("NewExecutor(TypeExternallyControlled) returned nil")
+	}
+	if e.Type() != executor.TypeExternallyControlled {
+		t.Errorf("Type() = %v, want %v", e.Type(), executor.TypeExternallyControlled)
+	}
+	if _, ok := e.(executor.Controller); !ok {
+		t.Error("externally-controlled executor does not implement Controller")
 	}
 }
 
@@ -254,8 +282,9 @@ func TestIsValidExecutorType(t *testing.T) {
 		{"ramping-vus", "ramping-vus", true},
 		{"constant-arrival-rate", "constant-arrival-rate", true},
 		{"ramping-arrival-rate", "ramping-arrival-rate", true},
-		{"per-vu-iterations", "per-vu-iterations", true}, // Valid but not implemented
-		{"shared-iterations", "shared-iterations", true}, // Valid but not implemented
+		{"per-vu-iterations", "per-vu-iterations", true},
+		{"shared-iterations", "shared-iterations", true},
+		{"externally-controlled", "externally-controlled", true},
 		{"unknown", "unknown-type", false},
 		{"empty", "", false},
 		{"typo", "constant-vu", false},
@@ -274,8 +303,8 @@ func TestIsValidExecutorType(t *testing.T) {
 func TestGetSupportedExecutors(t *testing.T) {
 	supported := executor.GetSupportedExecutors()
 
-	if len(supported) != 4 {
-		t.Errorf("GetSupportedExecutors() returned %d types, want 4", len(supported))
+	if len(supported) != 7 {
+		t.Errorf("GetSupportedExecutors() returned %d types, want 7", len(supported))
 	}
 
 	// Check that all expected types are present
@@ -284,6 +313,9 @@ func TestGetSupportedExecutors(t *testing.T) {
 		executor.TypeRampingVUs,
 		executor.TypeConstantArrivalRate,
 		executor.TypeRampingArrivalRate,
+		executor.TypePerVUIterations,
+		executor.TypeSharedIterations,
+		executor.TypeExternallyControlled,
 	}
 
 	for _, expected := range expectedTypes {
@@ -376,6 +408,44 @@ func TestGetExecutorDescription_RampingArrivalRate(t *testing.T) {
 	}
 }
 
+func TestGetExecutorDescription_PerVUIterations(t *testing.T) {
+	desc := executor.GetExecutorDescription(executor.TypePerVUIterations)
+	if desc == nil {
+		t.Fatal("GetExecutorDescription(TypePerVUIterations) returned nil")
+	}
+	if desc.Type != executor.TypePerVUIterations {
+		t.Errorf("Type = %v, want %v", desc.Type, executor.TypePerVUIterations)
+	}
+	if desc.Name == "" {
+		t.Error("Name should not be empty")
+	}
+	if desc.Description == "" {
+		t.Error("Description should not be empty")
+	}
+	if len(desc.UseCases) == 0 {
+		t.Error("UseCases should not be empty")
+	}
+}
+
+func TestGetExecutorDescription_SharedIterations(t *testing.T) {
+	desc := executor.GetExecutorDescription(executor.TypeSharedIterations)
+	if desc == nil {
+		t.Fatal("GetExecutorDescription(TypeSharedIterations) returned nil")
+	}
+	if desc.Type != executor.TypeSharedIterations {
+		t.Errorf("Type = %v, want %v", desc.Type, executor.TypeSharedIterations)
+	}
+	if desc.Name == "" {
+		t.Error("Name should not be empty")
+	}
+	if desc.Description == "" {
+		t.Error("Description should not be empty")
+	}
+	if len(desc.UseCases) == 0 {
+		t.Error("UseCases should not be empty")
+	}
+}
+
 func TestGetExecutorDescription_UnknownType(t *testing.T) {
 	desc := executor.GetExecutorDescription(executor.Type("unknown-type"))
 	if desc != nil {
@@ -740,6 +810,74 @@ func TestConfig_Validate_SharedIterations(t *testing.T) {
 	}
 }
 
+func TestConfig_Validate_ExternallyControlled(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *executor.Config
+		wantErr bool
+	}{
+		{
+			name: "valid vus",
+			config: &executor.Config{
+				Type:   executor.TypeExternallyControlled,
+				VUs:    5,
+				MaxVUs: 50,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid rate",
+			config: &executor.Config{
+				Type: executor.TypeExternallyControlled,
+				Rate: 10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "starting with no vus",
+			config: &executor.Config{
+				Type:   executor.TypeExternallyControlled,
+				MaxVUs: 10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "no vus or maxVUs",
+			config: &executor.Config{
+				Type: executor.TypeExternallyControlled,
+			},
+			wantErr: true,
+		},
+		{
+			name: "vus greater than maxVUs",
+			config: &executor.Config{
+				Type:   executor.TypeExternallyControlled,
+				VUs:    20,
+				MaxVUs: 10,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative rate",
+			config: &executor.Config{
+				Type: executor.TypeExternallyControlled,
+				VUs:  1,
+				Rate: -1,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestConfig_Validate_UnknownType(t *testing.T) {
 	config := &executor.Config{
 		Type: executor.Type("unknown-type"),
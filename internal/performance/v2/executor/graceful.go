@@ -0,0 +1,162 @@
+package executor
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// gracefulStop is the stop sequence shared by the executors.
+//
+// Stopping closes the stop channel, so no new iterations start, asks the
+// VUs to stop after their current request, and waits up to gracefulStop
+// for in-flight iterations before cancelling the run context to interrupt
+// them.
+type gracefulStop struct {
+	stopCh   chan struct{}
+	stopOnce sync.Once
+
+	cancelMu   sync.Mutex // Protects cancelFunc
+	cancelFunc context.CancelFunc
+}
+
+func newGracefulStop() *gracefulStop {
+	return &gracefulStop{
+		stopCh: make(chan struct{}),
+	}
+}
+
+// runContext returns the context iterations run under. It is only cancelled
+// by the parent context or once gracefulStop expires.
+func (g *gracefulStop) runContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	runCtx, cancel := context.WithCancel(ctx)
+	g.cancelMu.Lock()
+	g.cancelFunc = cancel
+	g.cancelMu.Unlock()
+	return runCtx, cancel
+}
+
+// stopped returns a channel that is closed once stopping starts.
+func (g *gracefulStop) stopped() <-chan struct{} {
+	return g.stopCh
+}
+
+// stopping reports whether stopping has started.
+func (g *gracefulStop) stopping() bool {
+	select {
+	case <-g.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// shutdown stops new iterations, calls requestStop to stop the VUs and
+// waits up to timeout for wg before interrupting what is still running.
+//
+// Callers must not add to wg once stopping() is true, so the wait sees
+// every iteration.
+func (g *gracefulStop) shutdown(timeout time.Duration, requestStop func(), wg *sync.WaitGroup) {
+	g.stopOnce.Do(func() {
+		close(g.stopCh)
+	})
+	requestStop()
+
+	done := waitDone(wg)
+	select {
+	case <-done:
+		// Everything stopped
+	case <-time.After(timeout):
+		// Timeout expired - interrupt remaining iterations
+		g.cancel()
+		<-done
+	}
+}
+
+// wait blocks until wg is done. If limit expires first, it shuts down
+// gracefully; if ctx ends first, it waits for the interrupted iterations.
+func (g *gracefulStop) wait(ctx context.Context, limit, timeout time.Duration, requestStop func(), wg *sync.WaitGroup) {
+	done := waitDone(wg)
+
+	timer := time.NewTimer(limit)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		// All iterations completed
+	case <-timer.C:
+		g.shutdown(timeout, requestStop, wg)
+	case <-ctx.Done():
+		<-done
+	}
+}
+
+// stop runs shutdown, returning early with ctx's error if ctx ends first.
+func (g *gracefulStop) stop(ctx context.Context, timeout time.Duration, requestStop func(), wg *sync.WaitGroup) error {
+	stopped := make(chan struct{})
+	go func() {
+		g.shutdown(timeout, requestStop, wg)
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// cancel cancels the run context, interrupting in-flight iterations.
+func (g *gracefulStop) cancel() {
+	g.cancelMu.Lock()
+	defer g.cancelMu.Unlock()
+	if g.cancelFunc != nil {
+		g.cancelFunc()
+	}
+}
+
+// waitDone returns a channel that is closed once wg is done.
+func waitDone(wg *sync.WaitGroup) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	return done
+}
+
+// applyPacing waits between iterations according to the pacing config. It
+// returns early if ctx is cancelled or stopped or vuStopping is closed;
+// either channel may be nil.
+func applyPacing(ctx context.Context, pacing *PacingConfig, stopped, vuStopping <-chan struct{}) {
+	if pacing == nil || pacing.Type == PacingNone {
+		return
+	}
+
+	var wait time.Duration
+	switch pacing.Type {
+	case PacingConstant:
+		wait = pacing.Duration
+	case PacingRandom:
+		diff := pacing.Max - pacing.Min
+		if diff > 0 {
+			wait = pacing.Min + time.Duration(rand.Int63n(int64(diff)))
+		} else {
+			wait = pacing.Min
+		}
+	}
+
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+		case <-stopped:
+		case <-vuStopping:
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	v2 "github.com/wesleyorama2/lunge/internal/performance/v2"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+)
+
+// PerVUIterations runs a fixed number of iterations on each VU.
+//
+// Each of N VUs runs exactly M iterations, as fast as it can (closed model),
+// so the total is always N*M iterations unless the maxDuration cap is hit.
+// When the cap expires, VUs stop starting new iterations and in-flight
+// iterations get up to gracefulStop to finish before being interrupted.
+//
+// Use cases:
+//   - Deterministic smoke tests
+//   - Regression runs that must execute the same amount of work every time
+//   - Per-user workflows (e.g., each VU processes its own data set once)
+type PerVUIterations struct {
+	config    *Config
+	scheduler *v2.VUScheduler
+	metrics   *metrics.Engine
+
+	// State
+	startTime  time.Time
+	activeVUs  atomic.Int32
+	iterations atomic.Int64
+	running    atomic.Bool
+
+	// VU tracking (for graceful stop)
+	vus   []*v2.VirtualUser
+	vusMu sync.Mutex
+
+	// Cancellation
+	stop *gracefulStop
+	wg   sync.WaitGroup
+
+	// Stats
+	mu sync.RWMutex
+}
+
+// NewPerVUIterations creates a new per-VU iterations executor.
+func NewPerVUIterations() *PerVUIterations {
+	return &PerVUIterations{
+		stop: newGracefulStop(),
+	}
+}
+
+// Type returns the executor type.
+func (e *PerVUIterations) Type() Type {
+	return TypePerVUIterations
+}
+
+// Init initializes the executor with configuration.
+func (e *PerVUIterations) Init(ctx context.Context, config *Config) error {
+	if config.Type != TypePerVUIterations {
+		return fmt.Errorf("invalid config type: expected %s, got %s", TypePerVUIterations, config.Type)
+	}
+
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	e.config = config
+	return nil
+}
+
+// Run starts the executor and blocks until all iterations complete
+// or the maxDuration cap expires.
+func (e *PerVUIterations) Run(ctx context.Context, scheduler *v2.VUScheduler, metricsEngine *metrics.Engine) error {
+	e.scheduler = scheduler
+	e.metrics = metricsEngine
+	e.running.Store(true)
+	e.startTime = time.Now()
+
+	// The run context interrupts in-flight requests; it is only cancelled
+	// by the parent context or once gracefulStop expires.
+	runCtx, cancel := e.stop.runContext(ctx)
+	defer cancel()
+
+	// Set phase to steady (per-vu-iterations has no ramp)
+	e.metrics.SetPhase(metrics.PhaseSteady)
+
+	// Spawn all VUs, unless stopped before starting
+	e.vusMu.Lock()
+	for i := 0; i < e.config.VUs && !e.stop.stopping(); i++ {
+		vu := scheduler.SpawnVU()
+		e.vus = append(e.vus, vu)
+		e.wg.Add(1)
+		go e.runVU(runCtx, vu)
+	}
+	e.vusMu.Unlock()
+
+	// Wait for the iterations, stopping them at the maxDuration cap
+	e.stop.wait(ctx, e.config.EffectiveMaxDuration(), e.config.EffectiveGracefulStop(), e.stopVUs, &e.wg)
+
+	// Mark as done
+	e.metrics.SetPhase(metrics.PhaseDone)
+	e.running.Store(false)
+
+	return nil
+}
+
+// runVU runs the configured number of iterations on a single VU.
+func (e *PerVUIterations) runVU(ctx context.Context, vu *v2.VirtualUser) {
+	defer e.wg.Done()
+	defer vu.MarkStopped()
+
+	e.activeVUs.Add(1)
+	e.metrics.SetActiveVUs(int(e.activeVUs.Load()))
+	defer func() {
+		e.activeVUs.Add(-1)
+		e.metrics.SetActiveVUs(int(e.activeVUs.Load()))
+	}()
+
+	for i := int64(0); i < e.config.Iterations; i++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-e.stop.stopped():
+			return
+		default:
+		}
+
+		err := vu.RunIteration(ctx)
+		if err != nil {
+			// Context cancelled or VU stopping - exit gracefully, without
+			// counting an iteration the stop cut short
+			if ctx.Err() != nil || vu.GetState() == v2.VUStateStopping || errors.Is(err, v2.ErrIterationInterrupted) {
+				return
+			}
+			// Other errors - continue to next iteration
+		}
+
+		e.iterations.Add(1)
+
+		// Apply pacing between iterations (not after the last one)
+		if e.config.Pacing != nil && i < e.config.Iterations-1 {
+			applyPacing(ctx, e.config.Pacing, e.stop.stopped(), nil)
+		}
+	}
+}
+
+// GetProgress returns current progress (0.0 to 1.0).
+//
+// Progress is the fraction of iterations completed, or the fraction of
+// maxDuration elapsed if that is further along.
+func (e *PerVUIterations) GetProgress() float64 {
+	if !e.running.Load() {
+		if e.startTime.IsZero() {
+			return 0.0
+		}
+		return 1.0
+	}
+
+	progress := float64(e.iterations.Load()) / float64(e.totalIterations())
+
+	timeProgress := float64(time.Since(e.startTime)) / float64(e.config.EffectiveMaxDuration())
+	if timeProgress > progress {
+		progress = timeProgress
+	}
+
+	if progress > 1.0 {
+		progress = 1.0
+	}
+	return progress
+}
+
+// totalIterations returns the total number of iterations across all VUs.
+func (e *PerVUIterations) totalIterations() int64 {
+	return int64(e.config.VUs) * e.config.Iterations
+}
+
+// GetActiveVUs returns current active VU count.
+func (e *PerVUIterations) GetActiveVUs() int {
+	return int(e.activeVUs.Load())
+}
+
+// GetStats returns executor statistics.
+func (e *PerVUIterations) GetStats() *Stats {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var elapsed time.Duration
+	if !e.startTime.IsZero() {
+		elapsed = time.Since(e.startTime)
+	}
+
+	return &Stats{
+		StartTime:       e.startTime,
+		CurrentTime:     time.Now(),
+		Elapsed:         elapsed,
+		TotalDuration:   e.config.EffectiveMaxDuration(),
+		ActiveVUs:       int(e.activeVUs.Load()),
+		TargetVUs:       e.config.VUs,
+		Iterations:      e.iterations.Load(),
+		TotalIterations: e.totalIterations(),
+	}
+}
+
+// Stop gracefully stops the executor.
+//
+// VUs stop starting new iterations immediately; in-flight iterations
+// get up to gracefulStop to finish.
+func (e *PerVUIterations) Stop(ctx context.Context) error {
+	return e.stop.stop(ctx, e.config.EffectiveGracefulStop(), e.stopVUs, &e.wg)
+}
+
+// stopVUs asks every VU to stop after its current request.
+func (e *PerVUIterations) stopVUs() {
+	e.vusMu.Lock()
+	defer e.vusMu.Unlock()
+	for _, vu := range e.vus {
+		vu.RequestStop()
+	}
+}
+
+// Ensure PerVUIterations implements Executor
+var _ Executor = (*PerVUIterations)(nil)
@@ -0,0 +1,355 @@
+package executor_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v2 "github.com/wesleyorama2/lunge/internal/performance/v2"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/executor"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+)
+
+// createIterationsTestServer creates a test HTTP server that counts requests
+// and responds after the given delay.
+func createIterationsTestServer(delay time.Duration, count *atomic.Int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count.Add(1)
+		if delay > 0 {
+			time.Sleep(delay)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+}
+
+// createIterationsTestScenario creates a scenario for testing
+func createIterationsTestScenario(serverURL string) *v2.Scenario {
+	return &v2.Scenario{
+		Name: "iterations-test",
+		Requests: []*v2.RequestConfig{
+			{
+				Name:   "test-request",
+				Method: "GET",
+				URL:    serverURL,
+			},
+		},
+	}
+}
+
+func TestNewPerVUIterations(t *testing.T) {
+	e := executor.NewPerVUIterations()
+	if e == nil {
+		t.Fatal("NewPerVUIterations() returned nil")
+	}
+	if e.Type() != executor.TypePerVUIterations {
+		t.Errorf("Type() = %v, want %v", e.Type(), executor.TypePerVUIterations)
+	}
+}
+
+func TestPerVUIterations_Init(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *executor.Config
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			config:  &executor.Config{Type: executor.TypePerVUIterations, VUs: 2, Iterations: 10},
+			wantErr: false,
+		},
+		{
+			name:    "valid with maxDuration",
+			config:  &executor.Config{Type: executor.TypePerVUIterations, VUs: 2, Iterations: 10, MaxDuration: time.Minute},
+			wantErr: false,
+		},
+		{
+			name:    "wrong type",
+			config:  &executor.Config{Type: executor.TypeConstantVUs, VUs: 2, Iterations: 10},
+			wantErr: true,
+		},
+		{
+			name:    "zero VUs",
+			config:  &executor.Config{Type: executor.TypePerVUIterations, VUs: 0, Iterations: 10},
+			wantErr: true,
+		},
+		{
+			name:    "zero iterations",
+			config:  &executor.Config{Type: executor.TypePerVUIterations, VUs: 2, Iterations: 0},
+			wantErr: true,
+		},
+		{
+			name:    "negative maxDuration",
+			config:  &executor.Config{Type: executor.TypePerVUIterations, VUs: 2, Iterations: 10, MaxDuration: -time.Second},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := executor.NewPerVUIterations().Init(context.Background(), tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPerVUIterations_Run_ExactIterations(t *testing.T) {
+	var requests atomic.Int64
+	server := createIterationsTestServer(0, &requests)
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scheduler := v2.NewVUScheduler(createIterationsTestScenario(server.URL), metricsEngine, v2.DefaultHTTPClientConfig())
+
+	e := executor.NewPerVUIterations()
+	config := &executor.Config{
+		Type:       executor.TypePerVUIterations,
+		VUs:        3,
+		Iterations: 5,
+	}
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := e.Run(ctx, scheduler, metricsEngine); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	stats := e.GetStats()
+	if stats.Iterations != 15 {
+		t.Errorf("Iterations = %d, want 15", stats.Iterations)
+	}
+	if stats.TotalIterations != 15 {
+		t.Errorf("TotalIterations = %d, want 15", stats.TotalIterations)
+	}
+	if requests.Load() != 15 {
+		t.Errorf("server received %d requests, want 15", requests.Load())
+	}
+	if e.GetProgress() != 1.0 {
+		t.Errorf("GetProgress() after run = %f, want 1.0", e.GetProgress())
+	}
+	if e.GetActiveVUs() != 0 {
+		t.Errorf("GetActiveVUs() after run = %d, want 0", e.GetActiveVUs())
+	}
+	if metricsEngine.GetPhase() != metrics.PhaseDone {
+		t.Errorf("Phase = %v, want %v", metricsEngine.GetPhase(), metrics.PhaseDone)
+	}
+}
+
+func TestPerVUIterations_Run_MaxDuration(t *testing.T) {
+	var requests atomic.Int64
+	server := createIterationsTestServer(50*time.Millisecond, &requests)
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scheduler := v2.NewVUScheduler(createIterationsTestScenario(server.URL), metricsEngine, v2.DefaultHTTPClientConfig())
+
+	e := executor.NewPerVUIterations()
+	config := &executor.Config{
+		Type:         executor.TypePerVUIterations,
+		VUs:          2,
+		Iterations:   1000,
+		MaxDuration:  300 * time.Millisecond,
+		GracefulStop: time.Second,
+	}
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	if err := e.Run(ctx, scheduler, metricsEngine); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if elapsed < 250*time.Millisecond || elapsed > time.Second {
+		t.Errorf("Run() elapsed = %v, want ~300ms", elapsed)
+	}
+
+	stats := e.GetStats()
+	if stats.Iterations < 1 || stats.Iterations >= 2000 {
+		t.Errorf("Iterations = %d, want between 1 and 2000", stats.Iterations)
+	}
+	if stats.TotalDuration != 300*time.Millisecond {
+		t.Errorf("TotalDuration = %v, want 300ms", stats.TotalDuration)
+	}
+}
+
+func TestPerVUIterations_Run_GracefulStopInterrupts(t *testing.T) {
+	var requests atomic.Int64
+	server := createIterationsTestServer(2*time.Second, &requests)
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scheduler := v2.NewVUScheduler(createIterationsTestScenario(server.URL), metricsEngine, v2.DefaultHTTPClientConfig())
+
+	e := executor.NewPerVUIterations()
+	config := &executor.Config{
+		Type:         executor.TypePerVUIterations,
+		VUs:          1,
+		Iterations:   10,
+		MaxDuration:  100 * time.Millisecond,
+		GracefulStop: 100 * time.Millisecond,
+	}
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	if err := e.Run(ctx, scheduler, metricsEngine); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	elapsed := time.Since(start)
+
+	// maxDuration + gracefulStop, well before the 2s response
+	if elapsed > time.Second {
+		t.Errorf("Run() elapsed = %v, want ~200ms (in-flight request should be interrupted)", elapsed)
+	}
+}
+
+func TestPerVUIterations_Run_InterruptedIterationsNotCounted(t *testing.T) {
+	var requests atomic.Int64
+	server := createIterationsTestServer(0, &requests)
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	// The stop arrives during the think time between the two requests
+	scenario := &v2.Scenario{
+		Name: "interrupted",
+		Requests: []*v2.RequestConfig{
+			{Name: "first", Method: "GET", URL: server.URL, ThinkTime: 5 * time.Second},
+			{Name: "second", Method: "GET", URL: server.URL},
+		},
+	}
+	scheduler := v2.NewVUScheduler(scenario, metricsEngine, v2.DefaultHTTPClientConfig())
+
+	e := executor.NewPerVUIterations()
+	config := &executor.Config{
+		Type:         executor.TypePerVUIterations,
+		VUs:          2,
+		Iterations:   3,
+		MaxDuration:  100 * time.Millisecond,
+		GracefulStop: 2 * time.Second,
+	}
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	start := time.Now()
+	if err := e.Run(context.Background(), scheduler, metricsEngine); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Run() elapsed = %v, want the stop to interrupt the think time", elapsed)
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("server got %d requests, want 2 (one per VU)", got)
+	}
+	if stats := e.GetStats(); stats.Iterations != 0 {
+		t.Errorf("Iterations = %d, want 0 (no iteration completed)", stats.Iterations)
+	}
+}
+
+func TestPerVUIterations_Stop(t *testing.T) {
+	var requests atomic.Int64
+	server := createIterationsTestServer(10*time.Millisecond, &requests)
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scheduler := v2.NewVUScheduler(createIterationsTestScenario(server.URL), metricsEngine, v2.DefaultHTTPClientConfig())
+
+	e := executor.NewPerVUIterations()
+	config := &executor.Config{
+		Type:       executor.TypePerVUIterations,
+		VUs:        2,
+		Iterations: 100000,
+	}
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		e.Run(context.Background(), scheduler, metricsEngine)
+		close(done)
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+
+	progress := e.GetProgress()
+	if progress <= 0 || progress >= 1 {
+		t.Errorf("GetProgress() during run = %f, want between 0 and 1", progress)
+	}
+
+	if err := e.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() did not complete after Stop()")
+	}
+
+	if stats := e.GetStats(); stats.Iterations >= stats.TotalIterations {
+		t.Errorf("Iterations = %d, want fewer than %d after Stop()", stats.Iterations, stats.TotalIterations)
+	}
+}
+
+func TestPerVUIterations_ContextCancellation(t *testing.T) {
+	var requests atomic.Int64
+	server := createIterationsTestServer(10*time.Millisecond, &requests)
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scheduler := v2.NewVUScheduler(createIterationsTestScenario(server.URL), metricsEngine, v2.DefaultHTTPClientConfig())
+
+	e := executor.NewPerVUIterations()
+	config := &executor.Config{
+		Type:       executor.TypePerVUIterations,
+		VUs:        2,
+		Iterations: 100000,
+	}
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := e.Run(ctx, scheduler, metricsEngine); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Run() elapsed = %v, want ~200ms after context cancellation", elapsed)
+	}
+}
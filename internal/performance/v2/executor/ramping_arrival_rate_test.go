@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -659,3 +660,43 @@ func BenchmarkRampingArrivalRate_Run(b *testing.B) {
 		cancel()
 	}
 }
+
+func TestRampingArrivalRate_DroppedIterations(t *testing.T) {
+	var requests atomic.Int64
+	server := createIterationsTestServer(200*time.Millisecond, &requests)
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scheduler := v2.NewVUScheduler(createRampingArrivalRateTestScenario(server.URL), metricsEngine, v2.DefaultHTTPClientConfig())
+
+	e := executor.NewRampingArrivalRate()
+
+	config := &executor.Config{
+		Type: executor.TypeRampingArrivalRate,
+		Stages: []executor.Stage{
+			{Duration: 500 * time.Millisecond, Target: 50},
+		},
+		PreAllocatedVUs: 1,
+		MaxVUs:          1,
+	}
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := e.Run(ctx, scheduler, metricsEngine); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	stats := e.GetStats()
+	if stats.DroppedIterations < 10 {
+		t.Errorf("DroppedIterations = %d, want at least 10", stats.DroppedIterations)
+	}
+	if got := metricsEngine.GetDroppedIterations(); got != stats.DroppedIterations {
+		t.Errorf("metrics DroppedIterations = %d, want %d", got, stats.DroppedIterations)
+	}
+}
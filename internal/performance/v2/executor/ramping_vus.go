@@ -2,8 +2,8 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/rand"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -45,8 +45,8 @@ type RampingVUs struct {
 	running      atomic.Bool
 
 	// Cancellation
-	cancelFunc context.CancelFunc
-	wg         sync.WaitGroup
+	stop *gracefulStop
+	wg   sync.WaitGroup
 
 	// VU tracking
 	vus   []*v2.VirtualUser
@@ -59,7 +59,8 @@ type RampingVUs struct {
 // NewRampingVUs creates a new ramping VUs executor.
 func NewRampingVUs() *RampingVUs {
 	return &RampingVUs{
-		vus: make([]*v2.VirtualUser, 0),
+		vus:  make([]*v2.VirtualUser, 0),
+		stop: newGracefulStop(),
 	}
 }
 
@@ -92,9 +93,9 @@ func (e *RampingVUs) Run(ctx context.Context, scheduler *v2.VUScheduler, metrics
 	// Calculate total duration from stages
 	totalDuration := e.config.TotalDuration()
 
-	// Create cancellable context with duration timeout
-	runCtx, cancel := context.WithTimeout(ctx, totalDuration)
-	e.cancelFunc = cancel
+	// The run context interrupts in-flight requests; it is only cancelled
+	// by the parent context or once gracefulStop expires.
+	runCtx, cancel := e.stop.runContext(ctx)
 	defer cancel()
 
 	// Start VU controller (adjusts VU count smoothly)
@@ -104,14 +105,18 @@ func (e *RampingVUs) Run(ctx context.Context, scheduler *v2.VUScheduler, metrics
 		close(controllerDone)
 	}()
 
-	// Wait for context to complete or be cancelled
-	<-runCtx.Done()
+	duration := time.NewTimer(totalDuration)
+	defer duration.Stop()
 
-	// Wait for controller to finish
-	<-controllerDone
+	select {
+	case <-duration.C:
+	case <-e.stop.stopped():
+	case <-ctx.Done():
+	}
 
 	// Graceful shutdown - wait for VUs to finish current iteration
-	e.gracefulShutdown()
+	e.stop.shutdown(e.config.EffectiveGracefulStop(), e.stopVUs, &e.wg)
+	<-controllerDone
 
 	// Mark as done
 	e.metrics.SetPhase(metrics.PhaseDone)
@@ -130,6 +135,8 @@ func (e *RampingVUs) vuController(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
+		case <-e.stop.stopped():
+			return
 		case <-ticker.C:
 			targetVUs := e.calculateTargetVUs()
 			e.targetVUs.Store(int32(targetVUs))
@@ -183,6 +190,11 @@ func (e *RampingVUs) adjustVUs(ctx context.Context, targetVUs int) {
 	e.vusMu.Lock()
 	defer e.vusMu.Unlock()
 
+	// No VU is spawned or stopped here once shutting down
+	if e.stop.stopping() {
+		return
+	}
+
 	currentVUs := len(e.vus)
 
 	if targetVUs > currentVUs {
@@ -251,6 +263,8 @@ func (e *RampingVUs) runVU(ctx context.Context, vu *v2.VirtualUser) {
 		select {
 		case <-ctx.Done():
 			return
+		case <-e.stop.stopped():
+			return
 		default:
 		}
 
@@ -262,7 +276,9 @@ func (e *RampingVUs) runVU(ctx context.Context, vu *v2.VirtualUser) {
 		// Run one iteration
 		err := vu.RunIteration(ctx)
 		if err != nil {
-			if ctx.Err() != nil || vu.GetState() == v2.VUStateStopping {
+			// Context cancelled or VU stopping - exit gracefully, without
+			// counting an iteration the stop cut short
+			if ctx.Err() != nil || vu.GetState() == v2.VUStateStopping || errors.Is(err, v2.ErrIterationInterrupted) {
 				return
 			}
 		}
@@ -271,65 +287,18 @@ func (e *RampingVUs) runVU(ctx context.Context, vu *v2.VirtualUser) {
 
 		// Apply pacing between iterations
 		if e.config.Pacing != nil {
-			e.applyPacing(ctx, vu)
+			applyPacing(ctx, e.config.Pacing, e.stop.stopped(), vu.Stopping())
 		}
 	}
 }
 
-// applyPacing waits between iterations.
-func (e *RampingVUs) applyPacing(ctx context.Context, vu *v2.VirtualUser) {
-	if e.config.Pacing == nil || e.config.Pacing.Type == PacingNone {
-		return
-	}
-
-	var wait time.Duration
-	switch e.config.Pacing.Type {
-	case PacingConstant:
-		wait = e.config.Pacing.Duration
-	case PacingRandom:
-		diff := e.config.Pacing.Max - e.config.Pacing.Min
-		if diff > 0 {
-			wait = e.config.Pacing.Min + time.Duration(rand.Int63n(int64(diff)))
-		} else {
-			wait = e.config.Pacing.Min
-		}
-	}
-
-	if wait > 0 {
-		select {
-		case <-ctx.Done():
-		case <-time.After(wait):
-		}
-	}
-}
-
-// gracefulShutdown waits for all VUs to finish their current iteration.
-func (e *RampingVUs) gracefulShutdown() {
+// stopVUs asks every VU to stop after its current request.
+func (e *RampingVUs) stopVUs() {
 	e.vusMu.Lock()
-	// Request all VUs to stop
+	defer e.vusMu.Unlock()
 	for _, vu := range e.vus {
 		vu.RequestStop()
 	}
-	e.vusMu.Unlock()
-
-	// Wait with timeout
-	graceful := e.config.GracefulStop
-	if graceful == 0 {
-		graceful = 30 * time.Second
-	}
-
-	done := make(chan struct{})
-	go func() {
-		e.wg.Wait()
-		close(done)
-	}()
-
-	select {
-	case <-done:
-		// All VUs stopped
-	case <-time.After(graceful):
-		// Timeout expired
-	}
 }
 
 // GetProgress returns current progress (0.0 to 1.0).
@@ -390,13 +359,11 @@ func (e *RampingVUs) GetStats() *Stats {
 }
 
 // Stop gracefully stops the executor.
+//
+// VUs stop starting new iterations immediately; in-flight iterations
+// get up to gracefulStop to finish.
 func (e *RampingVUs) Stop(ctx context.Context) error {
-	if e.cancelFunc != nil {
-		e.cancelFunc()
-	}
-
-	e.gracefulShutdown()
-	return nil
+	return e.stop.stop(ctx, e.config.EffectiveGracefulStop(), e.stopVUs, &e.wg)
 }
 
 // Ensure RampingVUs implements Executor
@@ -0,0 +1,254 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	v2 "github.com/wesleyorama2/lunge/internal/performance/v2"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+)
+
+// SharedIterations shares a fixed total iteration count across a pool of VUs.
+//
+// N VUs pull iterations from a shared counter as fast as they can (closed
+// model) until the total is drained or the maxDuration cap is hit. Faster
+// VUs run more iterations, so the per-VU distribution is not uniform; it is
+// reported in Stats.VUIterations.
+// When the cap expires, VUs stop starting new iterations and in-flight
+// iterations get up to gracefulStop to finish before being interrupted.
+//
+// Use cases:
+//   - Data seeding
+//   - "Process this many orders" style tests
+//   - Finishing a fixed amount of work as fast as possible
+type SharedIterations struct {
+	config    *Config
+	scheduler *v2.VUScheduler
+	metrics   *metrics.Engine
+
+	// State
+	startTime  time.Time
+	activeVUs  atomic.Int32
+	iterations atomic.Int64
+	claimed    atomic.Int64
+	running    atomic.Bool
+
+	// VU tracking (for graceful stop and per-VU distribution)
+	vus          []*v2.VirtualUser
+	vuIterations map[int]*atomic.Int64
+	vusMu        sync.Mutex
+
+	// Cancellation
+	stop *gracefulStop
+	wg   sync.WaitGroup
+
+	// Stats
+	mu sync.RWMutex
+}
+
+// NewSharedIterations creates a new shared iterations executor.
+func NewSharedIterations() *SharedIterations {
+	return &SharedIterations{
+		vuIterations: make(map[int]*atomic.Int64),
+		stop:         newGracefulStop(),
+	}
+}
+
+// Type returns the executor type.
+func (e *SharedIterations) Type() Type {
+	return TypeSharedIterations
+}
+
+// Init initializes the executor with configuration.
+func (e *SharedIterations) Init(ctx context.Context, config *Config) error {
+	if config.Type != TypeSharedIterations {
+		return fmt.Errorf("invalid config type: expected %s, got %s", TypeSharedIterations, config.Type)
+	}
+
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	e.config = config
+	return nil
+}
+
+// Run starts the executor and blocks until all iterations complete
+// or the maxDuration cap expires.
+func (e *SharedIterations) Run(ctx context.Context, scheduler *v2.VUScheduler, metricsEngine *metrics.Engine) error {
+	e.scheduler = scheduler
+	e.metrics = metricsEngine
+	e.running.Store(true)
+	e.startTime = time.Now()
+
+	// The run context interrupts in-flight requests; it is only cancelled
+	// by the parent context or once gracefulStop expires.
+	runCtx, cancel := e.stop.runContext(ctx)
+	defer cancel()
+
+	// Set phase to steady (shared-iterations has no ramp)
+	e.metrics.SetPhase(metrics.PhaseSteady)
+
+	// Spawn VUs (never more than there are iterations to run), unless
+	// stopped before starting
+	e.vusMu.Lock()
+	for i := 0; i < e.targetVUs() && !e.stop.stopping(); i++ {
+		vu := scheduler.SpawnVU()
+		counter := &atomic.Int64{}
+		e.vus = append(e.vus, vu)
+		e.vuIterations[vu.ID] = counter
+		e.wg.Add(1)
+		go e.runVU(runCtx, vu, counter)
+	}
+	e.vusMu.Unlock()
+
+	// Wait for the iterations, stopping them at the maxDuration cap
+	e.stop.wait(ctx, e.config.EffectiveMaxDuration(), e.config.EffectiveGracefulStop(), e.stopVUs, &e.wg)
+
+	// Mark as done
+	e.metrics.SetPhase(metrics.PhaseDone)
+	e.running.Store(false)
+
+	return nil
+}
+
+// runVU runs iterations on a single VU until the shared total is drained.
+func (e *SharedIterations) runVU(ctx context.Context, vu *v2.VirtualUser, vuIterations *atomic.Int64) {
+	defer e.wg.Done()
+	defer vu.MarkStopped()
+
+	e.activeVUs.Add(1)
+	e.metrics.SetActiveVUs(int(e.activeVUs.Load()))
+	defer func() {
+		e.activeVUs.Add(-1)
+		e.metrics.SetActiveVUs(int(e.activeVUs.Load()))
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-e.stop.stopped():
+			return
+		default:
+		}
+
+		// Claim the next iteration from the shared pool
+		if e.claimed.Add(1) > e.config.Iterations {
+			return
+		}
+
+		err := vu.RunIteration(ctx)
+		if err != nil {
+			// Context cancelled or VU stopping - exit gracefully, without
+			// counting an iteration the stop cut short
+			if ctx.Err() != nil || vu.GetState() == v2.VUStateStopping || errors.Is(err, v2.ErrIterationInterrupted) {
+				return
+			}
+			// Other errors - continue to next iteration
+		}
+
+		e.iterations.Add(1)
+		vuIterations.Add(1)
+
+		// Apply pacing between iterations (not after the last one)
+		if e.config.Pacing != nil && e.claimed.Load() < e.config.Iterations {
+			applyPacing(ctx, e.config.Pacing, e.stop.stopped(), nil)
+		}
+	}
+}
+
+// GetProgress returns current progress (0.0 to 1.0).
+//
+// Progress is the fraction of iterations completed, or the fraction of
+// maxDuration elapsed if that is further along.
+func (e *SharedIterations) GetProgress() float64 {
+	if !e.running.Load() {
+		if e.startTime.IsZero() {
+			return 0.0
+		}
+		return 1.0
+	}
+
+	progress := float64(e.iterations.Load()) / float64(e.config.Iterations)
+
+	timeProgress := float64(time.Since(e.startTime)) / float64(e.config.EffectiveMaxDuration())
+	if timeProgress > progress {
+		progress = timeProgress
+	}
+
+	if progress > 1.0 {
+		progress = 1.0
+	}
+	return progress
+}
+
+// targetVUs returns the number of VUs to run, capped at the iteration count.
+func (e *SharedIterations) targetVUs() int {
+	if int64(e.config.VUs) > e.config.Iterations {
+		return int(e.config.Iterations)
+	}
+	return e.config.VUs
+}
+
+// GetActiveVUs returns current active VU count.
+func (e *SharedIterations) GetActiveVUs() int {
+	return int(e.activeVUs.Load())
+}
+
+// GetStats returns executor statistics.
+func (e *SharedIterations) GetStats() *Stats {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	var elapsed time.Duration
+	if !e.startTime.IsZero() {
+		elapsed = time.Since(e.startTime)
+	}
+
+	e.vusMu.Lock()
+	var vuIterations map[int]int64
+	if len(e.vuIterations) > 0 {
+		vuIterations = make(map[int]int64, len(e.vuIterations))
+		for id, count := range e.vuIterations {
+			vuIterations[id] = count.Load()
+		}
+	}
+	e.vusMu.Unlock()
+
+	return &Stats{
+		StartTime:       e.startTime,
+		CurrentTime:     time.Now(),
+		Elapsed:         elapsed,
+		TotalDuration:   e.config.EffectiveMaxDuration(),
+		ActiveVUs:       int(e.activeVUs.Load()),
+		TargetVUs:       e.targetVUs(),
+		Iterations:      e.iterations.Load(),
+		TotalIterations: e.config.Iterations,
+		VUIterations:    vuIterations,
+	}
+}
+
+// Stop gracefully stops the executor.
+//
+// VUs stop starting new iterations immediately; in-flight iterations
+// get up to gracefulStop to finish.
+func (e *SharedIterations) Stop(ctx context.Context) error {
+	return e.stop.stop(ctx, e.config.EffectiveGracefulStop(), e.stopVUs, &e.wg)
+}
+
+// stopVUs asks every VU to stop after its current request.
+func (e *SharedIterations) stopVUs() {
+	e.vusMu.Lock()
+	defer e.vusMu.Unlock()
+	for _, vu := range e.vus {
+		vu.RequestStop()
+	}
+}
+
+// Ensure SharedIterations implements Executor
+var _ Executor = (*SharedIterations)(nil)
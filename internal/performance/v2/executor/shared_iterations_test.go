@@ -0,0 +1,290 @@
+package executor_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v2 "github.com/wesleyorama2/lunge/internal/performance/v2"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/executor"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+)
+
+func TestNewSharedIterations(t *testing.T) {
+	e := executor.NewSharedIterations()
+	if e == nil {
+		t.Fatal("NewSharedIterations() returned nil")
+	}
+	if e.Type() != executor.TypeSharedIterations {
+		t.Errorf("Type() = %v, want %v", e.Type(), executor.TypeSharedIterations)
+	}
+}
+
+func TestSharedIterations_Init(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *executor.Config
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			config:  &executor.Config{Type: executor.TypeSharedIterations, VUs: 5, Iterations: 100},
+			wantErr: false,
+		},
+		{
+			name:    "wrong type",
+			config:  &executor.Config{Type: executor.TypePerVUIterations, VUs: 5, Iterations: 100},
+			wantErr: true,
+		},
+		{
+			name:    "zero VUs",
+			config:  &executor.Config{Type: executor.TypeSharedIterations, VUs: 0, Iterations: 100},
+			wantErr: true,
+		},
+		{
+			name:    "zero iterations",
+			config:  &executor.Config{Type: executor.TypeSharedIterations, VUs: 5, Iterations: 0},
+			wantErr: true,
+		},
+		{
+			name:    "negative maxDuration",
+			config:  &executor.Config{Type: executor.TypeSharedIterations, VUs: 5, Iterations: 100, MaxDuration: -time.Second},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := executor.NewSharedIterations().Init(context.Background(), tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSharedIterations_Run_DrainsTotal(t *testing.T) {
+	var requests atomic.Int64
+	server := createIterationsTestServer(time.Millisecond, &requests)
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scheduler := v2.NewVUScheduler(createIterationsTestScenario(server.URL), metricsEngine, v2.DefaultHTTPClientConfig())
+
+	e := executor.NewSharedIterations()
+	config := &executor.Config{
+		Type:       executor.TypeSharedIterations,
+		VUs:        4,
+		Iterations: 50,
+	}
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := e.Run(ctx, scheduler, metricsEngine); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	stats := e.GetStats()
+	if stats.Iterations != 50 {
+		t.Errorf("Iterations = %d, want 50", stats.Iterations)
+	}
+	if stats.TotalIterations != 50 {
+		t.Errorf("TotalIterations = %d, want 50", stats.TotalIterations)
+	}
+	if requests.Load() != 50 {
+		t.Errorf("server received %d requests, want 50", requests.Load())
+	}
+
+	// Per-VU distribution should cover every VU and sum to the total
+	if len(stats.VUIterations) != 4 {
+		t.Errorf("VUIterations has %d VUs, want 4", len(stats.VUIterations))
+	}
+	var sum int64
+	for id, count := range stats.VUIterations {
+		if count < 0 {
+			t.Errorf("VU %d iterations = %d, want >= 0", id, count)
+		}
+		sum += count
+	}
+	if sum != 50 {
+		t.Errorf("sum of VUIterations = %d, want 50", sum)
+	}
+
+	if e.GetProgress() != 1.0 {
+		t.Errorf("GetProgress() after run = %f, want 1.0", e.GetProgress())
+	}
+}
+
+func TestSharedIterations_Run_MoreVUsThanIterations(t *testing.T) {
+	var requests atomic.Int64
+	server := createIterationsTestServer(0, &requests)
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scheduler := v2.NewVUScheduler(createIterationsTestScenario(server.URL), metricsEngine, v2.DefaultHTTPClientConfig())
+
+	e := executor.NewSharedIterations()
+	config := &executor.Config{
+		Type:       executor.TypeSharedIterations,
+		VUs:        10,
+		Iterations: 3,
+	}
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if err := e.Run(context.Background(), scheduler, metricsEngine); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	stats := e.GetStats()
+	if stats.Iterations != 3 {
+		t.Errorf("Iterations = %d, want 3", stats.Iterations)
+	}
+	if stats.TargetVUs != 3 {
+		t.Errorf("TargetVUs = %d, want 3 (capped at iterations)", stats.TargetVUs)
+	}
+	if len(stats.VUIterations) != 3 {
+		t.Errorf("VUIterations has %d VUs, want 3", len(stats.VUIterations))
+	}
+}
+
+func TestSharedIterations_Run_MaxDuration(t *testing.T) {
+	var requests atomic.Int64
+	server := createIterationsTestServer(50*time.Millisecond, &requests)
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scheduler := v2.NewVUScheduler(createIterationsTestScenario(server.URL), metricsEngine, v2.DefaultHTTPClientConfig())
+
+	e := executor.NewSharedIterations()
+	config := &executor.Config{
+		Type:         executor.TypeSharedIterations,
+		VUs:          2,
+		Iterations:   1000,
+		MaxDuration:  300 * time.Millisecond,
+		GracefulStop: time.Second,
+	}
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	start := time.Now()
+	if err := e.Run(context.Background(), scheduler, metricsEngine); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if elapsed < 250*time.Millisecond || elapsed > time.Second {
+		t.Errorf("Run() elapsed = %v, want ~300ms", elapsed)
+	}
+
+	stats := e.GetStats()
+	if stats.Iterations < 1 || stats.Iterations >= 1000 {
+		t.Errorf("Iterations = %d, want between 1 and 1000", stats.Iterations)
+	}
+}
+
+func TestSharedIterations_Stop(t *testing.T) {
+	var requests atomic.Int64
+	server := createIterationsTestServer(10*time.Millisecond, &requests)
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scheduler := v2.NewVUScheduler(createIterationsTestScenario(server.URL), metricsEngine, v2.DefaultHTTPClientConfig())
+
+	e := executor.NewSharedIterations()
+	config := &executor.Config{
+		Type:       executor.TypeSharedIterations,
+		VUs:        2,
+		Iterations: 100000,
+	}
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		e.Run(context.Background(), scheduler, metricsEngine)
+		close(done)
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+
+	if active := e.GetActiveVUs(); active != 2 {
+		t.Errorf("GetActiveVUs() during run = %d, want 2", active)
+	}
+
+	if err := e.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run() did not complete after Stop()")
+	}
+
+	if stats := e.GetStats(); stats.Iterations >= stats.TotalIterations {
+		t.Errorf("Iterations = %d, want fewer than %d after Stop()", stats.Iterations, stats.TotalIterations)
+	}
+}
+
+func TestSharedIterations_Run_InterruptedIterationsNotCounted(t *testing.T) {
+	var requests atomic.Int64
+	server := createIterationsTestServer(0, &requests)
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	// The stop arrives during the think time between the two requests
+	scenario := &v2.Scenario{
+		Name: "interrupted",
+		Requests: []*v2.RequestConfig{
+			{Name: "first", Method: "GET", URL: server.URL, ThinkTime: 5 * time.Second},
+			{Name: "second", Method: "GET", URL: server.URL},
+		},
+	}
+	scheduler := v2.NewVUScheduler(scenario, metricsEngine, v2.DefaultHTTPClientConfig())
+
+	e := executor.NewSharedIterations()
+	config := &executor.Config{
+		Type:         executor.TypeSharedIterations,
+		VUs:          2,
+		Iterations:   10,
+		MaxDuration:  100 * time.Millisecond,
+		GracefulStop: 2 * time.Second,
+	}
+	if err := e.Init(context.Background(), config); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	start := time.Now()
+	if err := e.Run(context.Background(), scheduler, metricsEngine); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Run() elapsed = %v, want the stop to interrupt the think time", elapsed)
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("server got %d requests, want 2 (one per VU)", got)
+	}
+	if stats := e.GetStats(); stats.Iterations != 0 {
+		t.Errorf("Iterations = %d, want 0 (no iteration completed)", stats.Iterations)
+	}
+}
@@ -0,0 +1,64 @@
+package v2
+
+import "math/rand"
+
+// Flow is how the iterations of a scenario run its requests.
+type Flow string
+
+const (
+	// FlowSequential runs every request of the scenario in order.
+	FlowSequential Flow = "sequential"
+	// FlowWeighted runs one request or group per iteration, picked at
+	// random in proportion to the weights.
+	FlowWeighted Flow = "weighted"
+)
+
+// StepName returns the name under which a step is reported: the group
+// name for groups, the request name otherwise.
+func (r *RequestConfig) StepName() string {
+	if r.Group != nil {
+		return r.Group.Name
+	}
+	return r.Name
+}
+
+// pickStep picks the request or group the VU's next iteration of a
+// weighted flow runs.
+//
+// With a scenario seed, each VU draws from its own source seeded with the
+// scenario seed plus its ID, so a VU makes the same picks on every run.
+func (vu *VirtualUser) pickStep() *RequestConfig {
+	if vu.rng == nil {
+		seed := rand.Int63()
+		if vu.Scenario.Seed != nil {
+			seed = *vu.Scenario.Seed + int64(vu.ID)
+		}
+		vu.rng = rand.New(rand.NewSource(seed))
+	}
+	return pickWeighted(vu.Scenario.Requests, vu.rng)
+}
+
+// pickWeighted picks one of steps at random in proportion to their
+// weights. Steps without a positive weight are never picked, unless no
+// step has one, in which case all are equally likely.
+func pickWeighted(steps []*RequestConfig, rng *rand.Rand) *RequestConfig {
+	total := 0
+	for _, step := range steps {
+		total += max(step.Weight, 0)
+	}
+	if total == 0 {
+		return steps[rng.Intn(len(steps))]
+	}
+
+	n := rng.Intn(total)
+	for _, step := range steps {
+		if step.Weight <= 0 {
+			continue
+		}
+		if n < step.Weight {
+			return step
+		}
+		n -= step.Weight
+	}
+	return steps[len(steps)-1]
+}
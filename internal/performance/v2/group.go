@@ -0,0 +1,37 @@
+package v2
+
+// Group is a named block of requests that runs as a single step of an
+// iteration. Like a request, the step can be conditional or repeated.
+//
+// Each run of the group is recorded as a group duration: the wall time
+// of all its requests, including think time between them.
+type Group struct {
+	// Name of the group (used in metrics). Nested groups carry the full
+	// path, e.g. "checkout::payment".
+	Name string `json:"name" yaml:"name"`
+
+	// Requests to execute in order, which may include other groups
+	Requests []*RequestConfig `json:"requests" yaml:"requests"`
+}
+
+// RequestGroups returns the group of each request in the scenario that
+// belongs to one, keyed by request name.
+func (s *Scenario) RequestGroups() map[string]string {
+	groups := make(map[string]string)
+	collectRequestGroups(s.Requests, "", groups)
+	return groups
+}
+
+// collectRequestGroups adds the requests in requests, which belong to
+// group, and those of nested groups to groups.
+func collectRequestGroups(requests []*RequestConfig, group string, groups map[string]string) {
+	for _, req := range requests {
+		if req.Group != nil {
+			collectRequestGroups(req.Group.Requests, req.Group.Name, groups)
+			continue
+		}
+		if group != "" {
+			groups[req.Name] = group
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Package live serves the metrics of a running performance test over HTTP,
+// so dashboards and log watchers can follow a test remotely.
+//
+// The server exposes two read-only endpoints:
+//
+//	GET /v1/metrics   JSON snapshot of the current metrics
+//	GET /v1/events    Server-Sent Events stream of snapshots
+//
+// The stream sends a "metrics" event on connect and then once per interval.
+// When the server is closed, it sends a final "end" event and ends.
+package live
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/wesleyorama2/lunge/internal/performance/v2/engine"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/executor"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/serve"
+)
+
+// DefaultInterval is how often the event stream sends a snapshot.
+const DefaultInterval = time.Second
+
+// Snapshot is the live state of a test.
+type Snapshot struct {
+	Time      time.Time                  `json:"time"`
+	Running   bool                       `json:"running"`
+	Progress  float64                    `json:"progress"` // 0.0 to 1.0
+	Metrics   *metrics.Snapshot          `json:"metrics,omitempty"`
+	Scenarios map[string]*executor.Stats `json:"scenarios"`
+
+	// Latest is the most recent 1-second time bucket
+	Latest *metrics.TimeBucket `json:"latest,omitempty"`
+}
+
+// Server serves the live metrics of an engine.
+type Server struct {
+	engine   *engine.Engine
+	interval time.Duration
+	http     *serve.Server
+
+	// done is closed by Close to end the event streams
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewServer creates a live metrics server for an engine. The event stream
+// sends a snapshot every interval; zero uses DefaultInterval. Call Start to
+// listen.
+func NewServer(eng *engine.Engine, interval time.Duration) *Server {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	s := &Server{
+		engine:   eng,
+		interval: interval,
+		done:     make(chan struct{}),
+	}
+	s.http = serve.New(s.Handler())
+	return s
+}
+
+// Handler returns the HTTP handler of the live metrics endpoints.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/metrics", s.handleMetrics)
+	mux.HandleFunc("GET /v1/events", s.handleEvents)
+	return mux
+}
+
+// Start listens on addr (e.g. ":6566" or "127.0.0.1:0") and serves the
+// endpoints in the background.
+func (s *Server) Start(addr string) error {
+	return s.http.Start(addr)
+}
+
+// Addr returns the address the server listens on, once started.
+func (s *Server) Addr() string {
+	return s.http.Addr()
+}
+
+// Close ends the event streams, after sending each a final snapshot, and
+// stops the server.
+func (s *Server) Close() error {
+	s.closeOnce.Do(func() { close(s.done) })
+	return s.http.Close()
+}
+
+// Snapshot returns the current live state of the engine.
+func (s *Server) Snapshot() *Snapshot {
+	return &Snapshot{
+		Time:      time.Now(),
+		Running:   s.engine.IsRunning(),
+		Progress:  s.engine.GetProgress(),
+		Metrics:   s.engine.GetMetrics(),
+		Scenarios: s.engine.GetScenarioStats(),
+		Latest:    s.engine.GetLatestBucket(),
+	}
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(s.Snapshot())
+}
+
+// handleEvents streams snapshots until the client disconnects or the
+// server is closed.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	event := "metrics"
+	for {
+		if err := writeEvent(w, event, s.Snapshot()); err != nil {
+			return
+		}
+		flusher.Flush()
+		if event == "end" {
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-s.done:
+			event = "end"
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// writeEvent writes one Server-Sent Event with a JSON payload.
+func writeEvent(w http.ResponseWriter, event string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
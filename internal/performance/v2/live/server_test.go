@@ -0,0 +1,189 @@
+package live
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/config"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/engine"
+)
+
+// newEngine creates an engine with one open-ended scenario against a
+// local target.
+func newEngine(t *testing.T) *engine.Engine {
+	t.Helper()
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(target.Close)
+
+	eng, err := engine.NewEngine(&config.TestConfig{
+		Name: "Live Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"soak": {
+				Executor: "externally-controlled",
+				VUs:      2,
+				Requests: []config.RequestConfig{{Name: "get", Method: "GET", URL: target.URL}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	return eng
+}
+
+// startEngine runs the engine in the background until the test ends.
+func startEngine(t *testing.T, eng *engine.Engine) {
+	t.Helper()
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = eng.Run(context.Background())
+		close(done)
+	}()
+	t.Cleanup(func() {
+		_ = eng.Stop(context.Background())
+		<-done
+	})
+
+	require.Eventually(t, eng.IsRunning, 5*time.Second, 10*time.Millisecond)
+}
+
+// event is one parsed Server-Sent Event.
+type event struct {
+	name     string
+	snapshot Snapshot
+}
+
+// readEvent reads the next event from a stream.
+func readEvent(t *testing.T, reader *bufio.Reader) event {
+	t.Helper()
+
+	var ev event
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimRight(line, "\n")
+
+		switch {
+		case line == "":
+			return ev
+		case strings.HasPrefix(line, "event: "):
+			ev.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &ev.snapshot))
+		}
+	}
+}
+
+func TestServer_Metrics(t *testing.T) {
+	eng := newEngine(t)
+	server := httptest.NewServer(NewServer(eng, 0).Handler())
+	defer server.Close()
+
+	get := func() Snapshot {
+		resp, err := http.Get(server.URL + "/v1/metrics")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"), "other origins must not read the metrics")
+
+		var got Snapshot
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+		return got
+	}
+
+	// Before the test starts there is nothing to report
+	idle := get()
+	assert.False(t, idle.Running)
+	assert.Nil(t, idle.Metrics)
+	assert.Nil(t, idle.Latest)
+
+	startEngine(t, eng)
+	require.Eventually(t, func() bool {
+		return eng.GetLatestBucket() != nil
+	}, 5*time.Second, 50*time.Millisecond)
+
+	got := get()
+	assert.True(t, got.Running)
+	require.NotNil(t, got.Metrics)
+	assert.Greater(t, got.Metrics.TotalRequests, int64(0))
+	require.NotNil(t, got.Latest)
+	assert.Greater(t, got.Latest.TotalRequests, int64(0))
+	require.Contains(t, got.Scenarios, "soak")
+	assert.Greater(t, got.Scenarios["soak"].Iterations, int64(0))
+
+	// Read-only
+	resp, err := http.Post(server.URL+"/v1/metrics", "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestServer_Events(t *testing.T) {
+	eng := newEngine(t)
+	startEngine(t, eng)
+
+	server := NewServer(eng, 20*time.Millisecond)
+	require.NoError(t, server.Start("127.0.0.1:0"))
+	defer server.Close()
+
+	resp, err := http.Get("http://" + server.Addr() + "/v1/events")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	first := readEvent(t, reader)
+	assert.Equal(t, "metrics", first.name)
+	assert.True(t, first.snapshot.Running)
+
+	// Snapshots keep coming while the test runs
+	var last event
+	for i := 0; i < 5; i++ {
+		last = readEvent(t, reader)
+		assert.Equal(t, "metrics", last.name)
+	}
+	assert.True(t, last.snapshot.Time.After(first.snapshot.Time))
+
+	// Closing the server ends the stream with a final snapshot
+	closed := make(chan error, 1)
+	go func() { closed <- server.Close() }()
+
+	for {
+		ev := readEvent(t, reader)
+		if ev.name == "end" {
+			break
+		}
+	}
+	_, err = reader.ReadString('\n')
+	assert.Error(t, err, "stream should end after the end event")
+
+	select {
+	case err := <-closed:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close() did not return")
+	}
+}
+
+func TestServer_Start(t *testing.T) {
+	eng := newEngine(t)
+
+	server := NewServer(eng, 0)
+	require.NoError(t, server.Start("127.0.0.1:0"))
+	defer server.Close()
+	assert.NotEmpty(t, server.Addr())
+
+	// Port already in use
+	assert.Error(t, NewServer(eng, 0).Start(server.Addr()))
+}
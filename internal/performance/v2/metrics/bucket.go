@@ -52,6 +52,13 @@ type TimeBucket struct {
 
 	// Error rate for this interval
 	IntervalErrorRate float64 `json:"intervalErrorRate"`
+
+	// Iterations that arrival-rate executors could not start on time
+	TotalDroppedIterations    int64 `json:"totalDroppedIterations"`
+	IntervalDroppedIterations int64 `json:"intervalDroppedIterations"`
+
+	// Responses per HTTP status code in this interval
+	IntervalStatusCodes map[int]int64 `json:"intervalStatusCodes,omitempty"`
 }
 
 // TimeBucketStore stores time-bucketed metrics in a ring buffer.
@@ -79,6 +86,13 @@ type TimeBucketStore struct {
 	currentSuccesses atomic.Int64
 	currentFailures  atomic.Int64
 	currentBytes     atomic.Int64
+
+	// Dropped iterations (interval accumulator and running total)
+	currentDropped atomic.Int64
+	totalDropped   atomic.Int64
+
+	// Responses per status code in the current interval
+	currentStatusCodes *statusStore
 }
 
 // NewTimeBucketStore creates a new time bucket store.
@@ -93,9 +107,10 @@ func NewTimeBucketStore(maxBuckets int) *TimeBucketStore {
 	}
 
 	return &TimeBucketStore{
-		buckets:        make([]*TimeBucket, maxBuckets),
-		maxBuckets:     maxBuckets,
-		lastBucketTime: time.Now(),
+		buckets:            make([]*TimeBucket, maxBuckets),
+		maxBuckets:         maxBuckets,
+		lastBucketTime:     time.Now(),
+		currentStatusCodes: newStatusStore(),
 	}
 }
 
@@ -105,7 +120,7 @@ func NewTimeBucketStore(maxBuckets int) *TimeBucketStore {
 // for high-concurrency scenarios without blocking.
 //
 // Parameters:
-//   - success: true if the request succeeded (status < 400 and no error)
+//   - success: true if the request succeeded (expected status and no error)
 //   - bytes: number of bytes received
 func (tbs *TimeBucketStore) RecordRequest(success bool, bytes int64) {
 	tbs.currentRequests.Add(1)
@@ -118,6 +133,21 @@ func (tbs *TimeBucketStore) RecordRequest(success bool, bytes int64) {
 	}
 }
 
+// RecordDroppedIteration records an iteration that could not be started.
+//
+// Like RecordRequest, this method is lock-free.
+func (tbs *TimeBucketStore) RecordDroppedIteration() {
+	tbs.currentDropped.Add(1)
+	tbs.totalDropped.Add(1)
+}
+
+// RecordStatusCode records a response status code into the current interval.
+//
+// Like RecordRequest, this method is lock-free once the code has been seen.
+func (tbs *TimeBucketStore) RecordStatusCode(code int) {
+	tbs.currentStatusCodes.add(code)
+}
+
 // CreateBucket creates a new bucket with the current metrics.
 //
 // This method is called by the background emitter (typically every second).
@@ -147,6 +177,7 @@ func (tbs *TimeBucketStore) CreateBucket(
 	intervalSuccesses := tbs.currentSuccesses.Swap(0)
 	intervalFailures := tbs.currentFailures.Swap(0)
 	tbs.currentBytes.Swap(0) // Reset but don't use for interval
+	intervalDropped := tbs.currentDropped.Swap(0)
 
 	// Calculate RPS for this interval
 	intervalDuration := now.Sub(tbs.lastBucketTime).Seconds()
@@ -178,6 +209,10 @@ func (tbs *TimeBucketStore) CreateBucket(
 		ActiveVUs:         activeVUs,
 		Phase:             phase,
 		IntervalErrorRate: intervalErrorRate,
+
+		TotalDroppedIterations:    tbs.totalDropped.Load(),
+		IntervalDroppedIterations: intervalDropped,
+		IntervalStatusCodes:       tbs.currentStatusCodes.drain(),
 	}
 
 	// Add to ring buffer
@@ -299,6 +334,9 @@ func (tbs *TimeBucketStore) Reset() {
 	tbs.currentSuccesses.Store(0)
 	tbs.currentFailures.Store(0)
 	tbs.currentBytes.Store(0)
+	tbs.currentDropped.Store(0)
+	tbs.totalDropped.Store(0)
+	tbs.currentStatusCodes.reset()
 }
 
 // LatencyPercentiles holds latency percentile values.
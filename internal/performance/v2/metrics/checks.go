@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// checkCounter holds pass/fail counters for a single check.
+type checkCounter struct {
+	passes atomic.Int64
+	fails  atomic.Int64
+}
+
+// checkStore tracks pass/fail counts for named checks (response assertions).
+type checkStore struct {
+	keyedStore[string, checkCounter]
+}
+
+func newCheckStore() *checkStore {
+	return &checkStore{newKeyedStore[string, checkCounter]()}
+}
+
+// record records a single check evaluation.
+func (cs *checkStore) record(name string, passed bool) {
+	c := cs.get(name)
+	if passed {
+		c.passes.Add(1)
+	} else {
+		c.fails.Add(1)
+	}
+}
+
+// stats returns the statistics for all checks, sorted by name.
+func (cs *checkStore) stats() []CheckStats {
+	var result []CheckStats
+	cs.each(func(name string, c *checkCounter) {
+		passes := c.passes.Load()
+		fails := c.fails.Load()
+
+		passRate := 0.0
+		if passes+fails > 0 {
+			passRate = float64(passes) / float64(passes+fails)
+		}
+
+		result = append(result, CheckStats{
+			Name:     name,
+			Passes:   passes,
+			Fails:    fails,
+			PassRate: passRate,
+		})
+	})
+	if result == nil {
+		return nil
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// CheckStats contains pass/fail statistics for a single check.
+type CheckStats struct {
+	Name     string  `json:"name"`
+	Passes   int64   `json:"passes"`
+	Fails    int64   `json:"fails"`
+	PassRate float64 `json:"passRate"`
+}
+
+// ChecksTotals sums the passes and fails across all checks.
+func ChecksTotals(checks []CheckStats) (passes, fails int64) {
+	for _, c := range checks {
+		passes += c.Passes
+		fails += c.Fails
+	}
+	return passes, fails
+}
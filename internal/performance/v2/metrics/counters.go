@@ -0,0 +1,29 @@
+package metrics
+
+import "sync/atomic"
+
+// counterStore tracks a set of named counters.
+type counterStore struct {
+	keyedStore[string, atomic.Int64]
+}
+
+func newCounterStore() *counterStore {
+	return &counterStore{newKeyedStore[string, atomic.Int64]()}
+}
+
+// add increments the named counter by delta, creating it if needed.
+func (cs *counterStore) add(name string, delta int64) {
+	cs.get(name).Add(delta)
+}
+
+// snapshot returns a copy of all counter values, or nil if there are none.
+func (cs *counterStore) snapshot() map[string]int64 {
+	var result map[string]int64
+	cs.each(func(name string, c *atomic.Int64) {
+		if result == nil {
+			result = make(map[string]int64)
+		}
+		result[name] = c.Load()
+	})
+	return result
+}
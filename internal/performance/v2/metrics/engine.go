@@ -32,6 +32,17 @@ type Engine struct {
 	requestHists   map[string]*hdrhistogram.Histogram
 	requestHistsMu sync.RWMutex
 
+	// Per-request-name failure counts
+	requestFailures *counterStore
+
+	// Requests per request name and status code
+	requestStatuses *requestStatusStore
+
+	// HTTP phase timings, overall and per request name
+	timings          *timingStore
+	requestTimings   map[string]*timingStore
+	requestTimingsMu sync.RWMutex
+
 	// Atomic counters for lock-free updates
 	totalRequests   atomic.Int64
 	successRequests atomic.Int64
@@ -41,6 +52,30 @@ type Engine struct {
 	// Active VU tracking
 	activeVUs atomic.Int32
 
+	// Check (assertion) pass/fail counters
+	checks *checkStore
+
+	// Run durations of request groups
+	groups *groupStore
+
+	// Iterations of weighted flows, per picked request or group
+	selections *counterStore
+
+	// Failed variable extractions, total and per variable name
+	extractionFailures       atomic.Int64
+	extractionFailuresByName *counterStore
+
+	// Responses per status code, and errors of requests without a response
+	statusCodes *statusStore
+	errors      *errorStore
+
+	// Requests that timed out, total and per request name
+	timedOutRequests       atomic.Int64
+	timedOutRequestsByName *counterStore
+
+	// Iterations arrival-rate executors could not start (no free VU)
+	droppedIterations atomic.Int64
+
 	// Time-bucketed metrics store
 	bucketStore *TimeBucketStore
 
@@ -49,16 +84,27 @@ type Engine struct {
 	phaseMu      sync.RWMutex
 	phaseHistory []PhaseChange
 
-	// Timing
+	// Timing (guarded by phaseMu, as Reset may restart the clock)
 	startTime time.Time
 
 	// Background emitter
 	emitterCtx    context.Context
 	emitterCancel context.CancelFunc
 	emitterWg     sync.WaitGroup
+	stopOnce      sync.Once
 
 	// Configuration
 	config EngineConfig
+
+	// Engine hierarchy: a child records into its parents as well, so each
+	// parent holds the merged view of all its children. Parents are fixed
+	// before the child records anything, so reads need no lock.
+	parents    []*Engine
+	children   []*Engine
+	childrenMu sync.RWMutex
+
+	// Listeners notified of each sample and bucket
+	listeners listenerList
 }
 
 // EngineConfig contains configuration for the metrics engine.
@@ -109,15 +155,27 @@ func NewEngineWithConfig(config EngineConfig) *Engine {
 	hist := hdrhistogram.New(config.HistogramMin, config.HistogramMax, config.HistogramSigFigs)
 
 	engine := &Engine{
-		latencyHist:   hist,
-		requestHists:  make(map[string]*hdrhistogram.Histogram),
-		bucketStore:   NewTimeBucketStore(config.MaxBuckets),
-		currentPhase:  PhaseInit,
-		phaseHistory:  make([]PhaseChange, 0),
-		startTime:     time.Now(),
-		emitterCtx:    ctx,
-		emitterCancel: cancel,
-		config:        config,
+		latencyHist:  hist,
+		requestHists: make(map[string]*hdrhistogram.Histogram),
+		bucketStore:  NewTimeBucketStore(config.MaxBuckets),
+		checks:       newCheckStore(),
+		groups:       newGroupStore(config),
+		statusCodes:  newStatusStore(),
+		errors:       newErrorStore(),
+
+		timings:                  newTimingStore(config),
+		requestTimings:           make(map[string]*timingStore),
+		requestFailures:          newCounterStore(),
+		requestStatuses:          newRequestStatusStore(),
+		extractionFailuresByName: newCounterStore(),
+		timedOutRequestsByName:   newCounterStore(),
+		selections:               newCounterStore(),
+		currentPhase:             PhaseInit,
+		phaseHistory:             make([]PhaseChange, 0),
+		startTime:                time.Now(),
+		emitterCtx:               ctx,
+		emitterCancel:            cancel,
+		config:                   config,
 	}
 
 	// Start background emitter
@@ -127,6 +185,35 @@ func NewEngineWithConfig(config EngineConfig) *Engine {
 	return engine
 }
 
+// NewChild creates a metrics engine for one part of the test, typically a
+// single scenario.
+//
+// The child keeps its own counters, histograms, time series and phase.
+// Everything recorded on the child is also recorded on e, so e provides
+// the merged view across all children. The phase of e follows its
+// children (see SetPhase), and its active VU count is their sum.
+//
+// The child runs its own background emitter and must be stopped
+// separately with Stop.
+func (e *Engine) NewChild() *Engine {
+	child := NewEngineWithConfig(e.config)
+	e.AddChild(child)
+	return child
+}
+
+// AddChild makes an existing engine a child of e, in addition to any
+// parents it already has. This is used to build aggregates that overlap
+// (e.g. all scenarios sharing a tag) without copying data.
+//
+// AddChild must be called before the child records anything.
+func (e *Engine) AddChild(child *Engine) {
+	child.parents = append(child.parents, e)
+
+	e.childrenMu.Lock()
+	e.children = append(e.children, child)
+	e.childrenMu.Unlock()
+}
+
 // RecordLatency records a request latency.
 //
 // This is the primary method for recording request timing.
@@ -157,6 +244,9 @@ func (e *Engine) RecordLatency(duration time.Duration, requestName string, succe
 	// Record in per-request histogram (if name provided)
 	if requestName != "" {
 		e.recordRequestHistogram(requestName, latencyMicros)
+		if !success {
+			e.requestFailures.add(requestName, 1)
+		}
 	}
 
 	// Update atomic counters
@@ -171,6 +261,10 @@ func (e *Engine) RecordLatency(duration time.Duration, requestName string, succe
 
 	// Record in bucket store for time-series
 	e.bucketStore.RecordRequest(success, bytes)
+
+	for _, parent := range e.parents {
+		parent.RecordLatency(duration, requestName, success, bytes)
+	}
 }
 
 // recordRequestHistogram records a latency in a per-request histogram.
@@ -188,14 +282,233 @@ func (e *Engine) recordRequestHistogram(name string, latencyMicros int64) {
 	hist.RecordValue(latencyMicros)
 }
 
+// RecordTimings records the HTTP phase timings of a request that received
+// a response. The request itself is recorded by RecordLatency.
+func (e *Engine) RecordTimings(requestName string, timings Timings) {
+	e.timings.record(timings)
+
+	if requestName != "" {
+		e.requestTimingsMu.RLock()
+		store, exists := e.requestTimings[requestName]
+		e.requestTimingsMu.RUnlock()
+
+		if !exists {
+			e.requestTimingsMu.Lock()
+			if store, exists = e.requestTimings[requestName]; !exists {
+				store = newTimingStore(e.config)
+				e.requestTimings[requestName] = store
+			}
+			e.requestTimingsMu.Unlock()
+		}
+		store.record(timings)
+	}
+
+	for _, parent := range e.parents {
+		parent.RecordTimings(requestName, timings)
+	}
+}
+
+// GetTimings returns the HTTP phase timing statistics, or nil if no
+// timings were recorded.
+func (e *Engine) GetTimings() *TimingStats {
+	return e.timings.stats()
+}
+
+// RecordCheck records the outcome of a single check (response assertion).
+//
+// Checks are reported per name with pass/fail counts and do not affect
+// the request success/failure counters.
+func (e *Engine) RecordCheck(name string, passed bool) {
+	e.checks.record(name, passed)
+
+	for _, parent := range e.parents {
+		parent.RecordCheck(name, passed)
+	}
+}
+
+// GetChecks returns pass/fail statistics for all recorded checks, sorted by name.
+func (e *Engine) GetChecks() []CheckStats {
+	return e.checks.stats()
+}
+
+// RecordGroupDuration records the duration of one run of a request group,
+// including think time between its requests.
+//
+// Group durations are reported separately and do not affect the request
+// counters.
+func (e *Engine) RecordGroupDuration(group string, duration time.Duration) {
+	e.groups.record(group, duration)
+
+	for _, parent := range e.parents {
+		parent.RecordGroupDuration(group, duration)
+	}
+}
+
+// GetGroups returns duration statistics for all request groups, sorted by name.
+func (e *Engine) GetGroups() []GroupStats {
+	return e.groups.stats()
+}
+
+// RecordSelection records that an iteration of a weighted flow picked the
+// named request or group.
+func (e *Engine) RecordSelection(step string) {
+	e.selections.add(step, 1)
+
+	for _, parent := range e.parents {
+		parent.RecordSelection(step)
+	}
+}
+
+// GetSelections returns how many iterations of weighted flows picked each
+// request or group, or nil if there are none.
+func (e *Engine) GetSelections() map[string]int64 {
+	return e.selections.snapshot()
+}
+
+// RecordExtractionFailure records a variable extraction that produced no value.
+//
+// Extraction failures are counted separately and do not affect the
+// request success/failure counters.
+func (e *Engine) RecordExtractionFailure(variable string) {
+	e.extractionFailures.Add(1)
+	e.extractionFailuresByName.add(variable, 1)
+
+	for _, parent := range e.parents {
+		parent.RecordExtractionFailure(variable)
+	}
+}
+
+// GetExtractionFailures returns the total number of failed extractions.
+func (e *Engine) GetExtractionFailures() int64 {
+	return e.extractionFailures.Load()
+}
+
+// RecordStatusCode records the HTTP status code of a response.
+//
+// Status codes are counted in addition to RecordLatency, overall and per
+// time bucket.
+func (e *Engine) RecordStatusCode(code int) {
+	e.statusCodes.add(code)
+	e.bucketStore.RecordStatusCode(code)
+
+	for _, parent := range e.parents {
+		parent.RecordStatusCode(code)
+	}
+}
+
+// RecordRequestStatus counts a request by name and status code, using
+// status 0 for requests that received no response.
+//
+// The request itself is recorded by RecordLatency; this breakdown is kept
+// for exporters that label requests by status.
+func (e *Engine) RecordRequestStatus(requestName string, status int, success bool) {
+	e.requestStatuses.add(requestName, status, success)
+
+	for _, parent := range e.parents {
+		parent.RecordRequestStatus(requestName, status, success)
+	}
+}
+
+// GetRequestStatuses returns the request counts per request name and
+// status code, ordered by name and status.
+func (e *Engine) GetRequestStatuses() []RequestStatusCount {
+	return e.requestStatuses.counts()
+}
+
+// AddListener registers a listener for the samples recorded on e and its
+// children, and for the time buckets emitted by e.
+func (e *Engine) AddListener(l Listener) {
+	e.listeners.add(l)
+}
+
+// RecordSample passes the result of one request to the listeners.
+//
+// The request itself is recorded by RecordLatency; samples are not
+// aggregated.
+func (e *Engine) RecordSample(sample Sample) {
+	for _, l := range e.listeners.get() {
+		l.AddSample(sample)
+	}
+
+	for _, parent := range e.parents {
+		parent.RecordSample(sample)
+	}
+}
+
+// RecordError records why a request failed, by category and message.
+//
+// The request itself is recorded as failed by RecordLatency. Only the
+// most frequent messages are kept; every error is counted by category.
+func (e *Engine) RecordError(category ErrorCategory, message string) {
+	e.errors.record(category, message)
+
+	for _, parent := range e.parents {
+		parent.RecordError(category, message)
+	}
+}
+
+// RecordTimeout records a request that failed because it timed out.
+//
+// The request itself is recorded as failed by RecordLatency; timeouts are
+// counted separately so they can be told apart from other failures.
+func (e *Engine) RecordTimeout(requestName string) {
+	e.timedOutRequests.Add(1)
+	if requestName != "" {
+		e.timedOutRequestsByName.add(requestName, 1)
+	}
+
+	for _, parent := range e.parents {
+		parent.RecordTimeout(requestName)
+	}
+}
+
+// GetTimedOutRequests returns the total number of requests that timed out.
+func (e *Engine) GetTimedOutRequests() int64 {
+	return e.timedOutRequests.Load()
+}
+
+// RecordDroppedIteration records an iteration that was due to start but
+// could not, because every VU was busy and the executor was at its VU limit.
+//
+// Dropped iterations are tracked as a time series alongside requests.
+func (e *Engine) RecordDroppedIteration() {
+	e.droppedIterations.Add(1)
+	e.bucketStore.RecordDroppedIteration()
+
+	for _, parent := range e.parents {
+		parent.RecordDroppedIteration()
+	}
+}
+
+// GetDroppedIterations returns the total number of dropped iterations.
+func (e *Engine) GetDroppedIterations() int64 {
+	return e.droppedIterations.Load()
+}
+
 // SetPhase updates the current test phase.
 //
 // This is called by executors to mark phase transitions.
 // Phase information is included in time-series buckets.
+//
+// On a child engine, each parent's phase is re-derived from all of its
+// children afterwards (see mergePhases).
 func (e *Engine) SetPhase(phase Phase) {
+	e.setPhase(phase)
+
+	for _, parent := range e.parents {
+		parent.updatePhaseFromChildren()
+	}
+}
+
+// setPhase records a phase transition on this engine only.
+func (e *Engine) setPhase(phase Phase) {
 	e.phaseMu.Lock()
 	defer e.phaseMu.Unlock()
+	e.setPhaseLocked(phase)
+}
 
+// setPhaseLocked records a phase transition. Called with phaseMu held.
+func (e *Engine) setPhaseLocked(phase Phase) {
 	if e.currentPhase == phase {
 		return // No change
 	}
@@ -208,6 +521,60 @@ func (e *Engine) SetPhase(phase Phase) {
 	})
 }
 
+// updatePhaseFromChildren sets the phase to the merged phase of all children.
+//
+// phaseMu is held while the children's phases are read and merged, so
+// children changing phase at the same time cannot apply stale merges out
+// of order.
+func (e *Engine) updatePhaseFromChildren() {
+	e.phaseMu.Lock()
+	e.childrenMu.RLock()
+	phases := make([]Phase, len(e.children))
+	for i, child := range e.children {
+		phases[i] = child.GetPhase()
+	}
+	e.childrenMu.RUnlock()
+
+	if len(phases) == 0 {
+		e.phaseMu.Unlock()
+		return
+	}
+
+	e.setPhaseLocked(mergePhases(phases))
+	e.phaseMu.Unlock()
+
+	for _, parent := range e.parents {
+		parent.updatePhaseFromChildren()
+	}
+}
+
+// mergePhases combines the phases of concurrently running children.
+//
+// The result is PhaseDone only once every child is done. Otherwise it is
+// the most significant phase among the children still running, so that
+// e.g. one scenario ramping up while another holds steady reports ramp-up.
+func mergePhases(phases []Phase) Phase {
+	priority := map[Phase]int{
+		PhaseInit:     1,
+		PhaseCooldown: 2,
+		PhaseWarmup:   3,
+		PhaseRampDown: 4,
+		PhaseSteady:   5,
+		PhaseRampUp:   6,
+	}
+
+	merged := PhaseDone
+	for _, phase := range phases {
+		if phase == PhaseDone {
+			continue
+		}
+		if merged == PhaseDone || priority[phase] > priority[merged] {
+			merged = phase
+		}
+	}
+	return merged
+}
+
 // GetPhase returns the current test phase.
 func (e *Engine) GetPhase() Phase {
 	e.phaseMu.RLock()
@@ -216,8 +583,25 @@ func (e *Engine) GetPhase() Phase {
 }
 
 // SetActiveVUs updates the active VU count.
+//
+// On a child engine, the change is also applied to each parent's count.
 func (e *Engine) SetActiveVUs(count int) {
-	e.activeVUs.Store(int32(count))
+	old := e.activeVUs.Swap(int32(count))
+
+	if old != int32(count) {
+		for _, parent := range e.parents {
+			parent.addActiveVUs(int32(count) - old)
+		}
+	}
+}
+
+// addActiveVUs adjusts the active VU count by delta.
+func (e *Engine) addActiveVUs(delta int32) {
+	e.activeVUs.Add(delta)
+
+	for _, parent := range e.parents {
+		parent.addActiveVUs(delta)
+	}
 }
 
 // GetActiveVUs returns the current active VU count.
@@ -258,10 +642,14 @@ func (e *Engine) emitBucket() {
 	totalBytes := e.totalBytes.Load()
 
 	// Create bucket
-	e.bucketStore.CreateBucket(
+	bucket := e.bucketStore.CreateBucket(
 		totalRequests, totalSuccesses, totalFailures, totalBytes,
 		latencies, activeVUs, phase,
 	)
+
+	for _, l := range e.listeners.get() {
+		l.AddBucket(bucket)
+	}
 }
 
 // GetLatencyPercentiles returns current latency percentiles.
@@ -295,7 +683,11 @@ func (e *Engine) GetSnapshot() *Snapshot {
 	}
 	e.latencyHistMu.Unlock()
 
-	elapsed := time.Since(e.startTime)
+	e.phaseMu.RLock()
+	startTime := e.startTime
+	e.phaseMu.RUnlock()
+
+	elapsed := time.Since(startTime)
 	totalReqs := e.totalRequests.Load()
 	failedReqs := e.failedRequests.Load()
 
@@ -330,10 +722,25 @@ func (e *Engine) GetSnapshot() *Snapshot {
 		SteadyStateRPS:  steadyRPS,
 		ErrorRate:       errorRate,
 		ActiveVUs:       e.GetActiveVUs(),
-		CurrentPhase:    e.GetPhase(),
-		Elapsed:         elapsed,
-		StartTime:       e.startTime,
-		Timestamp:       time.Now(),
+		Checks:          e.checks.stats(),
+		Groups:          e.groups.stats(),
+		Selections:      e.selections.snapshot(),
+		Timings:         e.timings.stats(),
+
+		StatusCodes:     e.statusCodes.snapshot(),
+		ErrorCategories: e.errors.categoryCounts(),
+		TopErrors:       e.errors.top(topErrorCount),
+
+		ExtractionFailures:       e.extractionFailures.Load(),
+		ExtractionFailuresByName: e.extractionFailuresByName.snapshot(),
+		TimedOutRequests:         e.timedOutRequests.Load(),
+		TimedOutRequestsByName:   e.timedOutRequestsByName.snapshot(),
+		DroppedIterations:        e.droppedIterations.Load(),
+
+		CurrentPhase: e.GetPhase(),
+		Elapsed:      elapsed,
+		StartTime:    startTime,
+		Timestamp:    time.Now(),
 	}
 }
 
@@ -342,6 +749,12 @@ func (e *Engine) GetTimeSeries() []*TimeBucket {
 	return e.bucketStore.GetBuckets()
 }
 
+// GetLatestBucket returns the most recent time-series bucket, or nil if
+// none has been emitted yet.
+func (e *Engine) GetLatestBucket() *TimeBucket {
+	return e.bucketStore.GetLatestBucket()
+}
+
 // GetPhaseHistory returns the history of phase changes.
 func (e *Engine) GetPhaseHistory() []PhaseChange {
 	e.phaseMu.RLock()
@@ -376,16 +789,124 @@ func (e *Engine) GetRequestStats() map[string]LatencyStats {
 	return result
 }
 
+// Histogram is a cumulative latency histogram, as used by Prometheus.
+type Histogram struct {
+	// Bounds are the upper bounds of the buckets, in increasing order
+	Bounds []time.Duration
+
+	// Counts are the number of values at or below each bound
+	Counts []int64
+
+	Count int64
+	Sum   time.Duration // Estimated from the mean of the HDR histogram
+}
+
+// GetRequestHistograms returns a cumulative histogram of the latencies of
+// each request name, with the given bucket bounds.
+//
+// Counts are exact to the precision of the HDR histograms.
+func (e *Engine) GetRequestHistograms(bounds []time.Duration) map[string]Histogram {
+	e.requestHistsMu.Lock()
+	defer e.requestHistsMu.Unlock()
+
+	result := make(map[string]Histogram, len(e.requestHists))
+	for name, hist := range e.requestHists {
+		result[name] = cumulativeHistogram(hist, bounds)
+	}
+	return result
+}
+
+// cumulativeHistogram buckets the values of an HDR histogram.
+func cumulativeHistogram(hist *hdrhistogram.Histogram, bounds []time.Duration) Histogram {
+	h := Histogram{
+		Bounds: bounds,
+		Counts: make([]int64, len(bounds)),
+		Count:  hist.TotalCount(),
+		Sum:    time.Duration(hist.Mean()*float64(hist.TotalCount())) * time.Microsecond,
+	}
+
+	for _, bar := range hist.Distribution() {
+		if bar.Count == 0 {
+			continue
+		}
+		for i, bound := range bounds {
+			if bar.To <= bound.Microseconds() {
+				h.Counts[i] += bar.Count
+			}
+		}
+	}
+	return h
+}
+
+// GetRequestSnapshot returns a snapshot restricted to requests with the
+// given name, or nil if no such request was recorded.
+//
+// Only request counts, failures, timeouts, latencies and phase timings are
+// tracked per request; the remaining fields are zero.
+func (e *Engine) GetRequestSnapshot(name string) *Snapshot {
+	stats, ok := e.GetRequestStats()[name]
+	if !ok {
+		return nil
+	}
+
+	failures := e.requestFailures.snapshot()[name]
+
+	var timings *TimingStats
+	e.requestTimingsMu.RLock()
+	if store, exists := e.requestTimings[name]; exists {
+		timings = store.stats()
+	}
+	e.requestTimingsMu.RUnlock()
+
+	e.phaseMu.RLock()
+	startTime := e.startTime
+	e.phaseMu.RUnlock()
+	elapsed := time.Since(startTime)
+
+	rps := 0.0
+	if elapsed.Seconds() > 0 {
+		rps = float64(stats.Count) / elapsed.Seconds()
+	}
+
+	errorRate := 0.0
+	if stats.Count > 0 {
+		errorRate = float64(failures) / float64(stats.Count)
+	}
+
+	return &Snapshot{
+		TotalRequests:   stats.Count,
+		SuccessRequests: stats.Count - failures,
+		FailedRequests:  failures,
+		Latency:         stats,
+		Timings:         timings,
+		RPS:             rps,
+		ErrorRate:       errorRate,
+		CurrentPhase:    e.GetPhase(),
+		Elapsed:         elapsed,
+		StartTime:       startTime,
+		Timestamp:       time.Now(),
+
+		TimedOutRequests: e.timedOutRequestsByName.snapshot()[name],
+	}
+}
+
 // Stop stops the metrics engine and emits a final bucket.
+//
+// Calling Stop more than once has no further effect.
 func (e *Engine) Stop() {
-	e.emitterCancel()
-	e.emitterWg.Wait()
+	e.stopOnce.Do(func() {
+		e.emitterCancel()
+		e.emitterWg.Wait()
 
-	// Emit final bucket
-	e.emitBucket()
+		// Emit final bucket
+		e.emitBucket()
+	})
 }
 
 // Reset resets all metrics to initial state.
+//
+// On a child engine, values already recorded on the parents are kept;
+// only the child's own view starts over.
 func (e *Engine) Reset() {
 	e.latencyHistMu.Lock()
 	e.latencyHist.Reset()
@@ -394,37 +915,87 @@ func (e *Engine) Reset() {
 	e.requestHistsMu.Lock()
 	e.requestHists = make(map[string]*hdrhistogram.Histogram)
 	e.requestHistsMu.Unlock()
+	e.requestFailures.reset()
+	e.requestStatuses.reset()
+
+	e.timings.reset()
+	e.requestTimingsMu.Lock()
+	e.requestTimings = make(map[string]*timingStore)
+	e.requestTimingsMu.Unlock()
 
 	e.totalRequests.Store(0)
 	e.successRequests.Store(0)
 	e.failedRequests.Store(0)
 	e.totalBytes.Store(0)
-	e.activeVUs.Store(0)
+	e.SetActiveVUs(0)
+	e.checks.reset()
+	e.groups.reset()
+	e.selections.reset()
+	e.statusCodes.reset()
+	e.errors.reset()
+	e.extractionFailures.Store(0)
+	e.extractionFailuresByName.reset()
+	e.timedOutRequests.Store(0)
+	e.timedOutRequestsByName.reset()
+	e.droppedIterations.Store(0)
 
 	e.phaseMu.Lock()
 	e.currentPhase = PhaseInit
 	e.phaseHistory = make([]PhaseChange, 0)
+	e.startTime = time.Now()
 	e.phaseMu.Unlock()
 
 	e.bucketStore.Reset()
-	e.startTime = time.Now()
+
+	for _, parent := range e.parents {
+		parent.updatePhaseFromChildren()
+	}
 }
 
 // Snapshot contains a point-in-time view of all metrics.
 type Snapshot struct {
-	TotalRequests   int64         `json:"totalRequests"`
-	SuccessRequests int64         `json:"successRequests"`
-	FailedRequests  int64         `json:"failedRequests"`
-	TotalBytes      int64         `json:"totalBytes"`
-	Latency         LatencyStats  `json:"latency"`
-	RPS             float64       `json:"rps"`
-	SteadyStateRPS  float64       `json:"steadyStateRps"`
-	ErrorRate       float64       `json:"errorRate"`
-	ActiveVUs       int           `json:"activeVUs"`
-	CurrentPhase    Phase         `json:"currentPhase"`
-	Elapsed         time.Duration `json:"elapsed"`
-	StartTime       time.Time     `json:"startTime"`
-	Timestamp       time.Time     `json:"timestamp"`
+	TotalRequests   int64        `json:"totalRequests"`
+	SuccessRequests int64        `json:"successRequests"`
+	FailedRequests  int64        `json:"failedRequests"`
+	TotalBytes      int64        `json:"totalBytes"`
+	Latency         LatencyStats `json:"latency"`
+	Timings         *TimingStats `json:"timings,omitempty"`
+	RPS             float64      `json:"rps"`
+	SteadyStateRPS  float64      `json:"steadyStateRps"`
+	ErrorRate       float64      `json:"errorRate"`
+	ActiveVUs       int          `json:"activeVUs"`
+	Checks          []CheckStats `json:"checks,omitempty"`
+
+	// Groups are the run durations of request groups
+	Groups []GroupStats `json:"groups,omitempty"`
+
+	// Selections counts the iterations of weighted flows per picked
+	// request or group
+	Selections map[string]int64 `json:"selections,omitempty"`
+
+	// ExtractionFailures counts extractions that produced no value
+	ExtractionFailures       int64            `json:"extractionFailures,omitempty"`
+	ExtractionFailuresByName map[string]int64 `json:"extractionFailuresByName,omitempty"`
+
+	// StatusCodes counts responses per HTTP status code
+	StatusCodes map[int]int64 `json:"statusCodes,omitempty"`
+
+	// ErrorCategories counts requests that failed without a complete
+	// response, per category; TopErrors are the most frequent messages
+	ErrorCategories map[ErrorCategory]int64 `json:"errorCategories,omitempty"`
+	TopErrors       []ErrorStats            `json:"topErrors,omitempty"`
+
+	// TimedOutRequests counts failed requests that timed out
+	TimedOutRequests       int64            `json:"timedOutRequests,omitempty"`
+	TimedOutRequestsByName map[string]int64 `json:"timedOutRequestsByName,omitempty"`
+
+	// DroppedIterations counts arrival-rate iterations that could not start
+	DroppedIterations int64 `json:"droppedIterations,omitempty"`
+
+	CurrentPhase Phase         `json:"currentPhase"`
+	Elapsed      time.Duration `json:"elapsed"`
+	StartTime    time.Time     `json:"startTime"`
+	Timestamp    time.Time     `json:"timestamp"`
 }
 
 // LatencyStats contains latency statistics.
@@ -1,6 +1,9 @@
 package metrics
 
 import (
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -169,6 +172,761 @@ func TestEngine_RequestStats(t *testing.T) {
 	}
 }
 
+func TestEngine_RecordCheck(t *testing.T) {
+	engine := NewEngine()
+	defer engine.Stop()
+
+	if checks := engine.GetChecks(); checks != nil {
+		t.Errorf("GetChecks() = %v, want nil before any checks", checks)
+	}
+
+	engine.RecordCheck("status is 200", true)
+	engine.RecordCheck("status is 200", true)
+	engine.RecordCheck("status is 200", false)
+	engine.RecordCheck("body has id", true)
+
+	checks := engine.GetChecks()
+	if len(checks) != 2 {
+		t.Fatalf("GetChecks() length = %d, want 2", len(checks))
+	}
+
+	// Sorted by name
+	if checks[0].Name != "body has id" || checks[1].Name != "status is 200" {
+		t.Errorf("GetChecks() order = %q, %q", checks[0].Name, checks[1].Name)
+	}
+
+	status := checks[1]
+	if status.Passes != 2 || status.Fails != 1 {
+		t.Errorf("status check = %d passes, %d fails, want 2, 1", status.Passes, status.Fails)
+	}
+	if status.PassRate < 0.66 || status.PassRate > 0.67 {
+		t.Errorf("status check PassRate = %f, want ~0.667", status.PassRate)
+	}
+
+	passes, fails := ChecksTotals(checks)
+	if passes != 3 || fails != 1 {
+		t.Errorf("ChecksTotals() = %d, %d, want 3, 1", passes, fails)
+	}
+
+	snapshot := engine.GetSnapshot()
+	if len(snapshot.Checks) != 2 {
+		t.Errorf("Snapshot.Checks length = %d, want 2", len(snapshot.Checks))
+	}
+
+	engine.Reset()
+	if checks := engine.GetChecks(); checks != nil {
+		t.Errorf("GetChecks() after Reset = %v, want nil", checks)
+	}
+}
+
+func TestEngine_RecordExtractionFailure(t *testing.T) {
+	engine := NewEngine()
+	defer engine.Stop()
+
+	engine.RecordExtractionFailure("token")
+	engine.RecordExtractionFailure("token")
+	engine.RecordExtractionFailure("userId")
+
+	if got := engine.GetExtractionFailures(); got != 3 {
+		t.Errorf("GetExtractionFailures() = %d, want 3", got)
+	}
+
+	snapshot := engine.GetSnapshot()
+	if snapshot.ExtractionFailures != 3 {
+		t.Errorf("Snapshot.ExtractionFailures = %d, want 3", snapshot.ExtractionFailures)
+	}
+	if snapshot.ExtractionFailuresByName["token"] != 2 {
+		t.Errorf("ExtractionFailuresByName[token] = %d, want 2", snapshot.ExtractionFailuresByName["token"])
+	}
+	if snapshot.ExtractionFailuresByName["userId"] != 1 {
+		t.Errorf("ExtractionFailuresByName[userId] = %d, want 1", snapshot.ExtractionFailuresByName["userId"])
+	}
+
+	// Extraction failures do not affect request counters
+	if snapshot.FailedRequests != 0 {
+		t.Errorf("FailedRequests = %d, want 0", snapshot.FailedRequests)
+	}
+
+	engine.Reset()
+	if got := engine.GetExtractionFailures(); got != 0 {
+		t.Errorf("GetExtractionFailures() after Reset = %d, want 0", got)
+	}
+}
+
+func TestEngine_RecordTimeout(t *testing.T) {
+	engine := NewEngine()
+	defer engine.Stop()
+
+	engine.RecordLatency(time.Second, "slow", false, 0)
+	engine.RecordTimeout("slow")
+	engine.RecordLatency(time.Second, "slow", false, 0)
+	engine.RecordTimeout("slow")
+	engine.RecordLatency(10*time.Millisecond, "fast", false, 0)
+
+	if got := engine.GetTimedOutRequests(); got != 2 {
+		t.Errorf("GetTimedOutRequests() = %d, want 2", got)
+	}
+
+	snapshot := engine.GetSnapshot()
+	if snapshot.TimedOutRequests != 2 {
+		t.Errorf("Snapshot.TimedOutRequests = %d, want 2", snapshot.TimedOutRequests)
+	}
+	if snapshot.TimedOutRequestsByName["slow"] != 2 {
+		t.Errorf("TimedOutRequestsByName[slow] = %d, want 2", snapshot.TimedOutRequestsByName["slow"])
+	}
+
+	// Timeouts are a subset of the failed requests
+	if snapshot.FailedRequests != 3 {
+		t.Errorf("FailedRequests = %d, want 3", snapshot.FailedRequests)
+	}
+
+	if got := engine.GetRequestSnapshot("slow").TimedOutRequests; got != 2 {
+		t.Errorf("GetRequestSnapshot(slow).TimedOutRequests = %d, want 2", got)
+	}
+	if got := engine.GetRequestSnapshot("fast").TimedOutRequests; got != 0 {
+		t.Errorf("GetRequestSnapshot(fast).TimedOutRequests = %d, want 0", got)
+	}
+
+	engine.Reset()
+	if got := engine.GetTimedOutRequests(); got != 0 {
+		t.Errorf("GetTimedOutRequests() after Reset = %d, want 0", got)
+	}
+}
+
+func TestEngine_RecordStatusCode(t *testing.T) {
+	parent := NewEngine()
+	defer parent.Stop()
+	engine := parent.NewChild()
+	defer engine.Stop()
+
+	if engine.GetSnapshot().StatusCodes != nil {
+		t.Error("Snapshot.StatusCodes should be nil before any responses")
+	}
+
+	for i := 0; i < 3; i++ {
+		engine.RecordStatusCode(200)
+	}
+	engine.RecordStatusCode(503)
+
+	codes := engine.GetSnapshot().StatusCodes
+	if codes[200] != 3 || codes[503] != 1 || len(codes) != 2 {
+		t.Errorf("Snapshot.StatusCodes = %v, want 200:3 503:1", codes)
+	}
+	if got := parent.GetSnapshot().StatusCodes[200]; got != 3 {
+		t.Errorf("parent StatusCodes[200] = %d, want 3", got)
+	}
+
+	sorted := SortStatusCodes(codes)
+	if len(sorted) != 2 || sorted[0].Code != 200 || sorted[1].Code != 503 {
+		t.Errorf("SortStatusCodes() = %v, want 200 then 503", sorted)
+	}
+
+	engine.Reset()
+	if engine.GetSnapshot().StatusCodes != nil {
+		t.Error("Snapshot.StatusCodes after Reset should be nil")
+	}
+}
+
+func TestEngine_RecordRequestStatus(t *testing.T) {
+	parent := NewEngine()
+	defer parent.Stop()
+	engine := parent.NewChild()
+	defer engine.Stop()
+
+	engine.RecordRequestStatus("login", 200, true)
+	engine.RecordRequestStatus("login", 200, true)
+	engine.RecordRequestStatus("login", 500, false)
+	engine.RecordRequestStatus("home", 0, false)
+
+	want := []RequestStatusCount{
+		{Name: "home", Status: 0, Requests: 1, Failures: 1},
+		{Name: "login", Status: 200, Requests: 2, Failures: 0},
+		{Name: "login", Status: 500, Requests: 1, Failures: 1},
+	}
+	got := engine.GetRequestStatuses()
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("GetRequestStatuses() = %v, want %v", got, want)
+	}
+	if got := parent.GetRequestStatuses(); len(got) != 3 {
+		t.Errorf("parent GetRequestStatuses() = %v, want 3 entries", got)
+	}
+
+	engine.Reset()
+	if got := engine.GetRequestStatuses(); len(got) != 0 {
+		t.Errorf("GetRequestStatuses() after Reset = %v, want none", got)
+	}
+}
+
+func TestEngine_GetRequestHistograms(t *testing.T) {
+	engine := NewEngine()
+	defer engine.Stop()
+
+	for _, d := range []time.Duration{2 * time.Millisecond, 8 * time.Millisecond, 8 * time.Millisecond, 200 * time.Millisecond} {
+		engine.RecordLatency(d, "api", true, 0)
+	}
+
+	bounds := []time.Duration{5 * time.Millisecond, 10 * time.Millisecond, 100 * time.Millisecond, time.Second}
+	hists := engine.GetRequestHistograms(bounds)
+
+	h, ok := hists["api"]
+	if !ok {
+		t.Fatalf("GetRequestHistograms() = %v, want api", hists)
+	}
+	if fmt.Sprint(h.Counts) != "[1 3 3 4]" {
+		t.Errorf("Counts = %v, want [1 3 3 4]", h.Counts)
+	}
+	if h.Count != 4 {
+		t.Errorf("Count = %d, want 4", h.Count)
+	}
+	if h.Sum < 217*time.Millisecond || h.Sum > 219*time.Millisecond {
+		t.Errorf("Sum = %v, want ~218ms", h.Sum)
+	}
+}
+
+// recordingListener records the samples and buckets it receives.
+type recordingListener struct {
+	mu      sync.Mutex
+	samples []Sample
+	buckets []*TimeBucket
+}
+
+func (l *recordingListener) AddSample(s Sample) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.samples = append(l.samples, s)
+}
+
+func (l *recordingListener) AddBucket(b *TimeBucket) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.buckets = append(l.buckets, b)
+}
+
+func TestEngine_AddListener(t *testing.T) {
+	parent := NewEngine()
+	engine := parent.NewChild()
+	defer engine.Stop()
+
+	listener := &recordingListener{}
+	parent.AddListener(listener)
+
+	engine.RecordSample(Sample{Scenario: "api", Name: "get", Status: 200, Success: true})
+	engine.RecordSample(Sample{Scenario: "api", Name: "get", Status: 0})
+
+	// Stopping emits a final bucket
+	parent.RecordLatency(10*time.Millisecond, "get", true, 0)
+	parent.Stop()
+
+	listener.mu.Lock()
+	defer listener.mu.Unlock()
+
+	if len(listener.samples) != 2 || listener.samples[0].Scenario != "api" || listener.samples[1].Success {
+		t.Errorf("samples = %+v, want the two samples recorded on the child", listener.samples)
+	}
+	if len(listener.buckets) == 0 {
+		t.Fatal("no bucket passed to the listener")
+	}
+	if last := listener.buckets[len(listener.buckets)-1]; last.TotalRequests != 1 {
+		t.Errorf("last bucket TotalRequests = %d, want 1", last.TotalRequests)
+	}
+}
+
+func TestTimeBucketStore_StatusCodes(t *testing.T) {
+	store := NewTimeBucketStore(10)
+	store.RecordStatusCode(200)
+	store.RecordStatusCode(200)
+	store.RecordStatusCode(503)
+
+	bucket := store.CreateBucket(3, 2, 1, 0, LatencyPercentiles{}, 1, PhaseSteady)
+	if bucket.IntervalStatusCodes[200] != 2 || bucket.IntervalStatusCodes[503] != 1 {
+		t.Errorf("IntervalStatusCodes = %v, want 200:2 503:1", bucket.IntervalStatusCodes)
+	}
+
+	// Each bucket only holds the codes seen in its own interval
+	store.RecordStatusCode(200)
+	bucket = store.CreateBucket(4, 3, 1, 0, LatencyPercentiles{}, 1, PhaseSteady)
+	if len(bucket.IntervalStatusCodes) != 1 || bucket.IntervalStatusCodes[200] != 1 {
+		t.Errorf("second IntervalStatusCodes = %v, want 200:1", bucket.IntervalStatusCodes)
+	}
+
+	bucket = store.CreateBucket(4, 3, 1, 0, LatencyPercentiles{}, 1, PhaseSteady)
+	if bucket.IntervalStatusCodes != nil {
+		t.Errorf("empty interval IntervalStatusCodes = %v, want nil", bucket.IntervalStatusCodes)
+	}
+}
+
+func TestKeyedStore_ConcurrentGet(t *testing.T) {
+	store := newKeyedStore[string, atomic.Int64]()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				store.get(fmt.Sprintf("key-%d", j%4)).Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	keys := 0
+	store.each(func(key string, v *atomic.Int64) {
+		keys++
+		if n := v.Load(); n != 2000 {
+			t.Errorf("%s = %d, want 2000", key, n)
+		}
+	})
+	if keys != 4 {
+		t.Errorf("store has %d keys, want 4", keys)
+	}
+
+	store.reset()
+	store.each(func(key string, _ *atomic.Int64) {
+		t.Errorf("key %s left after reset", key)
+	})
+}
+
+func TestEngine_RecordError(t *testing.T) {
+	parent := NewEngine()
+	defer parent.Stop()
+	engine := parent.NewChild()
+	defer engine.Stop()
+
+	for i := 0; i < 3; i++ {
+		engine.RecordError(ErrorConnectionRefused, "connect: connection refused")
+	}
+	engine.RecordError(ErrorTimeout, "timed out after 1s")
+	engine.RecordError(ErrorTimeout, "timed out after 2s")
+
+	snapshot := engine.GetSnapshot()
+	if snapshot.ErrorCategories[ErrorConnectionRefused] != 3 || snapshot.ErrorCategories[ErrorTimeout] != 2 {
+		t.Errorf("ErrorCategories = %v, want connection_refused:3 timeout:2", snapshot.ErrorCategories)
+	}
+
+	want := []ErrorStats{
+		{Category: ErrorConnectionRefused, Message: "connect: connection refused", Count: 3},
+		{Category: ErrorTimeout, Message: "timed out after 1s", Count: 1},
+		{Category: ErrorTimeout, Message: "timed out after 2s", Count: 1},
+	}
+	if len(snapshot.TopErrors) != len(want) {
+		t.Fatalf("TopErrors = %v, want %v", snapshot.TopErrors, want)
+	}
+	for i := range want {
+		if snapshot.TopErrors[i] != want[i] {
+			t.Errorf("TopErrors[%d] = %v, want %v", i, snapshot.TopErrors[i], want[i])
+		}
+	}
+
+	if got := parent.GetSnapshot().ErrorCategories[ErrorTimeout]; got != 2 {
+		t.Errorf("parent ErrorCategories[timeout] = %d, want 2", got)
+	}
+
+	categories := SortErrorCategories(snapshot.ErrorCategories)
+	if len(categories) != 2 || categories[0].Category != ErrorConnectionRefused {
+		t.Errorf("SortErrorCategories() = %v, want connection_refused first", categories)
+	}
+
+	engine.Reset()
+	snapshot = engine.GetSnapshot()
+	if snapshot.ErrorCategories != nil || snapshot.TopErrors != nil {
+		t.Error("errors should be cleared after Reset")
+	}
+}
+
+func TestEngine_RecordError_Bounded(t *testing.T) {
+	engine := NewEngine()
+	defer engine.Stop()
+
+	for i := 0; i < maxErrorMessages+50; i++ {
+		engine.RecordError(ErrorOther, fmt.Sprintf("error %d", i))
+	}
+	engine.RecordError(ErrorOther, "error 0")
+
+	snapshot := engine.GetSnapshot()
+	if got := snapshot.ErrorCategories[ErrorOther]; got != maxErrorMessages+51 {
+		t.Errorf("ErrorCategories[other] = %d, want every error counted", got)
+	}
+	if len(snapshot.TopErrors) != topErrorCount {
+		t.Errorf("len(TopErrors) = %d, want %d", len(snapshot.TopErrors), topErrorCount)
+	}
+	if snapshot.TopErrors[0].Message != "error 0" || snapshot.TopErrors[0].Count != 2 {
+		t.Errorf("TopErrors[0] = %v, want error 0 twice", snapshot.TopErrors[0])
+	}
+	if got := len(engine.errors.messages); got != maxErrorMessages {
+		t.Errorf("tracked messages = %d, want %d", got, maxErrorMessages)
+	}
+}
+
+func TestEngine_RecordGroupDuration(t *testing.T) {
+	parent := NewEngine()
+	defer parent.Stop()
+	engine := parent.NewChild()
+	defer engine.Stop()
+
+	if engine.GetGroups() != nil {
+		t.Error("GetGroups() should be nil before any groups run")
+	}
+
+	engine.RecordGroupDuration("checkout", 300*time.Millisecond)
+	engine.RecordGroupDuration("checkout", 500*time.Millisecond)
+	engine.RecordGroupDuration("browse", 100*time.Millisecond)
+
+	groups := engine.GetSnapshot().Groups
+	if len(groups) != 2 || groups[0].Name != "browse" || groups[1].Name != "checkout" {
+		t.Fatalf("Snapshot.Groups = %v, want browse and checkout sorted by name", groups)
+	}
+	checkout := groups[1].Duration
+	if checkout.Count != 2 || checkout.Min < 299*time.Millisecond || checkout.Max < 499*time.Millisecond {
+		t.Errorf("checkout = %d runs, %v..%v, want 2 runs, 300ms..500ms", checkout.Count, checkout.Min, checkout.Max)
+	}
+
+	// Group durations don't count as requests
+	if got := engine.GetSnapshot().TotalRequests; got != 0 {
+		t.Errorf("TotalRequests = %d, want 0", got)
+	}
+
+	if got := parent.GetGroups(); len(got) != 2 {
+		t.Errorf("parent GetGroups() = %v, want 2 groups", got)
+	}
+
+	engine.Reset()
+	if engine.GetGroups() != nil {
+		t.Error("GetGroups() after Reset should be nil")
+	}
+}
+
+func TestEngine_RecordSelection(t *testing.T) {
+	parent := NewEngine()
+	defer parent.Stop()
+	engine := parent.NewChild()
+	defer engine.Stop()
+
+	if engine.GetSelections() != nil {
+		t.Error("GetSelections() should be nil before any picks")
+	}
+
+	engine.RecordSelection("search")
+	engine.RecordSelection("search")
+	engine.RecordSelection("purchase")
+
+	selections := engine.GetSnapshot().Selections
+	if selections["search"] != 2 || selections["purchase"] != 1 {
+		t.Errorf("Snapshot.Selections = %v, want search=2 purchase=1", selections)
+	}
+
+	// Picks don't count as requests
+	if got := engine.GetSnapshot().TotalRequests; got != 0 {
+		t.Errorf("TotalRequests = %d, want 0", got)
+	}
+
+	if got := parent.GetSelections(); got["search"] != 2 {
+		t.Errorf("parent GetSelections() = %v, want search=2", got)
+	}
+
+	engine.Reset()
+	if engine.GetSelections() != nil {
+		t.Error("GetSelections() after Reset should be nil")
+	}
+}
+
+func TestEngine_RecordTimings(t *testing.T) {
+	parent := NewEngine()
+	defer parent.Stop()
+	engine := parent.NewChild()
+	defer engine.Stop()
+
+	if engine.GetTimings() != nil {
+		t.Error("GetTimings() should be nil before any timings are recorded")
+	}
+
+	record := func(name string, timings Timings) {
+		engine.RecordLatency(timings.Waiting, name, true, 0)
+		engine.RecordTimings(name, timings)
+	}
+	record("login", Timings{DNS: 2 * time.Millisecond, Connect: time.Millisecond, Waiting: 100 * time.Millisecond, Receiving: 5 * time.Millisecond})
+	record("login", Timings{Waiting: 200 * time.Millisecond})
+	record("home", Timings{Waiting: 10 * time.Millisecond})
+
+	timings := engine.GetSnapshot().Timings
+	if timings == nil {
+		t.Fatal("Snapshot.Timings = nil, want phase statistics")
+	}
+	if timings.Waiting.Count != 3 {
+		t.Errorf("Waiting.Count = %d, want 3", timings.Waiting.Count)
+	}
+	if timings.Waiting.Max < 199*time.Millisecond || timings.Waiting.Min > 11*time.Millisecond {
+		t.Errorf("Waiting = %v..%v, want 10ms..200ms", timings.Waiting.Min, timings.Waiting.Max)
+	}
+
+	// Phases that took no time are recorded as zero
+	if timings.DNS.Min != 0 || timings.DNS.Max < 1999*time.Microsecond {
+		t.Errorf("DNS = %v..%v, want 0..2ms", timings.DNS.Min, timings.DNS.Max)
+	}
+
+	login := engine.GetRequestSnapshot("login")
+	if login == nil || login.Timings == nil {
+		t.Fatal("GetRequestSnapshot(login).Timings = nil, want phase statistics")
+	}
+	if login.Timings.Waiting.Count != 2 || login.Timings.Waiting.Min < 99*time.Millisecond {
+		t.Errorf("login Waiting = %d requests from %v, want 2 from 100ms", login.Timings.Waiting.Count, login.Timings.Waiting.Min)
+	}
+
+	// Timings are merged into the parent
+	if got := parent.GetTimings(); got == nil || got.Waiting.Count != 3 {
+		t.Errorf("parent GetTimings() = %v, want 3 requests", got)
+	}
+
+	phases := timings.Phases()
+	if len(phases) != 7 || phases[0].Name != "Blocked" || phases[5].Name != "Waiting" || phases[5].Count != 3 {
+		t.Errorf("Phases() = %v, want the seven phases in request order", phases)
+	}
+
+	engine.Reset()
+	if engine.GetTimings() != nil {
+		t.Error("GetTimings() after Reset should be nil")
+	}
+}
+
+func TestEngine_RecordDroppedIteration(t *testing.T) {
+	config := DefaultEngineConfig()
+	config.BucketInterval = time.Hour // Only the final bucket from Stop()
+	engine := NewEngineWithConfig(config)
+
+	engine.RecordDroppedIteration()
+	engine.RecordDroppedIteration()
+
+	if got := engine.GetDroppedIterations(); got != 2 {
+		t.Errorf("GetDroppedIterations() = %d, want 2", got)
+	}
+
+	snapshot := engine.GetSnapshot()
+	if snapshot.DroppedIterations != 2 {
+		t.Errorf("Snapshot.DroppedIterations = %d, want 2", snapshot.DroppedIterations)
+	}
+	if snapshot.TotalRequests != 0 {
+		t.Errorf("TotalRequests = %d, want 0", snapshot.TotalRequests)
+	}
+
+	engine.Stop()
+
+	buckets := engine.GetTimeSeries()
+	if len(buckets) != 1 {
+		t.Fatalf("GetTimeSeries() returned %d buckets, want 1", len(buckets))
+	}
+	if buckets[0].TotalDroppedIterations != 2 {
+		t.Errorf("TotalDroppedIterations = %d, want 2", buckets[0].TotalDroppedIterations)
+	}
+	if buckets[0].IntervalDroppedIterations != 2 {
+		t.Errorf("IntervalDroppedIterations = %d, want 2", buckets[0].IntervalDroppedIterations)
+	}
+
+	engine.Reset()
+	if got := engine.GetDroppedIterations(); got != 0 {
+		t.Errorf("GetDroppedIterations() after Reset = %d, want 0", got)
+	}
+}
+
+func TestEngine_NewChild(t *testing.T) {
+	parent := NewEngine()
+	defer parent.Stop()
+
+	a := parent.NewChild()
+	defer a.Stop()
+	b := parent.NewChild()
+	defer b.Stop()
+
+	a.RecordLatency(10*time.Millisecond, "a_req", true, 100)
+	a.RecordLatency(20*time.Millisecond, "a_req", false, 100)
+	b.RecordLatency(30*time.Millisecond, "b_req", true, 50)
+	b.RecordCheck("b: status eq 200", true)
+	b.RecordExtractionFailure("token")
+	b.RecordDroppedIteration()
+
+	// Children only see their own data
+	snapA := a.GetSnapshot()
+	if snapA.TotalRequests != 2 || snapA.FailedRequests != 1 {
+		t.Errorf("child a requests = %d (failed %d), want 2 (failed 1)", snapA.TotalRequests, snapA.FailedRequests)
+	}
+	if len(snapA.Checks) != 0 || snapA.ExtractionFailures != 0 || snapA.DroppedIterations != 0 {
+		t.Error("child a should not see child b's checks, extraction failures or dropped iterations")
+	}
+	if _, ok := a.GetRequestStats()["b_req"]; ok {
+		t.Error("child a should not have child b's request stats")
+	}
+
+	// The parent has the merged view
+	snap := parent.GetSnapshot()
+	if snap.TotalRequests != 3 {
+		t.Errorf("parent TotalRequests = %d, want 3", snap.TotalRequests)
+	}
+	if snap.TotalBytes != 250 {
+		t.Errorf("parent TotalBytes = %d, want 250", snap.TotalBytes)
+	}
+	if snap.Latency.Count != 3 {
+		t.Errorf("parent Latency.Count = %d, want 3", snap.Latency.Count)
+	}
+	if snap.Latency.Max < 29*time.Millisecond {
+		t.Errorf("parent Latency.Max = %v, want ~30ms", snap.Latency.Max)
+	}
+	if len(snap.Checks) != 1 || snap.ExtractionFailures != 1 || snap.DroppedIterations != 1 {
+		t.Errorf("parent checks/extraction failures/dropped = %d/%d/%d, want 1/1/1",
+			len(snap.Checks), snap.ExtractionFailures, snap.DroppedIterations)
+	}
+	if len(parent.GetRequestStats()) != 2 {
+		t.Errorf("parent has %d request stats, want 2", len(parent.GetRequestStats()))
+	}
+
+	// Active VUs are summed
+	a.SetActiveVUs(3)
+	b.SetActiveVUs(5)
+	a.SetActiveVUs(2)
+	if got := parent.GetActiveVUs(); got != 7 {
+		t.Errorf("parent GetActiveVUs() = %d, want 7", got)
+	}
+
+	// Phase follows the children
+	a.SetPhase(PhaseRampUp)
+	b.SetPhase(PhaseSteady)
+	if got := parent.GetPhase(); got != PhaseRampUp {
+		t.Errorf("parent phase = %v, want %v", got, PhaseRampUp)
+	}
+	a.SetPhase(PhaseDone)
+	if got := parent.GetPhase(); got != PhaseSteady {
+		t.Errorf("parent phase = %v, want %v", got, PhaseSteady)
+	}
+	b.SetPhase(PhaseDone)
+	if got := parent.GetPhase(); got != PhaseDone {
+		t.Errorf("parent phase = %v, want %v", got, PhaseDone)
+	}
+}
+
+func TestEngine_AddChild(t *testing.T) {
+	global := NewEngine()
+	defer global.Stop()
+	tagged := NewEngine()
+	defer tagged.Stop()
+
+	a := global.NewChild()
+	defer a.Stop()
+	b := global.NewChild()
+	defer b.Stop()
+
+	// Only a belongs to the second aggregate
+	tagged.AddChild(a)
+
+	a.RecordLatency(10*time.Millisecond, "a_req", true, 0)
+	b.RecordLatency(20*time.Millisecond, "b_req", true, 0)
+
+	if got := global.GetSnapshot().TotalRequests; got != 2 {
+		t.Errorf("global TotalRequests = %d, want 2", got)
+	}
+	if got := tagged.GetSnapshot().TotalRequests; got != 1 {
+		t.Errorf("tagged TotalRequests = %d, want 1", got)
+	}
+
+	a.SetActiveVUs(4)
+	if got := tagged.GetActiveVUs(); got != 4 {
+		t.Errorf("tagged GetActiveVUs() = %d, want 4", got)
+	}
+	if got := global.GetActiveVUs(); got != 4 {
+		t.Errorf("global GetActiveVUs() = %d, want 4", got)
+	}
+}
+
+func TestEngine_GetRequestSnapshot(t *testing.T) {
+	engine := NewEngine()
+	defer engine.Stop()
+
+	engine.RecordLatency(10*time.Millisecond, "Login", true, 0)
+	engine.RecordLatency(30*time.Millisecond, "Login", false, 0)
+	engine.RecordLatency(50*time.Millisecond, "Login", true, 0)
+	engine.RecordLatency(5*time.Millisecond, "Home", false, 0)
+
+	snap := engine.GetRequestSnapshot("Login")
+	if snap == nil {
+		t.Fatal("GetRequestSnapshot(Login) returned nil")
+	}
+	if snap.TotalRequests != 3 || snap.FailedRequests != 1 || snap.SuccessRequests != 2 {
+		t.Errorf("requests = %d (failed %d, success %d), want 3 (failed 1, success 2)",
+			snap.TotalRequests, snap.FailedRequests, snap.SuccessRequests)
+	}
+	if snap.ErrorRate < 0.33 || snap.ErrorRate > 0.34 {
+		t.Errorf("ErrorRate = %f, want ~0.333", snap.ErrorRate)
+	}
+	if snap.Latency.Max < 49*time.Millisecond {
+		t.Errorf("Latency.Max = %v, want ~50ms", snap.Latency.Max)
+	}
+
+	if engine.GetRequestSnapshot("Missing") != nil {
+		t.Error("GetRequestSnapshot(Missing) should return nil")
+	}
+
+	engine.Reset()
+	if engine.GetRequestSnapshot("Login") != nil {
+		t.Error("GetRequestSnapshot(Login) after Reset() should return nil")
+	}
+}
+
+func TestMergePhases(t *testing.T) {
+	tests := []struct {
+		name   string
+		phases []Phase
+		want   Phase
+	}{
+		{"single", []Phase{PhaseSteady}, PhaseSteady},
+		{"all done", []Phase{PhaseDone, PhaseDone}, PhaseDone},
+		{"done ignored", []Phase{PhaseDone, PhaseRampDown}, PhaseRampDown},
+		{"ramp-up wins", []Phase{PhaseSteady, PhaseRampUp, PhaseRampDown}, PhaseRampUp},
+		{"steady over ramp-down", []Phase{PhaseRampDown, PhaseSteady}, PhaseSteady},
+		{"waiting scenario", []Phase{PhaseDone, PhaseInit}, PhaseInit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergePhases(tt.phases); got != tt.want {
+				t.Errorf("mergePhases(%v) = %v, want %v", tt.phases, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEngine_PhaseFromChildrenFinishingTogether(t *testing.T) {
+	parent := NewEngine()
+	defer parent.Stop()
+
+	for round := 0; round < 200; round++ {
+		children := make([]*Engine, 16)
+		for i := range children {
+			children[i] = parent.NewChild()
+			children[i].SetPhase(PhaseSteady)
+		}
+
+		// Every child finishes at the same time; the last merge must see
+		// all of them done
+		var wg sync.WaitGroup
+		for _, child := range children {
+			wg.Add(1)
+			go func(child *Engine) {
+				defer wg.Done()
+				child.SetPhase(PhaseDone)
+			}(child)
+		}
+		wg.Wait()
+
+		if got := parent.GetPhase(); got != PhaseDone {
+			t.Fatalf("round %d: parent phase = %v, want %v", round, got, PhaseDone)
+		}
+
+		for _, child := range children {
+			child.Stop()
+		}
+		parent.childrenMu.Lock()
+		parent.children = nil
+		parent.childrenMu.Unlock()
+	}
+}
+
 func TestEngine_Reset(t *testing.T) {
 	engine := NewEngine()
 	defer engine.Stop()
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/HdrHistogram/hdrhistogram-go"
+)
+
+// GroupStats contains the duration statistics of a request group.
+type GroupStats struct {
+	Name     string       `json:"name"`
+	Duration LatencyStats `json:"duration"`
+}
+
+// groupStore holds one HDR histogram of run durations per request group.
+type groupStore struct {
+	hists map[string]*hdrhistogram.Histogram
+	mu    sync.Mutex
+	cfg   EngineConfig
+}
+
+func newGroupStore(cfg EngineConfig) *groupStore {
+	return &groupStore{
+		hists: make(map[string]*hdrhistogram.Histogram),
+		cfg:   cfg,
+	}
+}
+
+// record records the duration of one run of a group.
+func (gs *groupStore) record(name string, d time.Duration) {
+	micros := d.Microseconds()
+	if micros < gs.cfg.HistogramMin {
+		micros = gs.cfg.HistogramMin
+	}
+	if micros > gs.cfg.HistogramMax {
+		micros = gs.cfg.HistogramMax
+	}
+
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	hist, exists := gs.hists[name]
+	if !exists {
+		hist = hdrhistogram.New(gs.cfg.HistogramMin, gs.cfg.HistogramMax, gs.cfg.HistogramSigFigs)
+		gs.hists[name] = hist
+	}
+	hist.RecordValue(micros)
+}
+
+// stats returns the statistics for all groups, sorted by name.
+func (gs *groupStore) stats() []GroupStats {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	if len(gs.hists) == 0 {
+		return nil
+	}
+
+	result := make([]GroupStats, 0, len(gs.hists))
+	for name, hist := range gs.hists {
+		result = append(result, GroupStats{Name: name, Duration: histogramStats(hist)})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// reset clears all group histograms.
+func (gs *groupStore) reset() {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	gs.hists = make(map[string]*hdrhistogram.Histogram)
+}
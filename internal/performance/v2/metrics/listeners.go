@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Sample is the result of one request, passed to listeners as it is
+// recorded.
+type Sample struct {
+	Time     time.Time
+	Scenario string
+	Name     string
+	Method   string
+	Status   int // 0 for requests that received no response
+	Duration time.Duration
+	Success  bool
+	Bytes    int64
+	Error    string // Why the request failed without a response, if it did
+
+	// VU and Iteration identify the iteration that made the request
+	VU        int
+	Iteration int64
+
+	// Timings is the time spent in each phase, if the response was
+	// received in full
+	Timings *Timings
+
+	// TraceID and SpanID are the IDs sent in the request's traceparent
+	// header, if any
+	TraceID string
+	SpanID  string
+}
+
+// Listener receives request samples and time buckets as they are recorded,
+// e.g. to stream them to an external system.
+//
+// Its methods are called from the goroutines that record requests and emit
+// buckets, so they must return quickly and never block.
+type Listener interface {
+	AddSample(Sample)
+	AddBucket(*TimeBucket)
+}
+
+// listenerList is a copy-on-write list of listeners, so notifying them
+// takes no lock.
+type listenerList struct {
+	list atomic.Pointer[[]Listener]
+	mu   sync.Mutex
+}
+
+// add appends a listener.
+func (ll *listenerList) add(l Listener) {
+	ll.mu.Lock()
+	defer ll.mu.Unlock()
+
+	var list []Listener
+	if current := ll.list.Load(); current != nil {
+		list = append(list, *current...)
+	}
+	list = append(list, l)
+	ll.list.Store(&list)
+}
+
+// get returns the current listeners.
+func (ll *listenerList) get() []Listener {
+	if list := ll.list.Load(); list != nil {
+		return *list
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrorCategory classifies why a request failed without a response.
+type ErrorCategory string
+
+const (
+	ErrorTimeout           ErrorCategory = "timeout"
+	ErrorConnectionRefused ErrorCategory = "connection_refused"
+	ErrorConnectionReset   ErrorCategory = "connection_reset"
+	ErrorDNS               ErrorCategory = "dns"
+	ErrorTLS               ErrorCategory = "tls"
+	ErrorBodyRead          ErrorCategory = "body_read"
+	ErrorOther             ErrorCategory = "other"
+)
+
+// maxErrorMessages bounds the distinct error messages tracked per engine;
+// once reached, errors with new messages are only counted by category.
+const maxErrorMessages = 100
+
+// topErrorCount is the number of most frequent error messages in a Snapshot.
+const topErrorCount = 10
+
+// ErrorStats counts the errors of one category with the same message.
+type ErrorStats struct {
+	Category ErrorCategory `json:"category"`
+	Message  string        `json:"message"`
+	Count    int64         `json:"count"`
+}
+
+// statusStore counts responses per HTTP status code.
+type statusStore struct {
+	keyedStore[int, atomic.Int64]
+}
+
+func newStatusStore() *statusStore {
+	return &statusStore{newKeyedStore[int, atomic.Int64]()}
+}
+
+// add counts one response with the given status code.
+func (ss *statusStore) add(code int) {
+	ss.get(code).Add(1)
+}
+
+// snapshot returns the count of each status code, or nil if there are none.
+func (ss *statusStore) snapshot() map[int]int64 {
+	return ss.collect(func(c *atomic.Int64) int64 { return c.Load() })
+}
+
+// drain returns the count of each status code since the last drain and
+// resets the counts, omitting codes with no new responses.
+func (ss *statusStore) drain() map[int]int64 {
+	return ss.collect(func(c *atomic.Int64) int64 { return c.Swap(0) })
+}
+
+func (ss *statusStore) collect(value func(*atomic.Int64) int64) map[int]int64 {
+	var result map[int]int64
+	ss.each(func(code int, c *atomic.Int64) {
+		if n := value(c); n > 0 {
+			if result == nil {
+				result = make(map[int]int64)
+			}
+			result[code] = n
+		}
+	})
+	return result
+}
+
+// errorKey identifies an error message within its category.
+type errorKey struct {
+	category ErrorCategory
+	message  string
+}
+
+// errorStore counts request errors by category and by message.
+//
+// Errors only occur on failed requests, so a plain mutex is enough.
+type errorStore struct {
+	categories map[ErrorCategory]int64
+	messages   map[errorKey]int64
+	mu         sync.Mutex
+}
+
+func newErrorStore() *errorStore {
+	return &errorStore{
+		categories: make(map[ErrorCategory]int64),
+		messages:   make(map[errorKey]int64),
+	}
+}
+
+// record counts one error.
+func (es *errorStore) record(category ErrorCategory, message string) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	es.categories[category]++
+
+	key := errorKey{category: category, message: message}
+	if _, exists := es.messages[key]; exists || len(es.messages) < maxErrorMessages {
+		es.messages[key]++
+	}
+}
+
+// categoryCounts returns the number of errors per category, or nil if
+// there are none.
+func (es *errorStore) categoryCounts() map[ErrorCategory]int64 {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	if len(es.categories) == 0 {
+		return nil
+	}
+
+	result := make(map[ErrorCategory]int64, len(es.categories))
+	for category, count := range es.categories {
+		result[category] = count
+	}
+	return result
+}
+
+// top returns the n most frequent error messages, most frequent first.
+func (es *errorStore) top(n int) []ErrorStats {
+	es.mu.Lock()
+	stats := make([]ErrorStats, 0, len(es.messages))
+	for key, count := range es.messages {
+		stats = append(stats, ErrorStats{Category: key.category, Message: key.message, Count: count})
+	}
+	es.mu.Unlock()
+
+	if len(stats) == 0 {
+		return nil
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		if stats[i].Category != stats[j].Category {
+			return stats[i].Category < stats[j].Category
+		}
+		return stats[i].Message < stats[j].Message
+	})
+
+	if len(stats) > n {
+		stats = stats[:n]
+	}
+	return stats
+}
+
+// reset clears all error counts.
+func (es *errorStore) reset() {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+	es.categories = make(map[ErrorCategory]int64)
+	es.messages = make(map[errorKey]int64)
+}
+
+// RequestStatusCount counts the requests with one name and status code.
+type RequestStatusCount struct {
+	Name     string
+	Status   int // 0 for requests that received no response
+	Requests int64
+	Failures int64
+}
+
+// requestStatusKey identifies a request name and status code pair.
+type requestStatusKey struct {
+	name   string
+	status int
+}
+
+// requestStatusCounters holds the counts of one request status pair.
+type requestStatusCounters struct {
+	requests atomic.Int64
+	failures atomic.Int64
+}
+
+// requestStatusStore counts requests per request name and status code.
+type requestStatusStore struct {
+	keyedStore[requestStatusKey, requestStatusCounters]
+}
+
+func newRequestStatusStore() *requestStatusStore {
+	return &requestStatusStore{newKeyedStore[requestStatusKey, requestStatusCounters]()}
+}
+
+// add counts one request.
+func (rs *requestStatusStore) add(name string, status int, success bool) {
+	c := rs.get(requestStatusKey{name: name, status: status})
+	c.requests.Add(1)
+	if !success {
+		c.failures.Add(1)
+	}
+}
+
+// counts returns the counts of all pairs, ordered by name and status.
+func (rs *requestStatusStore) counts() []RequestStatusCount {
+	result := make([]RequestStatusCount, 0)
+	rs.each(func(key requestStatusKey, c *requestStatusCounters) {
+		result = append(result, RequestStatusCount{
+			Name:     key.name,
+			Status:   key.status,
+			Requests: c.requests.Load(),
+			Failures: c.failures.Load(),
+		})
+	})
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].Status < result[j].Status
+	})
+	return result
+}
+
+// StatusCount is the number of responses with one status code.
+type StatusCount struct {
+	Code  int
+	Count int64
+}
+
+// SortStatusCodes returns the status code counts ordered by code.
+func SortStatusCodes(codes map[int]int64) []StatusCount {
+	counts := make([]StatusCount, 0, len(codes))
+	for code, count := range codes {
+		counts = append(counts, StatusCount{Code: code, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].Code < counts[j].Code })
+	return counts
+}
+
+// CategoryCount is the number of errors in one category.
+type CategoryCount struct {
+	Category ErrorCategory
+	Count    int64
+}
+
+// SortErrorCategories returns the error category counts, most frequent first.
+func SortErrorCategories(categories map[ErrorCategory]int64) []CategoryCount {
+	counts := make([]CategoryCount, 0, len(categories))
+	for category, count := range categories {
+		counts = append(counts, CategoryCount{Category: category, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Category < counts[j].Category
+	})
+	return counts
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import "sync"
+
+// keyedStore holds one value per key, created on first use.
+//
+// Value creation is guarded by a mutex; values are updated lock-free, so
+// V is an atomic or a struct of atomics.
+type keyedStore[K comparable, V any] struct {
+	values map[K]*V
+	mu     sync.RWMutex
+}
+
+func newKeyedStore[K comparable, V any]() keyedStore[K, V] {
+	return keyedStore[K, V]{
+		values: make(map[K]*V),
+	}
+}
+
+// get returns the value for key, creating it if needed.
+func (s *keyedStore[K, V]) get(key K) *V {
+	s.mu.RLock()
+	v, exists := s.values[key]
+	s.mu.RUnlock()
+	if exists {
+		return v
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if v, exists = s.values[key]; exists {
+		return v
+	}
+	v = new(V)
+	s.values[key] = v
+	return v
+}
+
+// each calls fn for every key and value, in no particular order. No value
+// is created while it runs.
+func (s *keyedStore[K, V]) each(fn func(key K, v *V)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for key, v := range s.values {
+		fn(key, v)
+	}
+}
+
+// reset clears all values.
+func (s *keyedStore[K, V]) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values = make(map[K]*V)
+}
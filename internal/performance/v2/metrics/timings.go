@@ -0,0 +1,162 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/HdrHistogram/hdrhistogram-go"
+)
+
+// Timings is the time a single HTTP request spent in each phase.
+type Timings struct {
+	// Blocked is time spent waiting for a free connection, not counting
+	// DNS lookup, connecting and TLS handshake
+	Blocked time.Duration `json:"blocked"`
+
+	// DNS is the DNS lookup time
+	DNS time.Duration `json:"dns"`
+
+	// Connect is the TCP connection time
+	Connect time.Duration `json:"connect"`
+
+	// TLS is the TLS handshake time
+	TLS time.Duration `json:"tls"`
+
+	// Sending is the time spent writing the request
+	Sending time.Duration `json:"sending"`
+
+	// Waiting is the time from the request being written to the first
+	// response byte (time to first byte)
+	Waiting time.Duration `json:"waiting"`
+
+	// Receiving is the time spent reading the response body
+	Receiving time.Duration `json:"receiving"`
+}
+
+// phases returns the phase durations in the order of phaseNames.
+func (t Timings) phases() [numPhases]time.Duration {
+	return [numPhases]time.Duration{t.Blocked, t.DNS, t.Connect, t.TLS, t.Sending, t.Waiting, t.Receiving}
+}
+
+// numPhases is the number of phases in Timings.
+const numPhases = 7
+
+// phaseNames are the display names of the phases, in Timings order.
+var phaseNames = [numPhases]string{"Blocked", "DNS Lookup", "Connecting", "TLS Handshake", "Sending", "Waiting", "Receiving"}
+
+// TimingStats contains latency statistics for each phase of HTTP requests.
+type TimingStats struct {
+	Blocked   LatencyStats `json:"blocked"`
+	DNS       LatencyStats `json:"dns"`
+	Connect   LatencyStats `json:"connect"`
+	TLS       LatencyStats `json:"tls"`
+	Sending   LatencyStats `json:"sending"`
+	Waiting   LatencyStats `json:"waiting"`
+	Receiving LatencyStats `json:"receiving"`
+}
+
+// PhaseStats is the latency statistics of one named request phase.
+type PhaseStats struct {
+	Name string
+	LatencyStats
+}
+
+// Phases returns the statistics of each phase in request order, for display.
+func (s *TimingStats) Phases() []PhaseStats {
+	stats := [numPhases]LatencyStats{s.Blocked, s.DNS, s.Connect, s.TLS, s.Sending, s.Waiting, s.Receiving}
+	phases := make([]PhaseStats, numPhases)
+	for i := range phases {
+		phases[i] = PhaseStats{Name: phaseNames[i], LatencyStats: stats[i]}
+	}
+	return phases
+}
+
+// timingStore holds one HDR histogram per request phase.
+//
+// The histograms are created on the first record, so engines that never
+// see a request don't pay for them.
+type timingStore struct {
+	hists [numPhases]*hdrhistogram.Histogram
+	mu    sync.Mutex
+	cfg   EngineConfig
+}
+
+func newTimingStore(cfg EngineConfig) *timingStore {
+	return &timingStore{cfg: cfg}
+}
+
+// record records the phase timings of one request.
+func (ts *timingStore) record(t Timings) {
+	phases := t.phases()
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.hists[0] == nil {
+		for i := range ts.hists {
+			ts.hists[i] = hdrhistogram.New(ts.cfg.HistogramMin, ts.cfg.HistogramMax, ts.cfg.HistogramSigFigs)
+		}
+	}
+
+	for i, d := range phases {
+		// Phases can legitimately take no time (e.g. no DNS lookup on a
+		// reused connection), so unlike request latency zero is kept
+		micros := d.Microseconds()
+		if micros < 0 {
+			micros = 0
+		}
+		if micros > ts.cfg.HistogramMax {
+			micros = ts.cfg.HistogramMax
+		}
+		ts.hists[i].RecordValue(micros)
+	}
+}
+
+// stats returns the statistics of each phase, or nil if nothing was recorded.
+func (ts *timingStore) stats() *TimingStats {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.hists[0] == nil || ts.hists[0].TotalCount() == 0 {
+		return nil
+	}
+
+	var stats [numPhases]LatencyStats
+	for i, hist := range ts.hists {
+		stats[i] = histogramStats(hist)
+	}
+	return &TimingStats{
+		Blocked:   stats[0],
+		DNS:       stats[1],
+		Connect:   stats[2],
+		TLS:       stats[3],
+		Sending:   stats[4],
+		Waiting:   stats[5],
+		Receiving: stats[6],
+	}
+}
+
+// reset clears all phase histograms.
+func (ts *timingStore) reset() {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	for i := range ts.hists {
+		ts.hists[i] = nil
+	}
+}
+
+// histogramStats returns the latency statistics of a microsecond histogram.
+func histogramStats(hist *hdrhistogram.Histogram) LatencyStats {
+	return LatencyStats{
+		Min:    time.Duration(hist.Min()) * time.Microsecond,
+		Max:    time.Duration(hist.Max()) * time.Microsecond,
+		Mean:   time.Duration(hist.Mean()) * time.Microsecond,
+		StdDev: time.Duration(hist.StdDev()) * time.Microsecond,
+		P50:    time.Duration(hist.ValueAtQuantile(50)) * time.Microsecond,
+		P90:    time.Duration(hist.ValueAtQuantile(90)) * time.Microsecond,
+		P95:    time.Duration(hist.ValueAtQuantile(95)) * time.Microsecond,
+		P99:    time.Duration(hist.ValueAtQuantile(99)) * time.Microsecond,
+		Count:  hist.TotalCount(),
+	}
+}
@@ -0,0 +1,301 @@
+package otlp
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/wesleyorama2/lunge/internal/performance/v2/engine"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+)
+
+// DurationBuckets are the upper bounds of the lunge.http_req_duration
+// histogram buckets.
+var DurationBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// OTLP aggregation temporality of cumulative sums and histograms.
+const temporalityCumulative = 2
+
+// Exporter periodically pushes the metrics of an engine to a collector.
+type Exporter struct {
+	engine *engine.Engine
+	client *client
+	config Config
+	start  time.Time // Start of the cumulative sums
+
+	stop      chan struct{}
+	done      chan struct{}
+	startOnce sync.Once
+	closeOnce sync.Once
+
+	errMu   sync.Mutex
+	lastErr error
+}
+
+// NewExporter creates a metrics exporter for an engine. Call Start to begin
+// exporting.
+func NewExporter(eng *engine.Engine, config Config) (*Exporter, error) {
+	config = config.withDefaults()
+	c, err := newClient(config, "metrics")
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{
+		engine: eng,
+		client: c,
+		config: config,
+		start:  time.Now(),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}, nil
+}
+
+// Start exports the metrics every interval in the background.
+func (e *Exporter) Start() {
+	e.startOnce.Do(func() {
+		go e.run()
+	})
+}
+
+// Close stops the exporter after a final export, and returns the error of
+// the last failed export, if any.
+func (e *Exporter) Close() error {
+	e.closeOnce.Do(func() {
+		e.Start()
+		close(e.stop)
+		<-e.done
+	})
+
+	e.errMu.Lock()
+	defer e.errMu.Unlock()
+	return e.lastErr
+}
+
+func (e *Exporter) run() {
+	defer close(e.done)
+
+	ticker := time.NewTicker(e.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			e.Export()
+		case <-e.stop:
+			e.Export()
+			return
+		}
+	}
+}
+
+// Export pushes the current metrics once. Nothing is sent before the
+// engine has scenarios.
+func (e *Exporter) Export() error {
+	payload := buildMetrics(e.engine, e.start, time.Now())
+	if payload == nil {
+		return nil
+	}
+
+	body, err := json.Marshal(payload)
+	if err == nil {
+		err = e.client.post(body)
+	}
+	if err != nil {
+		e.errMu.Lock()
+		e.lastErr = err
+		e.errMu.Unlock()
+	}
+	return err
+}
+
+type metricsData struct {
+	ResourceMetrics []resourceMetrics `json:"resourceMetrics"`
+}
+
+type resourceMetrics struct {
+	Resource     resource       `json:"resource"`
+	ScopeMetrics []scopeMetrics `json:"scopeMetrics"`
+}
+
+type scopeMetrics struct {
+	Scope   scope    `json:"scope"`
+	Metrics []metric `json:"metrics"`
+}
+
+type metric struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Unit        string     `json:"unit,omitempty"`
+	Gauge       *gauge     `json:"gauge,omitempty"`
+	Sum         *sum       `json:"sum,omitempty"`
+	Histogram   *histogram `json:"histogram,omitempty"`
+}
+
+type gauge struct {
+	DataPoints []numberDataPoint `json:"dataPoints"`
+}
+
+type sum struct {
+	DataPoints             []numberDataPoint `json:"dataPoints"`
+	AggregationTemporality int               `json:"aggregationTemporality"`
+	IsMonotonic            bool              `json:"isMonotonic"`
+}
+
+type numberDataPoint struct {
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano,omitempty"`
+	TimeUnixNano      string     `json:"timeUnixNano"`
+	AsInt             string     `json:"asInt"`
+}
+
+type histogram struct {
+	DataPoints             []histogramDataPoint `json:"dataPoints"`
+	AggregationTemporality int                  `json:"aggregationTemporality"`
+}
+
+type histogramDataPoint struct {
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	TimeUnixNano      string     `json:"timeUnixNano"`
+	Count             string     `json:"count"`
+	Sum               float64    `json:"sum"`
+	BucketCounts      []string   `json:"bucketCounts"`
+	ExplicitBounds    []float64  `json:"explicitBounds"`
+}
+
+// buildMetrics collects the metrics of an engine, or returns nil if it has
+// no scenarios yet.
+func buildMetrics(eng *engine.Engine, start, now time.Time) *metricsData {
+	sources := eng.GetScenarioMetricsEngines()
+	if len(sources) == 0 {
+		return nil
+	}
+	stats := eng.GetScenarioStats()
+
+	scenarios := make([]string, 0, len(sources))
+	for name := range sources {
+		scenarios = append(scenarios, name)
+	}
+	sort.Strings(scenarios)
+
+	startNano, nowNano := unixNano(start), unixNano(now)
+	point := func(attrs []keyValue, value int64) numberDataPoint {
+		return numberDataPoint{
+			Attributes:        attrs,
+			StartTimeUnixNano: startNano,
+			TimeUnixNano:      nowNano,
+			AsInt:             strconv.FormatInt(value, 10),
+		}
+	}
+	counter := func(name, description, unit string, points []numberDataPoint) metric {
+		return metric{
+			Name:        name,
+			Description: description,
+			Unit:        unit,
+			Sum: &sum{
+				DataPoints:             points,
+				AggregationTemporality: temporalityCumulative,
+				IsMonotonic:            true,
+			},
+		}
+	}
+
+	var vus, iterations, dropped, requests, failed []numberDataPoint
+	var durations []histogramDataPoint
+	for _, scenario := range scenarios {
+		source := sources[scenario]
+		snapshot := source.GetSnapshot()
+		attrs := []keyValue{stringAttr("scenario", scenario)}
+
+		vus = append(vus, numberDataPoint{Attributes: attrs, TimeUnixNano: nowNano, AsInt: strconv.Itoa(snapshot.ActiveVUs)})
+		if st, ok := stats[scenario]; ok {
+			iterations = append(iterations, point(attrs, st.Iterations))
+		}
+		dropped = append(dropped, point(attrs, snapshot.DroppedIterations))
+
+		for _, c := range source.GetRequestStatuses() {
+			attrs := []keyValue{
+				stringAttr("scenario", scenario),
+				stringAttr("name", c.Name),
+				stringAttr("status", strconv.Itoa(c.Status)),
+			}
+			requests = append(requests, point(attrs, c.Requests))
+			failed = append(failed, point(attrs, c.Failures))
+		}
+
+		hists := source.GetRequestHistograms(DurationBuckets)
+		names := make([]string, 0, len(hists))
+		for name := range hists {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			dp := histogramPoint(hists[name])
+			dp.Attributes = []keyValue{stringAttr("scenario", scenario), stringAttr("name", name)}
+			dp.StartTimeUnixNano = startNano
+			dp.TimeUnixNano = nowNano
+			durations = append(durations, dp)
+		}
+	}
+
+	metricList := []metric{
+		{Name: "lunge.vus", Description: "Active virtual users.", Unit: "{vu}", Gauge: &gauge{DataPoints: vus}},
+		counter("lunge.iterations", "Completed iterations.", "{iteration}", iterations),
+		counter("lunge.dropped_iterations", "Iterations that could not start because every VU was busy.", "{iteration}", dropped),
+		counter("lunge.http_reqs", "HTTP requests made.", "{request}", requests),
+		counter("lunge.http_req_failed", "HTTP requests that failed.", "{request}", failed),
+		{
+			Name:        "lunge.http_req_duration",
+			Description: "HTTP request duration.",
+			Unit:        "ms",
+			Histogram: &histogram{
+				DataPoints:             durations,
+				AggregationTemporality: temporalityCumulative,
+			},
+		},
+	}
+
+	return &metricsData{ResourceMetrics: []resourceMetrics{{
+		Resource: serviceResource(),
+		ScopeMetrics: []scopeMetrics{{
+			Scope:   scope{Name: ServiceName},
+			Metrics: metricList,
+		}},
+	}}}
+}
+
+// histogramPoint converts a cumulative histogram to OTLP buckets, which
+// count the values in each bucket only, plus one for values above the
+// last bound.
+func histogramPoint(h metrics.Histogram) histogramDataPoint {
+	dp := histogramDataPoint{
+		Count:          strconv.FormatInt(h.Count, 10),
+		Sum:            millis(h.Sum),
+		BucketCounts:   make([]string, 0, len(h.Counts)+1),
+		ExplicitBounds: make([]float64, 0, len(h.Bounds)),
+	}
+
+	previous := int64(0)
+	for i, bound := range h.Bounds {
+		dp.ExplicitBounds = append(dp.ExplicitBounds, millis(bound))
+		dp.BucketCounts = append(dp.BucketCounts, strconv.FormatInt(h.Counts[i]-previous, 10))
+		previous = h.Counts[i]
+	}
+	dp.BucketCounts = append(dp.BucketCounts, strconv.FormatInt(h.Count-previous, 10))
+	return dp
+}
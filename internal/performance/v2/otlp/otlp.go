@@ -0,0 +1,164 @@
+// Package otlp exports the metrics and request spans of a performance test
+// to an OpenTelemetry collector over OTLP/HTTP, using the JSON encoding.
+//
+// The Exporter pushes the aggregated metrics of an engine to
+// {endpoint}/v1/metrics at a fixed interval:
+//
+//	lunge.vus{scenario}                      gauge
+//	lunge.iterations{scenario}               cumulative sum
+//	lunge.dropped_iterations{scenario}       cumulative sum
+//	lunge.http_reqs{scenario,name,status}    cumulative sum
+//	lunge.http_req_failed{scenario,name,status}
+//	lunge.http_req_duration{scenario,name}   histogram, in ms
+//
+// NewTraceOutput streams one client span per request to
+// {endpoint}/v1/traces. With the traceparent setting enabled, the span IDs
+// are the ones sent to the server, so its spans become children of them.
+package otlp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ServiceName is the service.name resource attribute of exported data.
+const ServiceName = "lunge"
+
+// Config configures the connection to a collector.
+type Config struct {
+	// Endpoint is the base URL of the collector's OTLP/HTTP receiver, e.g.
+	// http://localhost:4318
+	Endpoint string
+
+	// Headers are added to every export request, e.g. for authentication
+	Headers map[string]string
+
+	// Interval is the time between metric exports (default: 10s)
+	Interval time.Duration
+
+	// Timeout bounds each export request (default: 10s)
+	Timeout time.Duration
+}
+
+// withDefaults fills unset fields with their defaults.
+func (c Config) withDefaults() Config {
+	if c.Interval <= 0 {
+		c.Interval = 10 * time.Second
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 10 * time.Second
+	}
+	return c
+}
+
+// signalURL returns the URL of a signal, e.g. "traces", under the endpoint.
+func (c Config) signalURL(signal string) (string, error) {
+	u, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid OTLP endpoint: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("OTLP endpoint must be http(s)://host[:port], got %q", c.Endpoint)
+	}
+	return strings.TrimSuffix(c.Endpoint, "/") + "/v1/" + signal, nil
+}
+
+// client posts OTLP/JSON payloads to one signal URL.
+type client struct {
+	url     string
+	headers map[string]string
+	http    *http.Client
+}
+
+func newClient(config Config, signal string) (*client, error) {
+	u, err := config.signalURL(signal)
+	if err != nil {
+		return nil, err
+	}
+	return &client{
+		url:     u,
+		headers: config.Headers,
+		http:    &http.Client{Timeout: config.Timeout},
+	}, nil
+}
+
+func (c *client) post(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("export to %s returned %s: %s", c.url, resp.Status, strings.TrimSpace(string(body)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// OTLP/JSON messages. Only the fields lunge sets are declared; 64-bit
+// integers are encoded as strings, as the OTLP JSON mapping requires.
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+}
+
+func stringAttr(key, value string) keyValue {
+	return keyValue{Key: key, Value: anyValue{StringValue: &value}}
+}
+
+func intAttr(key string, value int64) keyValue {
+	s := strconv.FormatInt(value, 10)
+	return keyValue{Key: key, Value: anyValue{IntValue: &s}}
+}
+
+func doubleAttr(key string, value float64) keyValue {
+	return keyValue{Key: key, Value: anyValue{DoubleValue: &value}}
+}
+
+// serviceResource is the resource all exported data belongs to.
+func serviceResource() resource {
+	return resource{Attributes: []keyValue{stringAttr("service.name", ServiceName)}}
+}
+
+// unixNano formats a time as OTLP nanoseconds since the epoch.
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// millis converts a duration to fractional milliseconds.
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,299 @@
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/config"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/engine"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/stream"
+)
+
+// collector is a stand-in OTLP/HTTP receiver that records the payloads it
+// receives by path.
+type collector struct {
+	*httptest.Server
+	mu       sync.Mutex
+	payloads map[string][]map[string]any
+	headers  http.Header
+}
+
+func newCollector(t *testing.T) *collector {
+	t.Helper()
+
+	c := &collector{payloads: make(map[string][]map[string]any)}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]any
+		if err := json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		c.mu.Lock()
+		c.payloads[r.URL.Path] = append(c.payloads[r.URL.Path], payload)
+		c.headers = r.Header.Clone()
+		c.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(c.Close)
+	return c
+}
+
+func (c *collector) received(path string) []map[string]any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.payloads[path]
+}
+
+// runEngine runs a short test with a succeeding and a failing request and
+// returns the traceparent headers the target received.
+func runEngine(t *testing.T, traceparent bool, listeners ...metrics.Listener) (*engine.Engine, []string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var headers []string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		headers = append(headers, r.Header.Get("Traceparent"))
+		mu.Unlock()
+
+		time.Sleep(2 * time.Millisecond)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer target.Close()
+
+	eng, err := engine.NewEngine(&config.TestConfig{
+		Name:     "OTLP Test",
+		Settings: config.GlobalSettings{Traceparent: traceparent},
+		Scenarios: map[string]*config.ScenarioConfig{
+			"api": {
+				Executor:   "per-vu-iterations",
+				VUs:        2,
+				Iterations: 3,
+				Requests: []config.RequestConfig{
+					{Name: "ok", Method: "GET", URL: target.URL + "/ok"},
+					{Name: "fail", Method: "GET", URL: target.URL + "/fail"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	for _, l := range listeners {
+		eng.AddListener(l)
+	}
+
+	_, err = eng.Run(context.Background())
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	return eng, headers
+}
+
+// attrs flattens OTLP attributes to a map of their values.
+func attrs(t *testing.T, v any) map[string]any {
+	t.Helper()
+
+	result := make(map[string]any)
+	list, _ := v.([]any)
+	for _, item := range list {
+		kv := item.(map[string]any)
+		for _, value := range kv["value"].(map[string]any) {
+			result[kv["key"].(string)] = value
+		}
+	}
+	return result
+}
+
+// path returns the value at a path of object keys and array indexes.
+func path(v any, keys ...any) any {
+	for _, key := range keys {
+		switch k := key.(type) {
+		case string:
+			v = v.(map[string]any)[k]
+		case int:
+			v = v.([]any)[k]
+		}
+	}
+	return v
+}
+
+func TestExporter_Metrics(t *testing.T) {
+	c := newCollector(t)
+	eng, _ := runEngine(t, false)
+
+	exporter, err := NewExporter(eng, Config{
+		Endpoint: c.URL + "/",
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, exporter.Export())
+
+	payloads := c.received("/v1/metrics")
+	require.Len(t, payloads, 1)
+	assert.Equal(t, "Bearer token", c.headers.Get("Authorization"))
+	assert.Equal(t, "application/json", c.headers.Get("Content-Type"))
+
+	rm := path(payloads[0], "resourceMetrics", 0)
+	assert.Equal(t, map[string]any{"service.name": ServiceName}, attrs(t, path(rm, "resource", "attributes")))
+
+	byName := make(map[string]map[string]any)
+	for _, m := range path(rm, "scopeMetrics", 0, "metrics").([]any) {
+		byName[m.(map[string]any)["name"].(string)] = m.(map[string]any)
+	}
+	require.Contains(t, byName, "lunge.vus")
+	require.Contains(t, byName, "lunge.dropped_iterations")
+
+	iterations := path(byName["lunge.iterations"], "sum", "dataPoints", 0)
+	assert.Equal(t, "6", iterations.(map[string]any)["asInt"])
+	assert.Equal(t, 2.0, path(byName["lunge.iterations"], "sum", "aggregationTemporality"))
+
+	requests := make(map[string]string)
+	for _, dp := range path(byName["lunge.http_reqs"], "sum", "dataPoints").([]any) {
+		a := attrs(t, dp.(map[string]any)["attributes"])
+		requests[a["name"].(string)+"/"+a["status"].(string)] = dp.(map[string]any)["asInt"].(string)
+	}
+	assert.Equal(t, map[string]string{"ok/200": "6", "fail/500": "6"}, requests)
+
+	for _, dp := range path(byName["lunge.http_req_duration"], "histogram", "dataPoints").([]any) {
+		point := dp.(map[string]any)
+		assert.Equal(t, "6", point["count"])
+		assert.Len(t, point["explicitBounds"], len(DurationBuckets))
+
+		total := 0
+		for _, count := range point["bucketCounts"].([]any) {
+			n, err := strconv.Atoi(count.(string))
+			require.NoError(t, err)
+			total += n
+		}
+		assert.Equal(t, 6, total)
+	}
+}
+
+func TestExporter_CloseExportsAndReportsErrors(t *testing.T) {
+	eng, _ := runEngine(t, false)
+
+	c := newCollector(t)
+	exporter, err := NewExporter(eng, Config{Endpoint: c.URL, Interval: time.Hour})
+	require.NoError(t, err)
+	exporter.Start()
+	require.NoError(t, exporter.Close())
+	assert.Len(t, c.received("/v1/metrics"), 1)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer failing.Close()
+
+	exporter, err = NewExporter(eng, Config{Endpoint: failing.URL})
+	require.NoError(t, err)
+	err = exporter.Close()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unauthorized")
+}
+
+func TestNewExporter_InvalidEndpoint(t *testing.T) {
+	for _, endpoint := range []string{"", "localhost:4318", "grpc://localhost:4317"} {
+		_, err := NewExporter(nil, Config{Endpoint: endpoint})
+		assert.Error(t, err, endpoint)
+	}
+}
+
+func TestTraceOutput_LinksSpansToTraceparent(t *testing.T) {
+	c := newCollector(t)
+	out, err := NewTraceOutput(Config{Endpoint: c.URL}, stream.Config{BatchSize: 5})
+	require.NoError(t, err)
+
+	_, headers := runEngine(t, true, out)
+	require.NoError(t, out.Close())
+	assert.Equal(t, stream.Stats{Written: 12}, out.Stats())
+
+	sent := make(map[string]bool)
+	for _, header := range headers {
+		parts := strings.Split(header, "-")
+		require.Len(t, parts, 4, "malformed traceparent %q", header)
+		assert.Equal(t, "00", parts[0])
+		assert.Len(t, parts[1], 32)
+		assert.Len(t, parts[2], 16)
+		assert.Equal(t, "01", parts[3])
+		sent[parts[1]+"/"+parts[2]] = true
+	}
+	require.Len(t, sent, 12)
+
+	var spans []map[string]any
+	for _, payload := range c.received("/v1/traces") {
+		for _, s := range path(payload, "resourceSpans", 0, "scopeSpans", 0, "spans").([]any) {
+			spans = append(spans, s.(map[string]any))
+		}
+	}
+	require.Len(t, spans, 12)
+
+	for _, s := range spans {
+		assert.True(t, sent[s["traceId"].(string)+"/"+s["spanId"].(string)], "span not linked to a request")
+		assert.Equal(t, 3.0, s["kind"])
+
+		a := attrs(t, s["attributes"])
+		assert.Equal(t, "api", a["lunge.scenario"])
+		assert.Equal(t, "GET", a["http.request.method"])
+		assert.Contains(t, a, "lunge.vu")
+		assert.Contains(t, a, "lunge.timings.waiting")
+
+		switch s["name"] {
+		case "ok":
+			assert.Equal(t, "200", a["http.response.status_code"])
+			assert.Equal(t, 1.0, path(s, "status", "code"))
+		case "fail":
+			assert.Equal(t, "500", a["http.response.status_code"])
+			assert.Equal(t, 2.0, path(s, "status", "code"))
+		default:
+			t.Errorf("unexpected span %v", s["name"])
+		}
+	}
+}
+
+func TestSampleSpan_WithoutTraceparent(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	s := sampleSpan(metrics.Sample{
+		Time:     start,
+		Method:   "POST",
+		Duration: 250 * time.Millisecond,
+		Error:    "connection refused",
+	})
+
+	assert.Len(t, s.TraceID, 32)
+	assert.Len(t, s.SpanID, 16)
+	assert.Equal(t, "POST", s.Name)
+	assert.Equal(t, "1700000000000000000", s.StartTimeUnixNano)
+	assert.Equal(t, "1700000000250000000", s.EndTimeUnixNano)
+	assert.Equal(t, spanStatus{Code: statusCodeError, Message: "connection refused"}, s.Status)
+}
+
+func TestHistogramPoint(t *testing.T) {
+	dp := histogramPoint(metrics.Histogram{
+		Bounds: []time.Duration{100 * time.Millisecond, time.Second},
+		Counts: []int64{2, 3},
+		Count:  4,
+		Sum:    2500 * time.Millisecond,
+	})
+
+	assert.Equal(t, []float64{100, 1000}, dp.ExplicitBounds)
+	assert.Equal(t, []string{"2", "1", "1"}, dp.BucketCounts)
+	assert.Equal(t, "4", dp.Count)
+	assert.Equal(t, 2500.0, dp.Sum)
+}
@@ -0,0 +1,151 @@
+package otlp
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/stream"
+)
+
+// OTLP span kind and status codes.
+const (
+	spanKindClient  = 3
+	statusCodeOK    = 1
+	statusCodeError = 2
+)
+
+type span struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes"`
+	Status            spanStatus `json:"status"`
+}
+
+type spanStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// traceSink encodes request samples as OTLP spans and posts them in
+// batches. Buckets are skipped.
+type traceSink struct {
+	client *client
+	prefix []byte // Envelope up to the spans array
+}
+
+// NewTraceOutput creates a stream output that exports a span per request
+// to {endpoint}/v1/traces. Add it to the engine as a listener.
+func NewTraceOutput(config Config, streamConfig stream.Config) (*stream.Output, error) {
+	config = config.withDefaults()
+	c, err := newClient(config, "traces")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := json.Marshal(serviceResource())
+	if err != nil {
+		return nil, err
+	}
+	prefix := `{"resourceSpans":[{"resource":` + string(res) +
+		`,"scopeSpans":[{"scope":{"name":"` + ServiceName + `"},"spans":[`
+
+	return stream.NewOutput("otlp", c.url, &traceSink{client: c, prefix: []byte(prefix)}, streamConfig), nil
+}
+
+func (s *traceSink) EncodeSample(buf []byte, sample metrics.Sample) []byte {
+	data, err := json.Marshal(sampleSpan(sample))
+	if err != nil {
+		return buf
+	}
+	buf = append(buf, data...)
+	return append(buf, ',')
+}
+
+func (s *traceSink) EncodeBucket(buf []byte, bucket *metrics.TimeBucket) []byte {
+	return buf
+}
+
+// Write wraps the encoded spans, each followed by a comma, in an export
+// request.
+func (s *traceSink) Write(batch []byte) error {
+	body := make([]byte, 0, len(s.prefix)+len(batch)+8)
+	body = append(body, s.prefix...)
+	body = append(body, batch[:len(batch)-1]...)
+	body = append(body, "]}]}]}"...)
+	return s.client.post(body)
+}
+
+func (s *traceSink) Close() error {
+	s.client.http.CloseIdleConnections()
+	return nil
+}
+
+// sampleSpan converts a request sample to a client span.
+func sampleSpan(sample metrics.Sample) span {
+	traceID, spanID := sample.TraceID, sample.SpanID
+	if traceID == "" || spanID == "" {
+		traceID, spanID = newIDs()
+	}
+
+	name := sample.Name
+	if name == "" {
+		name = sample.Method
+	}
+
+	attrs := []keyValue{
+		stringAttr("lunge.scenario", sample.Scenario),
+		intAttr("lunge.vu", int64(sample.VU)),
+		intAttr("lunge.iteration", sample.Iteration),
+		stringAttr("http.request.method", sample.Method),
+		intAttr("http.response.body.size", sample.Bytes),
+	}
+	if sample.Status != 0 {
+		attrs = append(attrs, intAttr("http.response.status_code", int64(sample.Status)))
+	}
+	if t := sample.Timings; t != nil {
+		attrs = append(attrs,
+			doubleAttr("lunge.timings.blocked", millis(t.Blocked)),
+			doubleAttr("lunge.timings.dns", millis(t.DNS)),
+			doubleAttr("lunge.timings.connect", millis(t.Connect)),
+			doubleAttr("lunge.timings.tls", millis(t.TLS)),
+			doubleAttr("lunge.timings.sending", millis(t.Sending)),
+			doubleAttr("lunge.timings.waiting", millis(t.Waiting)),
+			doubleAttr("lunge.timings.receiving", millis(t.Receiving)),
+		)
+	}
+
+	status := spanStatus{Code: statusCodeOK}
+	if !sample.Success {
+		status.Code = statusCodeError
+		status.Message = sample.Error
+		if status.Message == "" && sample.Status != 0 {
+			status.Message = "unexpected status " + strconv.Itoa(sample.Status)
+		}
+	}
+
+	return span{
+		TraceID:           traceID,
+		SpanID:            spanID,
+		Name:              name,
+		Kind:              spanKindClient,
+		StartTimeUnixNano: unixNano(sample.Time),
+		EndTimeUnixNano:   unixNano(sample.Time.Add(sample.Duration)),
+		Attributes:        attrs,
+		Status:            status,
+	}
+}
+
+// newIDs returns a random trace ID and span ID for a request that sent no
+// traceparent header.
+func newIDs() (traceID, spanID string) {
+	var b [24]byte
+	_, _ = cryptorand.Read(b[:])
+	return hex.EncodeToString(b[:16]), hex.EncodeToString(b[16:])
+}
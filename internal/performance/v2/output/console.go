@@ -68,6 +68,9 @@ type LiveStats struct {
 	Errors        int64   // Total errors
 	ErrorRate     float64 // Error rate (0.0 to 1.0)
 
+	// Iterations dropped by arrival-rate executors (all VUs busy)
+	DroppedIterations int64
+
 	// Latency stats
 	LatencyP95 time.Duration // P95 latency
 	LatencyAvg time.Duration // Average latency
@@ -287,6 +290,12 @@ func (c *ConsoleOutput) renderLiveStats(stats *LiveStats) []string {
 	avgStr := fmt.Sprintf("Avg:         %s", c.colorize(formatDurationShort(stats.LatencyAvg), colorBlue))
 	lines = append(lines, c.formatBoxRow(p95Str, avgStr, boxWidth))
 
+	// Dropped iterations row (only shown once iterations start dropping)
+	if stats.DroppedIterations > 0 {
+		droppedStr := fmt.Sprintf("Dropped: %s", c.colorize(formatNumber(stats.DroppedIterations), colorYellow))
+		lines = append(lines, c.formatBoxRow(droppedStr, "", boxWidth))
+	}
+
 	// Bottom border
 	lines = append(lines, c.colorize(boxBottomLeft+strings.Repeat(boxHorizontal, boxWidth-2)+boxBottomRight, colorDim))
 
@@ -339,7 +348,9 @@ func (c *ConsoleOutput) renderProgressBar(progress float64, width int) string {
 func (c *ConsoleOutput) PrintSummary(result *engine.TestResult) {
 	if c.quiet {
 		// In quiet mode, just print passed/failed status
-		if result.Passed {
+		if result.Aborted {
+			c.writeln(c.colorize("ABORTED: "+result.AbortReason, colorRed))
+		} else if result.Passed {
 			c.writeln(c.colorize("PASSED", colorGreen))
 		} else {
 			c.writeln(c.colorize("FAILED", colorRed))
@@ -363,7 +374,10 @@ func (c *ConsoleOutput) PrintSummary(result *engine.TestResult) {
 	line := strings.Repeat(boxHorizontal, 56)
 	status := "Completed ✓"
 	statusColor := colorGreen
-	if !result.Passed {
+	if result.Aborted {
+		status = "Aborted ✗"
+		statusColor = colorRed
+	} else if !result.Passed {
 		status = "Failed ✗"
 		statusColor = colorRed
 	}
@@ -376,6 +390,10 @@ func (c *ConsoleOutput) PrintSummary(result *engine.TestResult) {
 	c.writeln(c.colorize(line, colorCyan))
 	c.writeln("")
 
+	if result.AbortReason != "" {
+		c.writeln(fmt.Sprintf("Abort Reason:  %s", c.colorize(result.AbortReason, colorRed)))
+	}
+
 	// Duration and request summary
 	c.writeln(fmt.Sprintf("Duration:      %s", c.colorize(formatDuration(result.Duration), colorCyan)))
 	if result.Metrics != nil {
@@ -390,6 +408,15 @@ func (c *ConsoleOutput) PrintSummary(result *engine.TestResult) {
 			successColor = colorRed
 		}
 		c.writeln(fmt.Sprintf("Success Rate:  %s", c.colorize(fmt.Sprintf("%.1f%%", successRate*100), successColor)))
+		if result.Metrics.TimedOutRequests > 0 {
+			c.writeln(fmt.Sprintf("Timeouts:      %s", c.colorize(formatNumber(result.Metrics.TimedOutRequests), colorYellow)))
+		}
+		if result.Metrics.ExtractionFailures > 0 {
+			c.writeln(fmt.Sprintf("Extract Fails: %s", c.colorize(formatNumber(result.Metrics.ExtractionFailures), colorYellow)))
+		}
+		if result.Metrics.DroppedIterations > 0 {
+			c.writeln(fmt.Sprintf("Dropped Iters: %s", c.colorize(formatNumber(result.Metrics.DroppedIterations), colorYellow)))
+		}
 	}
 	c.writeln("")
 
@@ -405,6 +432,100 @@ func (c *ConsoleOutput) PrintSummary(result *engine.TestResult) {
 		c.writeln("")
 	}
 
+	// HTTP phase timings
+	if result.Metrics != nil && result.Metrics.Timings != nil {
+		c.writeln(c.colorize("Request Timings:", colorBold))
+		c.writeln(fmt.Sprintf("  %-15s %8s %8s %8s %8s", "", "Avg", "P50", "P95", "P99"))
+		for _, phase := range result.Metrics.Timings.Phases() {
+			c.writeln(fmt.Sprintf("  %-15s %8s %8s %8s %8s", phase.Name+":",
+				formatDurationShort(phase.Mean), formatDurationShort(phase.P50),
+				formatDurationShort(phase.P95), formatDurationShort(phase.P99)))
+		}
+		c.writeln("")
+	}
+
+	// Request groups
+	if result.Metrics != nil && len(result.Metrics.Groups) > 0 {
+		c.writeln(c.colorize("Groups:", colorBold))
+		c.writeln(fmt.Sprintf("  %-24s %8s %8s %8s %8s", "", "Runs", "Avg", "P95", "P99"))
+		for _, group := range result.Metrics.Groups {
+			c.writeln(fmt.Sprintf("  %-24s %8s %8s %8s %8s", group.Name+":", formatNumber(group.Duration.Count),
+				formatDurationShort(group.Duration.Mean), formatDurationShort(group.Duration.P95),
+				formatDurationShort(group.Duration.P99)))
+		}
+		c.writeln("")
+	}
+
+	// Target and actual mix of weighted flows
+	for _, name := range result.ScenarioNames() {
+		scenario := result.Scenarios[name]
+		if len(scenario.Mix) == 0 {
+			continue
+		}
+		c.writeln(c.colorize(fmt.Sprintf("Traffic Mix (%s):", name), colorBold))
+		c.writeln(fmt.Sprintf("  %-24s %8s %8s %10s", "", "Target", "Actual", "Iterations"))
+		for _, m := range scenario.Mix {
+			c.writeln(fmt.Sprintf("  %-24s %7.1f%% %7.1f%% %10s", m.Name+":",
+				m.Target*100, m.Actual*100, formatNumber(m.Iterations)))
+		}
+		c.writeln("")
+	}
+
+	// Changes made through the control API
+	if len(result.ControlEvents) > 0 {
+		c.writeln(c.colorize("Control Events:", colorBold))
+		for _, ev := range result.ControlEvents {
+			c.writeln(fmt.Sprintf("  %8s  %s", formatDuration(ev.Elapsed), ev))
+		}
+		c.writeln("")
+	}
+
+	// Status code distribution
+	if result.Metrics != nil && len(result.Metrics.StatusCodes) > 0 {
+		c.writeln(c.colorize("Status Codes:", colorBold))
+		for _, sc := range metrics.SortStatusCodes(result.Metrics.StatusCodes) {
+			codeColor := colorGreen
+			if sc.Code >= 400 {
+				codeColor = colorRed
+			} else if sc.Code >= 300 {
+				codeColor = colorYellow
+			}
+			c.writeln(fmt.Sprintf("  %s  %s", c.colorize(fmt.Sprintf("%d", sc.Code), codeColor), formatNumber(sc.Count)))
+		}
+		c.writeln("")
+	}
+
+	// Request errors
+	if result.Metrics != nil && len(result.Metrics.ErrorCategories) > 0 {
+		c.writeln(c.colorize("Errors:", colorBold))
+		for _, cc := range metrics.SortErrorCategories(result.Metrics.ErrorCategories) {
+			c.writeln(fmt.Sprintf("  %-20s %s", string(cc.Category)+":", c.colorize(formatNumber(cc.Count), colorRed)))
+		}
+		if len(result.Metrics.TopErrors) > 0 {
+			c.writeln("  Top messages:")
+			for _, e := range result.Metrics.TopErrors {
+				c.writeln(fmt.Sprintf("    %s x %s (%s)", formatNumber(e.Count), e.Message, e.Category))
+			}
+		}
+		c.writeln("")
+	}
+
+	// Checks
+	if result.Metrics != nil && len(result.Metrics.Checks) > 0 {
+		passes, fails := metrics.ChecksTotals(result.Metrics.Checks)
+		c.writeln(c.colorize(fmt.Sprintf("Checks: %s passed, %s failed",
+			formatNumber(passes), formatNumber(fails)), colorBold))
+		for _, check := range result.Metrics.Checks {
+			status := c.colorize("✓", colorGreen)
+			if check.Fails > 0 {
+				status = c.colorize("✗", colorRed)
+			}
+			c.writeln(fmt.Sprintf("  %s %s (%.1f%% - %s / %s)", status, check.Name,
+				check.PassRate*100, formatNumber(check.Passes), formatNumber(check.Passes+check.Fails)))
+		}
+		c.writeln("")
+	}
+
 	// Thresholds
 	if len(result.Thresholds) > 0 {
 		c.writeln(c.colorize("Thresholds:", colorBold))
@@ -413,7 +534,11 @@ func (c *ConsoleOutput) PrintSummary(result *engine.TestResult) {
 			if !t.Passed {
 				status = c.colorize("✗", colorRed)
 			}
-			c.writeln(fmt.Sprintf("  %s %s %s (actual: %s)", status, t.Metric, t.Expression, t.Value))
+			line := fmt.Sprintf("  %s %s %s (actual: %s)", status, t.Metric, t.Expression, t.Value)
+			if t.Aborted {
+				line += c.colorize(" - aborted the test", colorRed)
+			}
+			c.writeln(line)
 		}
 		c.writeln("")
 	}
@@ -430,7 +555,7 @@ func (c *ConsoleOutput) PrintNonInteractiveUpdate(stats *LiveStats) {
 	defer c.mu.Unlock()
 
 	// Simple one-line status for non-TTY
-	c.writeln(fmt.Sprintf("[%s] Progress: %.0f%% | VUs: %d | Reqs: %d | RPS: %.1f | Errors: %d (%.1f%%) | P95: %s",
+	line := fmt.Sprintf("[%s] Progress: %.0f%% | VUs: %d | Reqs: %d | RPS: %.1f | Errors: %d (%.1f%%) | P95: %s",
 		formatDuration(stats.Elapsed),
 		stats.Progress*100,
 		stats.ActiveVUs,
@@ -438,7 +563,11 @@ func (c *ConsoleOutput) PrintNonInteractiveUpdate(stats *LiveStats) {
 		stats.CurrentRPS,
 		stats.Errors,
 		stats.ErrorRate*100,
-		formatDurationShort(stats.LatencyP95)))
+		formatDurationShort(stats.LatencyP95))
+	if stats.DroppedIterations > 0 {
+		line += fmt.Sprintf(" | Dropped: %d", stats.DroppedIterations)
+	}
+	c.writeln(line)
 }
 
 // IsTTY returns whether the output is a terminal.
@@ -591,5 +720,7 @@ func StatsFromMetrics(
 		CurrentPhase:  string(metricsSnapshot.CurrentPhase),
 		CurrentStage:  currentStage,
 		TotalStages:   totalStages,
+
+		DroppedIterations: metricsSnapshot.DroppedIterations,
 	}
 }
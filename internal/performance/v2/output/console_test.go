@@ -211,6 +211,295 @@ func TestPrintSummary(t *testing.T) {
 	}
 }
 
+func TestPrintSummaryChecks(t *testing.T) {
+	var buf bytes.Buffer
+
+	output := NewConsoleOutput(ConsoleOutputConfig{
+		TestName: "Test",
+		Writer:   &buf,
+	})
+
+	result := &engine.TestResult{
+		Name:     "Checks Result",
+		Duration: 10 * time.Second,
+		Passed:   true,
+		Metrics: &metrics.Snapshot{
+			TotalRequests: 100,
+			Checks: []metrics.CheckStats{
+				{Name: "Get: status eq 200", Passes: 100, Fails: 0, PassRate: 1.0},
+				{Name: "Get: body $.ok eq true", Passes: 90, Fails: 10, PassRate: 0.9},
+			},
+		},
+	}
+
+	output.PrintSummary(result)
+
+	summary := buf.String()
+
+	if !strings.Contains(summary, "Checks: 190 passed, 10 failed") {
+		t.Errorf("Summary should contain check totals, got:\n%s", summary)
+	}
+	if !strings.Contains(summary, "Get: status eq 200 (100.0% - 100 / 100)") {
+		t.Error("Summary should contain passing check")
+	}
+	if !strings.Contains(summary, "Get: body $.ok eq true (90.0% - 90 / 100)") {
+		t.Error("Summary should contain failing check")
+	}
+	if strings.Contains(summary, "Extract Fails") {
+		t.Error("Summary should not show extraction failures when there are none")
+	}
+}
+
+func TestPrintSummaryExtractionFailures(t *testing.T) {
+	var buf bytes.Buffer
+
+	output := NewConsoleOutput(ConsoleOutputConfig{
+		TestName: "Test",
+		Writer:   &buf,
+	})
+
+	output.PrintSummary(&engine.TestResult{
+		Name:     "Extraction Result",
+		Duration: 10 * time.Second,
+		Passed:   true,
+		Metrics: &metrics.Snapshot{
+			TotalRequests:      100,
+			ExtractionFailures: 1234,
+		},
+	})
+
+	if !strings.Contains(buf.String(), "Extract Fails: 1,234") {
+		t.Errorf("Summary should show extraction failures, got:\n%s", buf.String())
+	}
+}
+
+func TestPrintSummaryTimings(t *testing.T) {
+	var buf bytes.Buffer
+
+	output := NewConsoleOutput(ConsoleOutputConfig{
+		TestName: "Test",
+		Writer:   &buf,
+	})
+
+	result := &engine.TestResult{
+		Name:     "Timings Result",
+		Duration: 10 * time.Second,
+		Passed:   true,
+		Metrics:  &metrics.Snapshot{TotalRequests: 100},
+	}
+	output.PrintSummary(result)
+	if strings.Contains(buf.String(), "Request Timings:") {
+		t.Errorf("Summary should not show timings when none were recorded, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	result.Metrics.Timings = &metrics.TimingStats{
+		Waiting: metrics.LatencyStats{Mean: 120 * time.Millisecond, P95: 180 * time.Millisecond},
+	}
+	output.PrintSummary(result)
+
+	for _, expected := range []string{"Request Timings:", "TLS Handshake:", "Waiting:", "120ms", "180ms"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Summary should contain %q, got:\n%s", expected, buf.String())
+		}
+	}
+}
+
+func TestPrintSummaryGroups(t *testing.T) {
+	var buf bytes.Buffer
+
+	output := NewConsoleOutput(ConsoleOutputConfig{
+		TestName: "Test",
+		Writer:   &buf,
+	})
+
+	result := &engine.TestResult{
+		Name:     "Groups Result",
+		Duration: 10 * time.Second,
+		Passed:   true,
+		Metrics:  &metrics.Snapshot{TotalRequests: 100},
+	}
+	output.PrintSummary(result)
+	if strings.Contains(buf.String(), "Groups:") {
+		t.Errorf("Summary should not show groups when there are none, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	result.Metrics.Groups = []metrics.GroupStats{
+		{Name: "checkout flow", Duration: metrics.LatencyStats{Count: 1200, Mean: 850 * time.Millisecond, P95: 1400 * time.Millisecond}},
+	}
+	output.PrintSummary(result)
+
+	for _, expected := range []string{"Groups:", "checkout flow:", "1,200", "850ms", "1.40s"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Summary should contain %q, got:\n%s", expected, buf.String())
+		}
+	}
+}
+
+func TestPrintSummaryTrafficMix(t *testing.T) {
+	var buf bytes.Buffer
+
+	output := NewConsoleOutput(ConsoleOutputConfig{
+		TestName: "Test",
+		Writer:   &buf,
+	})
+
+	result := &engine.TestResult{
+		Name:     "Mix Result",
+		Duration: 10 * time.Second,
+		Passed:   true,
+		Metrics:  &metrics.Snapshot{TotalRequests: 1000},
+		Scenarios: map[string]*engine.ScenarioResult{
+			"browse": {Name: "browse"},
+			"shop": {Name: "shop", Mix: []engine.MixStats{
+				{Name: "search", Weight: 70, Target: 0.7, Iterations: 1380, Actual: 0.69},
+				{Name: "purchase", Weight: 30, Target: 0.3, Iterations: 620, Actual: 0.31},
+			}},
+		},
+	}
+	output.PrintSummary(result)
+
+	for _, expected := range []string{"Traffic Mix (shop):", "search:", "70.0%", "69.0%", "1,380", "purchase:", "31.0%"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Summary should contain %q, got:\n%s", expected, buf.String())
+		}
+	}
+	if strings.Contains(buf.String(), "Traffic Mix (browse)") {
+		t.Errorf("Summary should not show a mix for sequential scenarios, got:\n%s", buf.String())
+	}
+}
+
+func TestPrintSummaryControlEvents(t *testing.T) {
+	var buf bytes.Buffer
+
+	output := NewConsoleOutput(ConsoleOutputConfig{
+		TestName: "Test",
+		Writer:   &buf,
+	})
+
+	result := &engine.TestResult{
+		Name:        "Controlled Result",
+		Duration:    90 * time.Second,
+		Aborted:     true,
+		AbortReason: "stopped by control API",
+		Metrics:     &metrics.Snapshot{TotalRequests: 100},
+		ControlEvents: []engine.ControlEvent{
+			{Elapsed: 10 * time.Second, Scenario: "explore", Action: engine.ControlVUs, Value: 20},
+			{Elapsed: 30 * time.Second, Scenario: "explore", Action: engine.ControlPause},
+			{Elapsed: 80 * time.Second, Action: engine.ControlStop},
+		},
+	}
+	output.PrintSummary(result)
+
+	for _, expected := range []string{"Control Events:", "10.0s  explore: vus 20", "explore: pause", "1m 20s  stop"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Summary should contain %q, got:\n%s", expected, buf.String())
+		}
+	}
+}
+
+func TestPrintSummaryStatusCodesAndErrors(t *testing.T) {
+	var buf bytes.Buffer
+
+	output := NewConsoleOutput(ConsoleOutputConfig{
+		TestName: "Test",
+		Writer:   &buf,
+	})
+
+	result := &engine.TestResult{
+		Name:     "Errors Result",
+		Duration: 10 * time.Second,
+		Passed:   true,
+		Metrics:  &metrics.Snapshot{TotalRequests: 100},
+	}
+	output.PrintSummary(result)
+	for _, unexpected := range []string{"Status Codes:", "Errors:"} {
+		if strings.Contains(buf.String(), unexpected) {
+			t.Errorf("Summary should not contain %q without responses or errors, got:\n%s", unexpected, buf.String())
+		}
+	}
+
+	buf.Reset()
+	result.Metrics.StatusCodes = map[int]int64{200: 1500, 503: 12}
+	result.Metrics.ErrorCategories = map[metrics.ErrorCategory]int64{metrics.ErrorConnectionRefused: 7}
+	result.Metrics.TopErrors = []metrics.ErrorStats{
+		{Category: metrics.ErrorConnectionRefused, Message: "connect: connection refused", Count: 7},
+	}
+	output.PrintSummary(result)
+
+	for _, expected := range []string{"Status Codes:", "200", "1,500", "503", "Errors:", "connection_refused:", "7 x connect: connection refused"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Summary should contain %q, got:\n%s", expected, buf.String())
+		}
+	}
+	if strings.Index(buf.String(), "200") > strings.Index(buf.String(), "503") {
+		t.Errorf("Status codes should be listed in order, got:\n%s", buf.String())
+	}
+}
+
+func TestPrintSummaryAborted(t *testing.T) {
+	var buf bytes.Buffer
+
+	output := NewConsoleOutput(ConsoleOutputConfig{
+		TestName: "Test",
+		Writer:   &buf,
+	})
+
+	output.PrintSummary(&engine.TestResult{
+		Name:        "Aborted Result",
+		Duration:    3 * time.Second,
+		Aborted:     true,
+		AbortReason: "threshold http_req_failed rate < 0.01 failed (actual: 0.5000)",
+		Metrics:     &metrics.Snapshot{TotalRequests: 10},
+		Thresholds: []engine.ThresholdResult{
+			{Metric: "http_req_failed", Expression: "rate < 0.01", Value: "0.5000", AbortOnFail: true, Aborted: true},
+		},
+	})
+
+	text := stripANSI(buf.String())
+	for _, want := range []string{"Aborted ✗", "Abort Reason:  threshold http_req_failed", "aborted the test"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Summary should contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestDroppedIterationsDisplay(t *testing.T) {
+	var buf bytes.Buffer
+
+	output := NewConsoleOutput(ConsoleOutputConfig{
+		TestName: "Test",
+		Writer:   &buf,
+	})
+
+	snapshot := &metrics.Snapshot{
+		TotalRequests:     100,
+		DroppedIterations: 42,
+	}
+
+	stats := StatsFromMetrics(snapshot, 0.5, time.Minute, 10, 1, 1)
+	if stats.DroppedIterations != 42 {
+		t.Errorf("DroppedIterations = %d, want 42", stats.DroppedIterations)
+	}
+
+	output.PrintNonInteractiveUpdate(stats)
+	if !strings.Contains(buf.String(), "Dropped: 42") {
+		t.Errorf("Live update should show dropped iterations, got:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	output.PrintSummary(&engine.TestResult{
+		Name:     "Dropped Result",
+		Duration: 10 * time.Second,
+		Passed:   true,
+		Metrics:  snapshot,
+	})
+	if !strings.Contains(buf.String(), "Dropped Iters: 42") {
+		t.Errorf("Summary should show dropped iterations, got:\n%s", buf.String())
+	}
+}
+
 func TestStatsFromMetrics(t *testing.T) {
 	snapshot := &metrics.Snapshot{
 		TotalRequests:   500,
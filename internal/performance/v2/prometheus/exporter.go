@@ -0,0 +1,238 @@
+// Package prometheus exposes the metrics of a running performance test in
+// the Prometheus text exposition format, so load generator metrics can be
+// scraped and shown next to the metrics of the system under test.
+//
+// The server answers GET /metrics with:
+//
+//	lunge_test_running                       1 while the test runs
+//	lunge_vus{scenario}                      active VUs
+//	lunge_iterations_total{scenario}         completed iterations
+//	lunge_dropped_iterations_total{scenario} iterations that could not start
+//	lunge_http_reqs_total{scenario,name,status}
+//	lunge_http_req_failed_total{scenario,name,status}
+//	lunge_http_req_errors_total{scenario,category}
+//	lunge_http_req_duration_seconds{scenario,name}  histogram
+//
+// The status label is "0" for requests that received no response.
+package prometheus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wesleyorama2/lunge/internal/performance/v2/engine"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/serve"
+)
+
+// ContentType is the media type of the text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are the upper bounds of the latency histogram buckets.
+var DefaultBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// Server serves the metrics of an engine for Prometheus to scrape.
+type Server struct {
+	engine *engine.Engine
+	http   *serve.Server
+}
+
+// NewServer creates a Prometheus metrics server for an engine. Call Start
+// to listen.
+func NewServer(eng *engine.Engine) *Server {
+	s := &Server{engine: eng}
+	s.http = serve.New(s.Handler())
+	return s
+}
+
+// Handler returns the HTTP handler of the metrics endpoint.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", s.handleMetrics)
+	return mux
+}
+
+// Start listens on addr (e.g. ":9464" or "127.0.0.1:0") and serves the
+// endpoint in the background.
+func (s *Server) Start(addr string) error {
+	return s.http.Start(addr)
+}
+
+// Addr returns the address the server listens on, once started.
+func (s *Server) Addr() string {
+	return s.http.Addr()
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	return s.http.Close()
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	_ = Write(w, s.engine)
+}
+
+// Write writes the current metrics of an engine in the text exposition
+// format.
+func Write(w io.Writer, eng *engine.Engine) error {
+	out := bufio.NewWriter(w)
+
+	running := 0.0
+	if eng.IsRunning() {
+		running = 1
+	}
+	writeFamily(out, "lunge_test_running", "gauge", "Whether the test is running (1) or not (0).")
+	writeSample(out, "lunge_test_running", nil, running)
+
+	sources := eng.GetScenarioMetricsEngines()
+	stats := eng.GetScenarioStats()
+
+	scenarios := make([]string, 0, len(sources))
+	for name := range sources {
+		scenarios = append(scenarios, name)
+	}
+	sort.Strings(scenarios)
+
+	snapshots := make(map[string]*metrics.Snapshot, len(sources))
+	for name, source := range sources {
+		snapshots[name] = source.GetSnapshot()
+	}
+
+	writeFamily(out, "lunge_vus", "gauge", "Active virtual users.")
+	for _, scenario := range scenarios {
+		writeSample(out, "lunge_vus", labels("scenario", scenario), float64(snapshots[scenario].ActiveVUs))
+	}
+
+	writeFamily(out, "lunge_iterations_total", "counter", "Completed iterations.")
+	for _, scenario := range scenarios {
+		if st, ok := stats[scenario]; ok {
+			writeSample(out, "lunge_iterations_total", labels("scenario", scenario), float64(st.Iterations))
+		}
+	}
+
+	writeFamily(out, "lunge_dropped_iterations_total", "counter", "Iterations that could not start because every VU was busy.")
+	for _, scenario := range scenarios {
+		writeSample(out, "lunge_dropped_iterations_total", labels("scenario", scenario), float64(snapshots[scenario].DroppedIterations))
+	}
+
+	statuses := make(map[string][]metrics.RequestStatusCount, len(sources))
+	for name, source := range sources {
+		statuses[name] = source.GetRequestStatuses()
+	}
+
+	writeFamily(out, "lunge_http_reqs_total", "counter", "HTTP requests made.")
+	for _, scenario := range scenarios {
+		for _, c := range statuses[scenario] {
+			writeSample(out, "lunge_http_reqs_total", requestLabels(scenario, c), float64(c.Requests))
+		}
+	}
+
+	writeFamily(out, "lunge_http_req_failed_total", "counter", "HTTP requests that failed.")
+	for _, scenario := range scenarios {
+		for _, c := range statuses[scenario] {
+			writeSample(out, "lunge_http_req_failed_total", requestLabels(scenario, c), float64(c.Failures))
+		}
+	}
+
+	writeFamily(out, "lunge_http_req_errors_total", "counter", "HTTP requests that received no response, by error category.")
+	for _, scenario := range scenarios {
+		for _, c := range metrics.SortErrorCategories(snapshots[scenario].ErrorCategories) {
+			writeSample(out, "lunge_http_req_errors_total", labels("scenario", scenario, "category", string(c.Category)), float64(c.Count))
+		}
+	}
+
+	writeFamily(out, "lunge_http_req_duration_seconds", "histogram", "HTTP request duration.")
+	for _, scenario := range scenarios {
+		hists := sources[scenario].GetRequestHistograms(DefaultBuckets)
+		names := make([]string, 0, len(hists))
+		for name := range hists {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			writeHistogram(out, "lunge_http_req_duration_seconds", labels("scenario", scenario, "name", name), hists[name])
+		}
+	}
+
+	return out.Flush()
+}
+
+// requestLabels returns the labels of a request status count.
+func requestLabels(scenario string, c metrics.RequestStatusCount) []string {
+	return labels("scenario", scenario, "name", c.Name, "status", strconv.Itoa(c.Status))
+}
+
+// labels pairs up label names and values.
+func labels(pairs ...string) []string {
+	return pairs
+}
+
+func writeFamily(w *bufio.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func writeSample(w *bufio.Writer, name string, labelPairs []string, value float64) {
+	w.WriteString(name)
+	writeLabels(w, labelPairs)
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(value))
+	w.WriteByte('\n')
+}
+
+// writeHistogram writes the bucket, sum and count samples of a histogram.
+func writeHistogram(w *bufio.Writer, name string, labelPairs []string, h metrics.Histogram) {
+	bucket := func(le string) []string {
+		return append(append([]string(nil), labelPairs...), "le", le)
+	}
+
+	for i, bound := range h.Bounds {
+		writeSample(w, name+"_bucket", bucket(formatFloat(bound.Seconds())), float64(h.Counts[i]))
+	}
+	writeSample(w, name+"_bucket", bucket("+Inf"), float64(h.Count))
+	writeSample(w, name+"_sum", labelPairs, h.Sum.Seconds())
+	writeSample(w, name+"_count", labelPairs, float64(h.Count))
+}
+
+func writeLabels(w *bufio.Writer, labelPairs []string) {
+	if len(labelPairs) == 0 {
+		return
+	}
+	w.WriteByte('{')
+	for i := 0; i+1 < len(labelPairs); i += 2 {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		w.WriteString(labelPairs[i])
+		w.WriteString(`="`)
+		w.WriteString(labelEscaper.Replace(labelPairs[i+1]))
+		w.WriteByte('"')
+	}
+	w.WriteByte('}')
+}
+
+// labelEscaper escapes label values as the exposition format requires.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,176 @@
+package prometheus
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/config"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/engine"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+)
+
+// runEngine runs a short test with a succeeding and a failing request.
+func runEngine(t *testing.T) *engine.Engine {
+	t.Helper()
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(2 * time.Millisecond)
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer target.Close()
+
+	eng, err := engine.NewEngine(&config.TestConfig{
+		Name: "Prometheus Test",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"api": {
+				Executor: "constant-vus",
+				VUs:      2,
+				Duration: "300ms",
+				Requests: []config.RequestConfig{
+					{Name: "ok", Method: "GET", URL: target.URL + "/ok"},
+					{Name: "fail", Method: "GET", URL: target.URL + "/fail"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = eng.Run(context.Background())
+	require.NoError(t, err)
+	return eng
+}
+
+// scrape fetches the metrics endpoint and returns the samples by series.
+func scrape(t *testing.T, url string) map[string]float64 {
+	t.Helper()
+
+	resp, err := http.Get(url + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, ContentType, resp.Header.Get("Content-Type"))
+
+	samples := make(map[string]float64)
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		idx := strings.LastIndex(line, " ")
+		require.Greater(t, idx, 0, "malformed sample %q", line)
+		value, err := strconv.ParseFloat(line[idx+1:], 64)
+		require.NoError(t, err, "malformed sample %q", line)
+		samples[line[:idx]] = value
+	}
+	require.NoError(t, scanner.Err())
+	return samples
+}
+
+func TestServer_Metrics(t *testing.T) {
+	eng := runEngine(t)
+	server := httptest.NewServer(NewServer(eng).Handler())
+	defer server.Close()
+
+	samples := scrape(t, server.URL)
+
+	assert.Equal(t, 0.0, samples["lunge_test_running"])
+	assert.Contains(t, samples, `lunge_vus{scenario="api"}`)
+	assert.Greater(t, samples[`lunge_iterations_total{scenario="api"}`], 0.0)
+	assert.Equal(t, 0.0, samples[`lunge_dropped_iterations_total{scenario="api"}`])
+
+	ok := samples[`lunge_http_reqs_total{scenario="api",name="ok",status="200"}`]
+	fail := samples[`lunge_http_reqs_total{scenario="api",name="fail",status="500"}`]
+	assert.Greater(t, ok, 0.0)
+	assert.Greater(t, fail, 0.0)
+	assert.Equal(t, 0.0, samples[`lunge_http_req_failed_total{scenario="api",name="ok",status="200"}`])
+	assert.Equal(t, fail, samples[`lunge_http_req_failed_total{scenario="api",name="fail",status="500"}`])
+
+	// Histogram buckets are cumulative and end with every request of that
+	// name, including any cut off at the end of the test (status 0)
+	total := 0.0
+	for series, value := range samples {
+		if strings.HasPrefix(series, `lunge_http_reqs_total{scenario="api",name="ok",`) {
+			total += value
+		}
+	}
+
+	prefix := `lunge_http_req_duration_seconds_bucket{scenario="api",name="ok",le="`
+	previous := 0.0
+	for _, bound := range DefaultBuckets {
+		count, exists := samples[prefix+formatFloat(bound.Seconds())+`"}`]
+		require.True(t, exists, "missing bucket %v", bound)
+		assert.GreaterOrEqual(t, count, previous)
+		previous = count
+	}
+	assert.Equal(t, total, samples[prefix+`+Inf"}`])
+	assert.Equal(t, total, samples[`lunge_http_req_duration_seconds_count{scenario="api",name="ok"}`])
+	assert.Greater(t, samples[`lunge_http_req_duration_seconds_sum{scenario="api",name="ok"}`], 0.0)
+}
+
+func TestServer_NotStarted(t *testing.T) {
+	eng, err := engine.NewEngine(&config.TestConfig{
+		Name: "Idle",
+		Scenarios: map[string]*config.ScenarioConfig{
+			"idle": {
+				Executor: "constant-vus",
+				VUs:      1,
+				Duration: "1s",
+				Requests: []config.RequestConfig{{Method: "GET", URL: "http://localhost"}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	server := NewServer(eng)
+	require.NoError(t, server.Start("127.0.0.1:0"))
+	defer server.Close()
+
+	samples := scrape(t, "http://"+server.Addr())
+	assert.Equal(t, map[string]float64{"lunge_test_running": 0}, samples)
+
+	// Port already in use
+	assert.Error(t, NewServer(eng).Start(server.Addr()))
+}
+
+func TestWriteLabels_Escaping(t *testing.T) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	writeSample(w, "lunge_vus", labels("scenario", "a \"quoted\"\\path\nnext"), 3)
+	require.NoError(t, w.Flush())
+
+	assert.Equal(t, `lunge_vus{scenario="a \"quoted\"\\path\nnext"} 3`+"\n", buf.String())
+}
+
+func TestWriteHistogram(t *testing.T) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	writeHistogram(w, "lunge_http_req_duration_seconds", labels("name", "get"), metrics.Histogram{
+		Bounds: []time.Duration{100 * time.Millisecond, time.Second},
+		Counts: []int64{2, 3},
+		Count:  4,
+		Sum:    2500 * time.Millisecond,
+	})
+	require.NoError(t, w.Flush())
+
+	want := `lunge_http_req_duration_seconds_bucket{name="get",le="0.1"} 2
+lunge_http_req_duration_seconds_bucket{name="get",le="1"} 3
+lunge_http_req_duration_seconds_bucket{name="get",le="+Inf"} 4
+lunge_http_req_duration_seconds_sum{name="get"} 2.5
+lunge_http_req_duration_seconds_count{name="get"} 4
+`
+	assert.Equal(t, want, buf.String())
+}
@@ -151,19 +151,27 @@ func (lb *LeakyBucket) Next() time.Time {
 //   - nil if the wait completed successfully
 //   - ctx.Err() if the context was cancelled
 func (lb *LeakyBucket) Wait(ctx context.Context) error {
+	_, err := lb.WaitNext(ctx)
+	return err
+}
+
+// WaitNext is like Wait but also returns the time the iteration was
+// scheduled to start. Comparing it with the actual start time shows how
+// late the iteration is.
+func (lb *LeakyBucket) WaitNext(ctx context.Context) (time.Time, error) {
 	nextTime := lb.Next()
 
 	waitDuration := time.Until(nextTime)
 	if waitDuration <= 0 {
 		// Execute immediately
-		return nil
+		return nextTime, nil
 	}
 
 	select {
 	case <-ctx.Done():
-		return ctx.Err()
+		return nextTime, ctx.Err()
 	case <-time.After(waitDuration):
-		return nil
+		return nextTime, nil
 	}
 }
 
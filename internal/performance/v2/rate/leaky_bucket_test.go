@@ -85,6 +85,26 @@ func TestLeakyBucket_Wait_RespectsContext(t *testing.T) {
 	}
 }
 
+func TestLeakyBucket_WaitNext_ReturnsScheduledTime(t *testing.T) {
+	lb := NewLeakyBucket(50.0) // 20ms apart
+
+	// Consume first token
+	_ = lb.Next()
+
+	scheduled, err := lb.WaitNext(context.Background())
+	if err != nil {
+		t.Fatalf("WaitNext() error = %v", err)
+	}
+
+	// We should not wake before the scheduled time
+	if now := time.Now(); now.Before(scheduled) {
+		t.Errorf("WaitNext() returned at %v, before scheduled time %v", now, scheduled)
+	}
+	if lateness := time.Since(scheduled); lateness > 50*time.Millisecond {
+		t.Errorf("WaitNext() lateness = %v, want < 50ms", lateness)
+	}
+}
+
 func TestLeakyBucket_SetRate_NoAccumulation(t *testing.T) {
 	lb := NewLeakyBucket(1000.0) // High rate
 
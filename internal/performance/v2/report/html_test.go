@@ -49,6 +49,175 @@ func TestGenerateHTMLString(t *testing.T) {
 	}
 }
 
+func TestGenerateHTMLStringChecks(t *testing.T) {
+	result := createSampleTestResult()
+
+	html, err := GenerateHTMLString(result)
+	if err != nil {
+		t.Fatalf("GenerateHTMLString failed: %v", err)
+	}
+	if strings.Contains(html, `<h2 class="section-title">Checks</h2>`) {
+		t.Error("HTML should not contain checks section when there are no checks")
+	}
+
+	result.Metrics.Checks = []metrics.CheckStats{
+		{Name: "GET /api/users: status eq 200", Passes: 990, Fails: 10, PassRate: 0.99},
+	}
+
+	html, err = GenerateHTMLString(result)
+	if err != nil {
+		t.Fatalf("GenerateHTMLString failed: %v", err)
+	}
+
+	expectedContents := []string{
+		`<h2 class="section-title">Checks</h2>`,
+		"GET /api/users: status eq 200",
+		"99.00%",
+	}
+	for _, expected := range expectedContents {
+		if !strings.Contains(html, expected) {
+			t.Errorf("HTML does not contain expected content: %s", expected)
+		}
+	}
+}
+
+func TestGenerateHTMLStringTimings(t *testing.T) {
+	result := createSampleTestResult()
+
+	html, err := GenerateHTMLString(result)
+	if err != nil {
+		t.Fatalf("GenerateHTMLString failed: %v", err)
+	}
+	if strings.Contains(html, `<h2 class="section-title">Request Timings</h2>`) {
+		t.Error("HTML should not contain timings section when there are no timings")
+	}
+
+	result.Metrics.Timings = &metrics.TimingStats{
+		Waiting: metrics.LatencyStats{P95: 180 * time.Millisecond},
+	}
+
+	html, err = GenerateHTMLString(result)
+	if err != nil {
+		t.Fatalf("GenerateHTMLString failed: %v", err)
+	}
+
+	for _, expected := range []string{`<h2 class="section-title">Request Timings</h2>`, "DNS Lookup", "Waiting", "180"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("HTML does not contain expected content: %s", expected)
+		}
+	}
+}
+
+func TestGenerateHTMLStringGroups(t *testing.T) {
+	result := createSampleTestResult()
+	result.Metrics.Groups = []metrics.GroupStats{
+		{Name: "checkout flow", Duration: metrics.LatencyStats{Count: 1200, P95: 1400 * time.Millisecond}},
+	}
+
+	html, err := GenerateHTMLString(result)
+	if err != nil {
+		t.Fatalf("GenerateHTMLString failed: %v", err)
+	}
+
+	for _, expected := range []string{`<h2 class="section-title">Groups</h2>`, "<td>checkout flow</td>", "1,200"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("HTML does not contain expected content: %s", expected)
+		}
+	}
+}
+
+func TestGenerateHTMLStringTrafficMix(t *testing.T) {
+	result := createSampleTestResult()
+
+	html, err := GenerateHTMLString(result)
+	if err != nil {
+		t.Fatalf("GenerateHTMLString failed: %v", err)
+	}
+	if strings.Contains(html, "<th>Traffic Mix</th>") {
+		t.Error("HTML should not contain a traffic mix for sequential scenarios")
+	}
+
+	result.Scenarios["default"].Mix = []engine.MixStats{
+		{Name: "search", Weight: 70, Target: 0.7, Iterations: 690, Actual: 0.69},
+		{Name: "purchase", Weight: 30, Target: 0.3, Iterations: 310, Actual: 0.31},
+	}
+
+	html, err = GenerateHTMLString(result)
+	if err != nil {
+		t.Fatalf("GenerateHTMLString failed: %v", err)
+	}
+
+	for _, expected := range []string{"<th>Traffic Mix</th>", "<td>search</td>", "<td>70.0%</td>", "<td>69.0%</td>", "<td>purchase</td>"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("HTML does not contain expected content: %s", expected)
+		}
+	}
+}
+
+func TestGenerateHTMLStringControlEvents(t *testing.T) {
+	result := createSampleTestResult()
+	result.ControlEvents = []engine.ControlEvent{
+		{Elapsed: 5 * time.Second, Scenario: "default", Action: engine.ControlVUs, Value: 20},
+		{Elapsed: 9 * time.Second, Scenario: "default", Action: engine.ControlRate, Value: 12.5},
+		{Elapsed: 12 * time.Second, Action: engine.ControlStop},
+	}
+
+	html, err := GenerateHTMLString(result)
+	if err != nil {
+		t.Fatalf("GenerateHTMLString failed: %v", err)
+	}
+
+	for _, expected := range []string{"Control Events", "<td>vus 20</td>", "<td>rate 12.5/s</td>", "<td>stop</td>"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("HTML does not contain expected content: %s", expected)
+		}
+	}
+}
+
+func TestGenerateHTMLStringStatusCodesAndErrors(t *testing.T) {
+	result := createSampleTestResult()
+	result.Metrics.StatusCodes = map[int]int64{200: 1500, 503: 12}
+	result.Metrics.ErrorCategories = map[metrics.ErrorCategory]int64{metrics.ErrorTimeout: 4}
+	result.Metrics.TopErrors = []metrics.ErrorStats{
+		{Category: metrics.ErrorTimeout, Message: "timed out after 5s", Count: 4},
+	}
+
+	html, err := GenerateHTMLString(result)
+	if err != nil {
+		t.Fatalf("GenerateHTMLString failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		`<h2 class="section-title">Status Codes</h2>`, "<td>503</td>", "1,500",
+		`<h2 class="section-title">Errors</h2>`, "timeout", "timed out after 5s",
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("HTML does not contain expected content: %s", expected)
+		}
+	}
+}
+
+func TestGenerateHTMLStringAborted(t *testing.T) {
+	result := createSampleTestResult()
+	result.Passed = false
+	result.Aborted = true
+	result.AbortReason = "threshold http_req_failed rate < 0.01 failed"
+	result.Thresholds = append(result.Thresholds, engine.ThresholdResult{
+		Metric: "http_req_failed", Expression: "rate < 0.01", AbortOnFail: true, Aborted: true,
+	})
+
+	html, err := GenerateHTMLString(result)
+	if err != nil {
+		t.Fatalf("GenerateHTMLString failed: %v", err)
+	}
+
+	for _, expected := range []string{"✗ ABORTED", "threshold http_req_failed rate &lt; 0.01 failed", "aborted the test"} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("HTML does not contain expected content: %s", expected)
+		}
+	}
+}
+
 func TestGenerateHTMLStringNilResult(t *testing.T) {
 	_, err := GenerateHTMLString(nil)
 	if err == nil {
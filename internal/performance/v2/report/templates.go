@@ -488,11 +488,12 @@ const htmlTemplate = `<!DOCTYPE html>
                 <div class="meta">
                     <span>📅 {{.StartTime.Format "2006-01-02 15:04:05"}}</span>
                     <span>⏱️ {{formatDuration .Duration}}</span>
+                    {{if .AbortReason}}<span>⛔ {{.AbortReason}}</span>{{end}}
                 </div>
             </div>
             <div class="header-right">
-                <div class="status {{if .Passed}}pass{{else}}fail{{end}}">
-                    {{if .Passed}}✓ PASSED{{else}}✗ FAILED{{end}}
+                <div class="status {{if and .Passed (not .Aborted)}}pass{{else}}fail{{end}}">
+                    {{if .Aborted}}✗ ABORTED{{else if .Passed}}✓ PASSED{{else}}✗ FAILED{{end}}
                 </div>
                 <button class="theme-toggle" onclick="toggleTheme()" title="Toggle dark mode">🌙</button>
             </div>
@@ -524,6 +525,18 @@ const htmlTemplate = `<!DOCTYPE html>
                 <div class="label">Data Transferred</div>
                 <div class="value">{{formatBytes .Metrics.TotalBytes}}</div>
             </div>
+            {{if gt .Metrics.TimedOutRequests 0}}
+            <div class="metric-card">
+                <div class="label">Timed Out Requests</div>
+                <div class="value">{{formatNumber .Metrics.TimedOutRequests}}</div>
+            </div>
+            {{end}}
+            {{if gt .Metrics.DroppedIterations 0}}
+            <div class="metric-card">
+                <div class="label">Dropped Iterations</div>
+                <div class="value">{{formatNumber .Metrics.DroppedIterations}}</div>
+            </div>
+            {{end}}
         </div>
 
         <!-- Latency Statistics -->
@@ -565,6 +578,142 @@ const htmlTemplate = `<!DOCTYPE html>
             </div>
         </section>
 
+        <!-- HTTP Phase Timings -->
+        {{if .Metrics.Timings}}
+        <section class="section">
+            <h2 class="section-title">Request Timings</h2>
+            <table class="stats-table">
+                <thead>
+                    <tr>
+                        <th>Phase</th>
+                        <th>Min</th>
+                        <th>Mean</th>
+                        <th>P50</th>
+                        <th>P90</th>
+                        <th>P95</th>
+                        <th>P99</th>
+                        <th>Max</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .Metrics.Timings.Phases}}
+                    <tr>
+                        <td>{{.Name}}</td>
+                        <td>{{formatLatency .Min}}</td>
+                        <td>{{formatLatency .Mean}}</td>
+                        <td>{{formatLatency .P50}}</td>
+                        <td>{{formatLatency .P90}}</td>
+                        <td>{{formatLatency .P95}}</td>
+                        <td>{{formatLatency .P99}}</td>
+                        <td>{{formatLatency .Max}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </section>
+        {{end}}
+
+        <!-- Request Groups -->
+        {{if .Metrics.Groups}}
+        <section class="section">
+            <h2 class="section-title">Groups</h2>
+            <table class="stats-table">
+                <thead>
+                    <tr>
+                        <th>Group</th>
+                        <th>Runs</th>
+                        <th>Min</th>
+                        <th>Mean</th>
+                        <th>P50</th>
+                        <th>P95</th>
+                        <th>P99</th>
+                        <th>Max</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .Metrics.Groups}}
+                    <tr>
+                        <td>{{.Name}}</td>
+                        <td>{{formatNumber .Duration.Count}}</td>
+                        <td>{{formatLatency .Duration.Min}}</td>
+                        <td>{{formatLatency .Duration.Mean}}</td>
+                        <td>{{formatLatency .Duration.P50}}</td>
+                        <td>{{formatLatency .Duration.P95}}</td>
+                        <td>{{formatLatency .Duration.P99}}</td>
+                        <td>{{formatLatency .Duration.Max}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </section>
+        {{end}}
+
+        <!-- Status Codes -->
+        {{if .Metrics.StatusCodes}}
+        <section class="section">
+            <h2 class="section-title">Status Codes</h2>
+            <table class="stats-table">
+                <thead>
+                    <tr>
+                        <th>Status</th>
+                        <th>Responses</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range $code, $count := .Metrics.StatusCodes}}
+                    <tr>
+                        <td>{{$code}}</td>
+                        <td>{{formatNumber $count}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </section>
+        {{end}}
+
+        <!-- Request Errors -->
+        {{if .Metrics.ErrorCategories}}
+        <section class="section">
+            <h2 class="section-title">Errors</h2>
+            <table class="stats-table">
+                <thead>
+                    <tr>
+                        <th>Category</th>
+                        <th>Errors</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range $category, $count := .Metrics.ErrorCategories}}
+                    <tr>
+                        <td>{{$category}}</td>
+                        <td>{{formatNumber $count}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+            {{if .Metrics.TopErrors}}
+            <table class="stats-table">
+                <thead>
+                    <tr>
+                        <th>Category</th>
+                        <th>Message</th>
+                        <th>Count</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .Metrics.TopErrors}}
+                    <tr>
+                        <td>{{.Category}}</td>
+                        <td>{{.Message}}</td>
+                        <td>{{formatNumber .Count}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+            {{end}}
+        </section>
+        {{end}}
+
         <!-- Charts -->
         {{if .TimeSeries}}
         <section class="section">
@@ -630,6 +779,10 @@ const htmlTemplate = `<!DOCTYPE html>
                         <span class="value">{{$scenario.ActiveVUs}}</span>
                     </div>
                     {{if $scenario.Metrics}}
+                    <div class="scenario-metric">
+                        <span class="label">Requests</span>
+                        <span class="value">{{formatNumber $scenario.Metrics.TotalRequests}}</span>
+                    </div>
                     <div class="scenario-metric">
                         <span class="label">Avg Latency</span>
                         <span class="value">{{formatLatency $scenario.Metrics.Latency.Mean}}</span>
@@ -644,6 +797,30 @@ const htmlTemplate = `<!DOCTYPE html>
                     </div>
                     {{end}}
                 </div>
+                {{if $scenario.Mix}}
+                <table class="stats-table" style="margin-top: 1rem;">
+                    <thead>
+                        <tr>
+                            <th>Traffic Mix</th>
+                            <th>Weight</th>
+                            <th>Target</th>
+                            <th>Actual</th>
+                            <th>Iterations</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {{range $scenario.Mix}}
+                        <tr>
+                            <td>{{.Name}}</td>
+                            <td>{{.Weight}}</td>
+                            <td>{{printf "%.1f%%" (mul .Target 100)}}</td>
+                            <td>{{printf "%.1f%%" (mul .Actual 100)}}</td>
+                            <td>{{formatNumber .Iterations}}</td>
+                        </tr>
+                        {{end}}
+                    </tbody>
+                </table>
+                {{end}}
                 {{if $scenario.Error}}
                 <div style="margin-top: 1rem; padding: 0.75rem; background: rgba(239, 68, 68, 0.1); border-radius: 6px; color: var(--accent-error); font-size: 0.875rem;">
                     ⚠️ Error: {{$scenario.Error}}
@@ -662,6 +839,7 @@ const htmlTemplate = `<!DOCTYPE html>
                 <thead>
                     <tr>
                         <th>Request</th>
+                        <th>Group</th>
                         <th>Count</th>
                         <th>Min</th>
                         <th>Mean</th>
@@ -676,6 +854,7 @@ const htmlTemplate = `<!DOCTYPE html>
                     {{range $reqName, $stats := $scenario.RequestStats}}
                     <tr>
                         <td>{{$reqName}}</td>
+                        <td>{{$stats.Group}}</td>
                         <td>{{formatNumber $stats.Count}}</td>
                         <td>{{formatLatency $stats.Latency.Min}}</td>
                         <td>{{formatLatency $stats.Latency.Mean}}</td>
@@ -691,6 +870,60 @@ const htmlTemplate = `<!DOCTYPE html>
         </section>
         {{end}}
 
+        <!-- Control Events -->
+        {{if .ControlEvents}}
+        <section class="section">
+            <h2 class="section-title">Control Events</h2>
+            <table class="stats-table">
+                <thead>
+                    <tr>
+                        <th>Elapsed</th>
+                        <th>Scenario</th>
+                        <th>Change</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .ControlEvents}}
+                    <tr>
+                        <td>{{formatDuration .Elapsed}}</td>
+                        <td>{{.Scenario}}</td>
+                        <td>{{.Action}}{{if eq .Action "vus"}} {{.Value}}{{else if eq .Action "rate"}} {{.Value}}/s{{end}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </section>
+        {{end}}
+
+        <!-- Checks -->
+        {{if .Metrics.Checks}}
+        <section class="section">
+            <h2 class="section-title">Checks</h2>
+            <table class="stats-table">
+                <thead>
+                    <tr>
+                        <th></th>
+                        <th>Check</th>
+                        <th>Passes</th>
+                        <th>Fails</th>
+                        <th>Pass Rate</th>
+                    </tr>
+                </thead>
+                <tbody>
+                    {{range .Metrics.Checks}}
+                    <tr>
+                        <td><span class="threshold-icon {{if eq .Fails 0}}pass{{else}}fail{{end}}">{{if eq .Fails 0}}✓{{else}}✗{{end}}</span></td>
+                        <td>{{.Name}}</td>
+                        <td>{{formatNumber .Passes}}</td>
+                        <td>{{formatNumber .Fails}}</td>
+                        <td>{{printf "%.2f%%" (mul .PassRate 100)}}</td>
+                    </tr>
+                    {{end}}
+                </tbody>
+            </table>
+        </section>
+        {{end}}
+
         <!-- Thresholds -->
         {{if .Thresholds}}
         <section class="section">
@@ -703,7 +936,7 @@ const htmlTemplate = `<!DOCTYPE html>
                     </span>
                     <div class="threshold-info">
                         <div class="threshold-metric">{{.Metric}}</div>
-                        <div class="threshold-expression">{{.Expression}}</div>
+                        <div class="threshold-expression">{{.Expression}}{{if .Aborted}} — aborted the test{{end}}</div>
                     </div>
                     <div class="threshold-value">
                         Actual: {{.Value}}
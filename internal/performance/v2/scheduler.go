@@ -185,6 +185,20 @@ func (s *VUScheduler) GetActiveVUCount() int {
 	return count
 }
 
+// runningVUCount returns the count of VUs that have not been asked to stop.
+func (s *VUScheduler) runningVUCount() int {
+	s.vusMu.RLock()
+	defer s.vusMu.RUnlock()
+
+	count := 0
+	for _, vu := range s.vus {
+		if state := vu.GetState(); state != VUStateStopping && state != VUStateStopped {
+			count++
+		}
+	}
+	return count
+}
+
 // StopVU requests a specific VU to stop.
 func (s *VUScheduler) StopVU(id int) {
 	s.vusMu.RLock()
@@ -336,7 +350,8 @@ func (s *VUScheduler) UpdateMetrics() {
 // ScaleVUs adjusts the VU count to the target.
 //
 // This is a helper for ramping executors. It spawns or stops VUs
-// as needed to reach the target count.
+// as needed to reach the target count. VUs already asked to stop do not
+// count towards the target, so repeated calls converge on it.
 //
 // Parameters:
 //   - ctx: Context for spawning new VU goroutines
@@ -345,9 +360,9 @@ func (s *VUScheduler) UpdateMetrics() {
 //   - onSpawn: Callback when a new VU is spawned (for goroutine management)
 //
 // Returns:
-//   - Current VU count after adjustment
+//   - Count of VUs not asked to stop after adjustment
 func (s *VUScheduler) ScaleVUs(ctx context.Context, target int, pacing time.Duration, onSpawn func(*VirtualUser)) int {
-	current := s.GetActiveVUCount()
+	current := s.runningVUCount()
 
 	if target > current {
 		// Spawn new VUs
@@ -376,5 +391,5 @@ func (s *VUScheduler) ScaleVUs(ctx context.Context, target int, pacing time.Dura
 	}
 
 	s.UpdateMetrics()
-	return s.GetActiveVUCount()
+	return s.runningVUCount()
 }
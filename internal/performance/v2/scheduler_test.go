@@ -589,6 +589,38 @@ func TestVUScheduler_ScaleVUs_ScaleDown(t *testing.T) {
 	}
 }
 
+func TestVUScheduler_ScaleVUs_Repeated(t *testing.T) {
+	server := createTestServer()
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scenario := createSchedulerTestScenario(server.URL)
+	httpConfig := v2.DefaultHTTPClientConfig()
+	scheduler := v2.NewVUScheduler(scenario, metricsEngine, httpConfig)
+
+	ctx := context.Background()
+
+	// Stopping VUs must not count towards the target
+	scheduler.ScaleVUs(ctx, 5, 0, nil)
+	scheduler.ScaleVUs(ctx, 3, 0, nil)
+	if count := scheduler.ScaleVUs(ctx, 1, 0, nil); count != 1 {
+		t.Errorf("ScaleVUs down returned %d, want 1", count)
+	}
+
+	spawned := 0
+	count := scheduler.ScaleVUs(ctx, 4, 0, func(vu *v2.VirtualUser) {
+		spawned++
+	})
+	if count != 4 {
+		t.Errorf("ScaleVUs up returned %d, want 4", count)
+	}
+	if spawned != 3 {
+		t.Errorf("Spawned %d VUs, want 3", spawned)
+	}
+}
+
 func TestVUScheduler_ScaleVUs_NoChange(t *testing.T) {
 	server := createTestServer()
 	defer server.Close()
@@ -0,0 +1,60 @@
+// Package serve runs the HTTP servers that lunge perf exposes while a test
+// runs, such as the control API and the live and Prometheus metrics.
+package serve
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// shutdownTimeout bounds how long Close waits for open requests.
+const shutdownTimeout = 5 * time.Second
+
+// Server serves a handler in the background.
+type Server struct {
+	server   *http.Server
+	listener net.Listener
+}
+
+// New creates a server for handler. Call Start to listen.
+func New(handler http.Handler) *Server {
+	return &Server{
+		server: &http.Server{
+			Handler:           handler,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+	}
+}
+
+// Start listens on addr (e.g. ":6565" or "127.0.0.1:0") and serves in the
+// background.
+func (s *Server) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	s.listener = listener
+
+	go func() {
+		_ = s.server.Serve(listener)
+	}()
+	return nil
+}
+
+// Addr returns the address the server listens on, once started.
+func (s *Server) Addr() string {
+	if s.listener == nil {
+		return ""
+	}
+	return s.listener.Addr().String()
+}
+
+// Close stops the server, waiting up to 5 seconds for open requests.
+func (s *Server) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
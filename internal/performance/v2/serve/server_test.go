@@ -0,0 +1,33 @@
+package serve
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Lifecycle(t *testing.T) {
+	s := New(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	assert.Empty(t, s.Addr(), "Addr before Start")
+
+	require.NoError(t, s.Start("127.0.0.1:0"))
+
+	resp, err := http.Get("http://" + s.Addr())
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+
+	// Port already in use
+	assert.Error(t, New(http.NotFoundHandler()).Start(s.Addr()))
+
+	require.NoError(t, s.Close())
+	_, err = http.Get("http://" + s.Addr())
+	assert.Error(t, err, "server still answers after Close")
+}
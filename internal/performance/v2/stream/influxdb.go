@@ -0,0 +1,118 @@
+package stream
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+)
+
+// influxDBSink writes items in the InfluxDB line protocol to an HTTP write
+// endpoint, e.g. http://localhost:8086/write?db=lunge.
+//
+// Samples are written to the lunge_http_req measurement, tagged with the
+// scenario, request name and status; buckets to lunge_interval.
+type influxDBSink struct {
+	url    string
+	client *http.Client
+}
+
+func newInfluxDBSink(target string, timeout time.Duration) (*influxDBSink, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("write URL must be http(s)://host/..., got %q", target)
+	}
+
+	return &influxDBSink{
+		url:    target,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (s *influxDBSink) EncodeBucket(buf []byte, b *metrics.TimeBucket) []byte {
+	buf = append(buf, "lunge_interval vus="...)
+	buf = strconv.AppendInt(buf, int64(b.ActiveVUs), 10)
+	buf = append(buf, "i,requests="...)
+	buf = strconv.AppendInt(buf, b.IntervalRequests, 10)
+	buf = append(buf, "i,rps="...)
+	buf = strconv.AppendFloat(buf, b.IntervalRPS, 'f', -1, 64)
+	buf = append(buf, ",error_rate="...)
+	buf = strconv.AppendFloat(buf, b.IntervalErrorRate, 'f', -1, 64)
+	buf = append(buf, ",p50="...)
+	buf = appendMillis(buf, b.LatencyP50)
+	buf = append(buf, ",p90="...)
+	buf = appendMillis(buf, b.LatencyP90)
+	buf = append(buf, ",p95="...)
+	buf = appendMillis(buf, b.LatencyP95)
+	buf = append(buf, ",p99="...)
+	buf = appendMillis(buf, b.LatencyP99)
+	buf = append(buf, ",dropped_iterations="...)
+	buf = strconv.AppendInt(buf, b.IntervalDroppedIterations, 10)
+	buf = append(buf, 'i', ' ')
+	buf = strconv.AppendInt(buf, b.Timestamp.UnixNano(), 10)
+	return append(buf, '\n')
+}
+
+func (s *influxDBSink) EncodeSample(buf []byte, sample metrics.Sample) []byte {
+	buf = append(buf, "lunge_http_req"...)
+	if sample.Scenario != "" {
+		buf = append(buf, ",scenario="...)
+		buf = appendTag(buf, sample.Scenario)
+	}
+	if sample.Name != "" {
+		buf = append(buf, ",name="...)
+		buf = appendTag(buf, sample.Name)
+	}
+	buf = append(buf, ",status="...)
+	buf = strconv.AppendInt(buf, int64(sample.Status), 10)
+	buf = append(buf, " duration="...)
+	buf = appendMillis(buf, sample.Duration)
+	buf = append(buf, ",bytes="...)
+	buf = strconv.AppendInt(buf, sample.Bytes, 10)
+	buf = append(buf, "i,success="...)
+	buf = strconv.AppendBool(buf, sample.Success)
+	buf = append(buf, ' ')
+	buf = strconv.AppendInt(buf, sample.Time.UnixNano(), 10)
+	return append(buf, '\n')
+}
+
+func (s *influxDBSink) Write(batch []byte) error {
+	resp, err := s.client.Post(s.url, "text/plain; charset=utf-8", bytes.NewReader(batch))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("write returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+func (s *influxDBSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
+
+// tagEscaper escapes tag values as the line protocol requires.
+var tagEscaper = strings.NewReplacer(`,`, `\,`, `=`, `\=`, ` `, `\ `, "\n", `\n`)
+
+func appendTag(buf []byte, value string) []byte {
+	return append(buf, tagEscaper.Replace(value)...)
+}
+
+// appendMillis appends a duration as fractional milliseconds.
+func appendMillis(buf []byte, d time.Duration) []byte {
+	return strconv.AppendFloat(buf, float64(d)/float64(time.Millisecond), 'f', -1, 64)
+}
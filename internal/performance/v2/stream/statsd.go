@@ -0,0 +1,116 @@
+package stream
+
+import (
+	"bytes"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+)
+
+// maxPacketSize keeps StatsD packets within a typical network MTU.
+const maxPacketSize = 1432
+
+// statsDSink sends items as StatsD metrics over UDP, e.g. to
+// localhost:8125.
+//
+// Samples become the lunge.http_reqs and lunge.http_req_failed counters
+// and the lunge.http_req_duration timer, tagged with the scenario, request
+// name and status in the DogStatsD format; buckets become gauges.
+type statsDSink struct {
+	conn    net.Conn
+	timeout time.Duration
+}
+
+func newStatsDSink(target string, timeout time.Duration) (*statsDSink, error) {
+	conn, err := net.Dial("udp", target)
+	if err != nil {
+		return nil, err
+	}
+	return &statsDSink{conn: conn, timeout: timeout}, nil
+}
+
+func (s *statsDSink) EncodeBucket(buf []byte, b *metrics.TimeBucket) []byte {
+	buf = appendStat(buf, "lunge.vus", strconv.Itoa(b.ActiveVUs), "g", nil)
+	buf = appendStat(buf, "lunge.rps", strconv.FormatFloat(b.IntervalRPS, 'f', -1, 64), "g", nil)
+	buf = appendStat(buf, "lunge.error_rate", strconv.FormatFloat(b.IntervalErrorRate, 'f', -1, 64), "g", nil)
+	if b.IntervalDroppedIterations > 0 {
+		buf = appendStat(buf, "lunge.dropped_iterations", strconv.FormatInt(b.IntervalDroppedIterations, 10), "c", nil)
+	}
+	return buf
+}
+
+func (s *statsDSink) EncodeSample(buf []byte, sample metrics.Sample) []byte {
+	tags := []string{
+		"scenario", sample.Scenario,
+		"name", sample.Name,
+		"status", strconv.Itoa(sample.Status),
+	}
+	buf = appendStat(buf, "lunge.http_reqs", "1", "c", tags)
+	buf = appendStat(buf, "lunge.http_req_duration", strconv.FormatFloat(float64(sample.Duration)/float64(time.Millisecond), 'f', -1, 64), "ms", tags)
+	if !sample.Success {
+		buf = appendStat(buf, "lunge.http_req_failed", "1", "c", tags)
+	}
+	return buf
+}
+
+// Write sends the batch in as few packets as possible, splitting it
+// between metrics.
+func (s *statsDSink) Write(batch []byte) error {
+	if err := s.conn.SetWriteDeadline(time.Now().Add(s.timeout)); err != nil {
+		return err
+	}
+
+	for len(batch) > 0 {
+		end := len(batch)
+		if end > maxPacketSize {
+			end = bytes.LastIndexByte(batch[:maxPacketSize], '\n') + 1
+			if end == 0 {
+				// A single metric larger than a packet
+				end = bytes.IndexByte(batch, '\n') + 1
+			}
+		}
+
+		if _, err := s.conn.Write(bytes.TrimSuffix(batch[:end], []byte("\n"))); err != nil {
+			return err
+		}
+		batch = batch[end:]
+	}
+	return nil
+}
+
+func (s *statsDSink) Close() error {
+	return s.conn.Close()
+}
+
+// statsDTagEscaper removes the characters that delimit DogStatsD tags.
+var statsDTagEscaper = strings.NewReplacer(",", "_", "|", "_", "#", "_", "\n", "_")
+
+// appendStat appends one metric line. Tags are name/value pairs; empty
+// values are omitted.
+func appendStat(buf []byte, name, value, kind string, tags []string) []byte {
+	buf = append(buf, name...)
+	buf = append(buf, ':')
+	buf = append(buf, value...)
+	buf = append(buf, '|')
+	buf = append(buf, kind...)
+
+	first := true
+	for i := 0; i+1 < len(tags); i += 2 {
+		if tags[i+1] == "" {
+			continue
+		}
+		if first {
+			buf = append(buf, "|#"...)
+			first = false
+		} else {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, tags[i]...)
+		buf = append(buf, ':')
+		buf = append(buf, statsDTagEscaper.Replace(tags[i+1])...)
+	}
+	return append(buf, '\n')
+}
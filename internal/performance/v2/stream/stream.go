@@ -0,0 +1,282 @@
+// Package stream pushes the metrics of a running performance test to
+// external systems as they are recorded: InfluxDB over HTTP and StatsD
+// over UDP.
+//
+// An Output receives every request sample and time bucket through a
+// bounded buffer and writes them in batches from a background goroutine.
+// When the sink cannot keep up, the buffer fills and further items are
+// dropped and counted, so a slow sink never slows the test down.
+package stream
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+)
+
+// Supported output types, the part of an output spec before "=".
+const (
+	TypeInfluxDB = "influxdb"
+	TypeStatsD   = "statsd"
+)
+
+// Config controls the buffering and batching of an output.
+type Config struct {
+	// BufferSize is the number of samples and buckets held while the sink
+	// is busy; items arriving when it is full are dropped (default: 10000)
+	BufferSize int
+
+	// BatchSize is the largest number of items per write (default: 500)
+	BatchSize int
+
+	// FlushInterval is the longest an item waits before it is written
+	// (default: 1s)
+	FlushInterval time.Duration
+
+	// Timeout bounds each write (default: 5s)
+	Timeout time.Duration
+}
+
+// DefaultConfig returns the default output configuration.
+func DefaultConfig() Config {
+	return Config{
+		BufferSize:    10000,
+		BatchSize:     500,
+		FlushInterval: time.Second,
+		Timeout:       5 * time.Second,
+	}
+}
+
+// withDefaults fills unset fields from DefaultConfig.
+func (c Config) withDefaults() Config {
+	defaults := DefaultConfig()
+	if c.BufferSize <= 0 {
+		c.BufferSize = defaults.BufferSize
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = defaults.BatchSize
+	}
+	if c.FlushInterval <= 0 {
+		c.FlushInterval = defaults.FlushInterval
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaults.Timeout
+	}
+	return c
+}
+
+// Stats counts what an output did with the items it received.
+type Stats struct {
+	Written   int64 // Items written to the sink
+	Dropped   int64 // Items dropped because the buffer was full
+	Failed    int64 // Items in batches the sink rejected
+	LastError error // Error of the last failed write
+}
+
+// item is a sample or a bucket waiting to be written.
+type item struct {
+	sample metrics.Sample
+	bucket *metrics.TimeBucket
+}
+
+// Sink encodes samples and buckets and writes batches of them to an
+// external system. Outputs call it from a single goroutine.
+type Sink interface {
+	// EncodeSample appends the encoded sample to buf, or returns buf
+	// unchanged to skip it
+	EncodeSample(buf []byte, sample metrics.Sample) []byte
+
+	// EncodeBucket appends the encoded bucket to buf, or returns buf
+	// unchanged to skip it
+	EncodeBucket(buf []byte, bucket *metrics.TimeBucket) []byte
+
+	// Write sends a batch of encoded items
+	Write(batch []byte) error
+
+	Close() error
+}
+
+// Output streams samples and buckets to one sink. It implements
+// metrics.Listener.
+type Output struct {
+	kind   string
+	target string
+	sink   Sink
+	config Config
+
+	items     chan item
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+	closeErr  error
+
+	written atomic.Int64
+	dropped atomic.Int64
+	failed  atomic.Int64
+
+	errMu   sync.Mutex
+	lastErr error
+}
+
+// New creates an output from a spec of the form "type=target" and starts
+// its background writer:
+//
+//	influxdb=http://localhost:8086/write?db=lunge
+//	statsd=localhost:8125
+func New(spec string, config Config) (*Output, error) {
+	kind, target, ok := strings.Cut(spec, "=")
+	if !ok || target == "" {
+		return nil, fmt.Errorf("invalid output %q: expected type=target, e.g. %s=localhost:8125", spec, TypeStatsD)
+	}
+
+	config = config.withDefaults()
+
+	var s Sink
+	var err error
+	switch kind {
+	case TypeInfluxDB:
+		s, err = newInfluxDBSink(target, config.Timeout)
+	case TypeStatsD:
+		s, err = newStatsDSink(target, config.Timeout)
+	default:
+		return nil, fmt.Errorf("unknown output type %q (supported: %s, %s)", kind, TypeInfluxDB, TypeStatsD)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s output: %w", kind, err)
+	}
+	return NewOutput(kind, target, s, config), nil
+}
+
+// NewOutput creates an output that writes to a custom sink and starts its
+// background writer. Kind and target describe the sink in String.
+func NewOutput(kind, target string, sink Sink, config Config) *Output {
+	config = config.withDefaults()
+
+	o := &Output{
+		kind:   kind,
+		target: target,
+		sink:   sink,
+		config: config,
+		items:  make(chan item, config.BufferSize),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go o.run()
+	return o
+}
+
+// String describes the output, e.g. "statsd (localhost:8125)".
+func (o *Output) String() string {
+	return fmt.Sprintf("%s (%s)", o.kind, o.target)
+}
+
+// AddSample queues a request sample, or drops it if the buffer is full.
+func (o *Output) AddSample(sample metrics.Sample) {
+	o.enqueue(item{sample: sample})
+}
+
+// AddBucket queues a time bucket, or drops it if the buffer is full.
+func (o *Output) AddBucket(bucket *metrics.TimeBucket) {
+	o.enqueue(item{bucket: bucket})
+}
+
+func (o *Output) enqueue(it item) {
+	select {
+	case o.items <- it:
+	default:
+		o.dropped.Add(1)
+	}
+}
+
+// Close writes the items still buffered and stops the output. Items added
+// after Close are dropped.
+func (o *Output) Close() error {
+	o.closeOnce.Do(func() {
+		close(o.stop)
+		<-o.done
+		o.closeErr = o.sink.Close()
+	})
+	return o.closeErr
+}
+
+// Stats returns what the output did with the items it received so far.
+func (o *Output) Stats() Stats {
+	o.errMu.Lock()
+	defer o.errMu.Unlock()
+
+	return Stats{
+		Written:   o.written.Load(),
+		Dropped:   o.dropped.Load(),
+		Failed:    o.failed.Load(),
+		LastError: o.lastErr,
+	}
+}
+
+// run encodes queued items and writes them in batches until stopped.
+func (o *Output) run() {
+	defer close(o.done)
+
+	ticker := time.NewTicker(o.config.FlushInterval)
+	defer ticker.Stop()
+
+	var batch []byte
+	count := 0
+
+	flush := func() {
+		if count == 0 {
+			return
+		}
+		if err := o.sink.Write(batch); err != nil {
+			o.failed.Add(int64(count))
+			o.errMu.Lock()
+			o.lastErr = err
+			o.errMu.Unlock()
+		} else {
+			o.written.Add(int64(count))
+		}
+		batch = batch[:0]
+		count = 0
+	}
+
+	add := func(it item) {
+		size := len(batch)
+		if it.bucket != nil {
+			batch = o.sink.EncodeBucket(batch, it.bucket)
+		} else {
+			batch = o.sink.EncodeSample(batch, it.sample)
+		}
+		if len(batch) == size {
+			return
+		}
+		count++
+		if count >= o.config.BatchSize {
+			flush()
+		}
+	}
+
+	for {
+		select {
+		case it := <-o.items:
+			add(it)
+		case <-ticker.C:
+			flush()
+		case <-o.stop:
+			for {
+				select {
+				case it := <-o.items:
+					add(it)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// Compile-time check that Output implements metrics.Listener
+var _ metrics.Listener = (*Output)(nil)
@@ -0,0 +1,223 @@
+package stream
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+)
+
+var sampleTime = time.Unix(1700000000, 500)
+
+// influxDBServer is a stand-in InfluxDB write endpoint that records the
+// lines it receives.
+type influxDBServer struct {
+	*httptest.Server
+	mu    sync.Mutex
+	lines []string
+}
+
+func newInfluxDBServer(t *testing.T, handler func(w http.ResponseWriter)) *influxDBServer {
+	t.Helper()
+
+	s := &influxDBServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.lines = append(s.lines, strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")...)
+		s.mu.Unlock()
+
+		if handler != nil {
+			handler(w)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *influxDBServer) received() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.lines...)
+}
+
+func TestNew_InvalidSpec(t *testing.T) {
+	tests := []struct {
+		spec   string
+		errMsg string
+	}{
+		{"statsd", "expected type=target"},
+		{"statsd=", "expected type=target"},
+		{"kafka=localhost:9092", "unknown output type"},
+		{"influxdb=localhost:8086", "write URL must be http(s)"},
+		{"influxdb=ftp://localhost/write", "write URL must be http(s)"},
+		{"statsd=localhost", "invalid statsd output"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := New(tt.spec, Config{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestOutput_InfluxDB(t *testing.T) {
+	server := newInfluxDBServer(t, nil)
+
+	out, err := New("influxdb="+server.URL+"/write?db=lunge", Config{BatchSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "influxdb ("+server.URL+"/write?db=lunge)", out.String())
+
+	out.AddSample(metrics.Sample{
+		Time:     sampleTime,
+		Scenario: "browse",
+		Name:     "list items, page 1",
+		Status:   200,
+		Duration: 12500 * time.Microsecond,
+		Success:  true,
+		Bytes:    512,
+	})
+	out.AddSample(metrics.Sample{Time: sampleTime, Scenario: "browse", Name: "login", Duration: time.Second})
+	out.AddBucket(&metrics.TimeBucket{
+		Timestamp:         sampleTime,
+		ActiveVUs:         5,
+		IntervalRequests:  20,
+		IntervalRPS:       19.5,
+		IntervalErrorRate: 0.05,
+		LatencyP50:        10 * time.Millisecond,
+		LatencyP90:        20 * time.Millisecond,
+		LatencyP95:        25 * time.Millisecond,
+		LatencyP99:        40 * time.Millisecond,
+	})
+	require.NoError(t, out.Close())
+
+	assert.Equal(t, []string{
+		`lunge_http_req,scenario=browse,name=list\ items\,\ page\ 1,status=200 duration=12.5,bytes=512i,success=true 1700000000000000500`,
+		`lunge_http_req,scenario=browse,name=login,status=0 duration=1000,bytes=0i,success=false 1700000000000000500`,
+		`lunge_interval vus=5i,requests=20i,rps=19.5,error_rate=0.05,p50=10,p90=20,p95=25,p99=40,dropped_iterations=0i 1700000000000000500`,
+	}, server.received())
+	assert.Equal(t, Stats{Written: 3}, out.Stats())
+}
+
+func TestOutput_InfluxDBWriteError(t *testing.T) {
+	server := newInfluxDBServer(t, func(w http.ResponseWriter) {
+		http.Error(w, "database not found", http.StatusNotFound)
+	})
+
+	out, err := New("influxdb="+server.URL+"/write?db=missing", Config{})
+	require.NoError(t, err)
+
+	out.AddSample(metrics.Sample{Time: sampleTime, Name: "get", Status: 200, Success: true})
+	require.NoError(t, out.Close())
+
+	stats := out.Stats()
+	assert.Equal(t, int64(0), stats.Written)
+	assert.Equal(t, int64(1), stats.Failed)
+	require.Error(t, stats.LastError)
+	assert.Contains(t, stats.LastError.Error(), "database not found")
+}
+
+func TestOutput_StatsD(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	out, err := New("statsd="+conn.LocalAddr().String(), Config{})
+	require.NoError(t, err)
+
+	out.AddSample(metrics.Sample{Scenario: "api", Name: "get, one", Status: 200, Duration: 15 * time.Millisecond, Success: true})
+	out.AddSample(metrics.Sample{Scenario: "api", Name: "post", Status: 503, Duration: 2 * time.Millisecond})
+	out.AddBucket(&metrics.TimeBucket{ActiveVUs: 3, IntervalRPS: 42, IntervalDroppedIterations: 2})
+	require.NoError(t, out.Close())
+
+	var lines []string
+	buf := make([]byte, 2048)
+	for len(lines) < 9 {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		lines = append(lines, strings.Split(string(buf[:n]), "\n")...)
+	}
+
+	assert.Equal(t, []string{
+		"lunge.http_reqs:1|c|#scenario:api,name:get_ one,status:200",
+		"lunge.http_req_duration:15|ms|#scenario:api,name:get_ one,status:200",
+		"lunge.http_reqs:1|c|#scenario:api,name:post,status:503",
+		"lunge.http_req_duration:2|ms|#scenario:api,name:post,status:503",
+		"lunge.http_req_failed:1|c|#scenario:api,name:post,status:503",
+		"lunge.vus:3|g",
+		"lunge.rps:42|g",
+		"lunge.error_rate:0|g",
+		"lunge.dropped_iterations:2|c",
+	}, lines)
+}
+
+func TestStatsDSink_Packets(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	sink, err := newStatsDSink(conn.LocalAddr().String(), time.Second)
+	require.NoError(t, err)
+	defer sink.Close()
+
+	var batch []byte
+	for i := 0; i < 100; i++ {
+		batch = sink.EncodeSample(batch, metrics.Sample{Scenario: "api", Name: "get", Status: 200, Success: true})
+	}
+	require.NoError(t, sink.Write(batch))
+
+	// Packets stay under the limit and only split between metrics
+	metricCount := 0
+	buf := make([]byte, 4096)
+	for metricCount < 200 {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, n, maxPacketSize)
+		for _, line := range strings.Split(string(buf[:n]), "\n") {
+			assert.True(t, strings.HasPrefix(line, "lunge.http_req"), "split metric %q", line)
+			metricCount++
+		}
+	}
+	assert.Equal(t, 200, metricCount)
+}
+
+func TestOutput_SlowSinkDropsInsteadOfBlocking(t *testing.T) {
+	release := make(chan struct{})
+	server := newInfluxDBServer(t, func(w http.ResponseWriter) {
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	out, err := New("influxdb="+server.URL+"/write", Config{BufferSize: 10, BatchSize: 1, Timeout: 10 * time.Second})
+	require.NoError(t, err)
+
+	start := time.Now()
+	for i := 0; i < 1000; i++ {
+		out.AddSample(metrics.Sample{Time: sampleTime, Name: "get", Status: 200, Success: true})
+	}
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "AddSample blocked on a slow sink")
+
+	stats := out.Stats()
+	assert.Greater(t, stats.Dropped, int64(0))
+
+	close(release)
+	require.NoError(t, out.Close())
+
+	stats = out.Stats()
+	assert.Equal(t, int64(1000), stats.Written+stats.Dropped)
+	assert.Equal(t, int64(0), stats.Failed)
+}
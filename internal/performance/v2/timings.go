@@ -0,0 +1,86 @@
+package v2
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+)
+
+// requestTrace records when each phase of a single HTTP request started
+// and ended.
+//
+// Trace callbacks may run on transport goroutines, so fields are guarded
+// by mu.
+type requestTrace struct {
+	mu sync.Mutex
+
+	getConn, gotConn          time.Time
+	dnsStart, dnsDone         time.Time
+	connectStart, connectDone time.Time
+	tlsStart, tlsDone         time.Time
+	wroteRequest, firstByte   time.Time
+}
+
+// clientTrace returns the httptrace hooks that fill in t.
+func (t *requestTrace) clientTrace() *httptrace.ClientTrace {
+	now := func(field *time.Time) {
+		t.mu.Lock()
+		*field = time.Now()
+		t.mu.Unlock()
+	}
+	// first only records the first occurrence, as a dial may try several
+	// addresses
+	first := func(field *time.Time) {
+		t.mu.Lock()
+		if field.IsZero() {
+			*field = time.Now()
+		}
+		t.mu.Unlock()
+	}
+
+	return &httptrace.ClientTrace{
+		GetConn:              func(string) { first(&t.getConn) },
+		GotConn:              func(httptrace.GotConnInfo) { now(&t.gotConn) },
+		DNSStart:             func(httptrace.DNSStartInfo) { first(&t.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { now(&t.dnsDone) },
+		ConnectStart:         func(string, string) { first(&t.connectStart) },
+		ConnectDone:          func(string, string, error) { now(&t.connectDone) },
+		TLSHandshakeStart:    func() { first(&t.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { now(&t.tlsDone) },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { now(&t.wroteRequest) },
+		GotFirstResponseByte: func() { now(&t.firstByte) },
+	}
+}
+
+// timings returns the time spent in each phase of a request whose
+// response body was fully read at end.
+func (t *requestTrace) timings(end time.Time) metrics.Timings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var timings metrics.Timings
+	timings.DNS = between(t.dnsStart, t.dnsDone)
+	timings.Connect = between(t.connectStart, t.connectDone)
+	timings.TLS = between(t.tlsStart, t.tlsDone)
+
+	// Time to get a connection not spent establishing it
+	if blocked := between(t.getConn, t.gotConn) - timings.DNS - timings.Connect - timings.TLS; blocked > 0 {
+		timings.Blocked = blocked
+	}
+
+	timings.Sending = between(t.gotConn, t.wroteRequest)
+	timings.Waiting = between(t.wroteRequest, t.firstByte)
+	timings.Receiving = between(t.firstByte, end)
+	return timings
+}
+
+// between returns the time from start to end, or zero if either is unset.
+func between(start, end time.Time) time.Duration {
+	if start.IsZero() || end.IsZero() || end.Before(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
@@ -0,0 +1,27 @@
+package v2
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"math/rand"
+)
+
+// traceparentHeader is the W3C Trace Context header linking a request to
+// the traces of the server that handles it.
+const traceparentHeader = "Traceparent"
+
+// newTraceContext returns a random W3C trace ID and span ID, hex-encoded.
+func newTraceContext() (traceID, spanID string) {
+	var b [24]byte
+	if _, err := cryptorand.Read(b[:]); err != nil {
+		for i := range b {
+			b[i] = byte(rand.Intn(256))
+		}
+	}
+	return hex.EncodeToString(b[:16]), hex.EncodeToString(b[16:])
+}
+
+// formatTraceparent formats a traceparent header value for a sampled span.
+func formatTraceparent(traceID, spanID string) string {
+	return "00-" + traceID + "-" + spanID + "-01"
+}
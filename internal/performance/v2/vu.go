@@ -3,15 +3,21 @@ package v2
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
+	"net"
 	"net/http"
+	"net/http/httptrace"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/wesleyorama2/lunge/internal/performance/v2/metrics"
+	"github.com/wesleyorama2/lunge/pkg/jsonpath"
 )
 
 // VUState represents the lifecycle state of a Virtual User.
@@ -81,9 +87,29 @@ type VirtualUser struct {
 	data   map[string]interface{}
 	dataMu sync.RWMutex
 
+	// Current data row
+	dataRow map[string]string
+
+	// Built-in variables of the current request
+	builtins *requestBuiltins
+
 	// Last iteration timing
 	lastIterStart time.Time
 	lastIterEnd   time.Time
+
+	// Scheduled start of the current iteration, cleared once its first
+	// request has run
+	intendedStart time.Time
+
+	// Status code of the iteration's most recent response (0 if none)
+	lastStatus int
+
+	// Think time of the previous request, applied before the next one so
+	// none is applied after the iteration's last executed request
+	pendingThinkTime time.Duration
+
+	// Random source of a weighted flow's picks, created on first use
+	rng *rand.Rand
 }
 
 // NewVirtualUser creates a new Virtual User.
@@ -115,59 +141,230 @@ func (vu *VirtualUser) GetIteration() int64 {
 	return vu.iteration.Load()
 }
 
+// ErrIterationInterrupted is returned by an iteration that a graceful stop
+// ended before all of its requests ran. Executors do not count it as a
+// completed iteration.
+var ErrIterationInterrupted = errors.New("iteration interrupted")
+
 // RunIteration executes a single iteration of the scenario.
 //
-// An iteration consists of executing all requests defined in the scenario,
-// optionally with think time between requests.
+// An iteration consists of executing the requests defined in the scenario,
+// or the one picked by a weighted flow, subject to their conditions and
+// loops, optionally with think time between requests.
 //
 // Returns:
 //   - nil if the iteration completed successfully
+//   - ErrIterationInterrupted if a graceful stop ended it early
 //   - error if the iteration was cancelled or encountered a fatal error
 func (vu *VirtualUser) RunIteration(ctx context.Context) error {
+	return vu.RunIterationAt(ctx, time.Time{})
+}
+
+// RunIterationAt executes a single iteration that was scheduled to start at
+// intendedStart.
+//
+// When the scenario has LatencyFromIntendedStart set, the first request's
+// latency is measured from intendedStart instead of the actual send time,
+// so time spent waiting for a free VU shows up in the latency figures.
+// A zero intendedStart behaves like RunIteration.
+func (vu *VirtualUser) RunIterationAt(ctx context.Context, intendedStart time.Time) error {
 	// Check if we should run
 	currentState := vu.GetState()
 	if currentState == VUStateStopping || currentState == VUStateStopped {
 		return fmt.Errorf("VU %d is stopping or stopped", vu.ID)
 	}
 
-	// Transition to running
-	vu.state.Store(int32(VUStateRunning))
+	// Take this iteration's data row; a VU that runs out of rows stops
+	if vu.Scenario.Data != nil {
+		row, err := vu.Scenario.Data.rowFor(vu)
+		if err != nil {
+			vu.RequestStop()
+			return err
+		}
+		vu.dataRow = row
+	}
+
+	// Transition to running, unless asked to stop in the meantime
+	if !vu.state.CompareAndSwap(int32(VUStateIdle), int32(VUStateRunning)) && vu.GetState() != VUStateRunning {
+		return fmt.Errorf("VU %d is stopping or stopped", vu.ID)
+	}
 	vu.lastIterStart = time.Now()
 	vu.iteration.Add(1)
+	vu.intendedStart = intendedStart
+	vu.lastStatus = 0
+	vu.pendingThinkTime = 0
+
+	// Execute all steps in the scenario, or a weighted flow's pick
+	steps := vu.Scenario.Requests
+	if vu.Scenario.Flow == FlowWeighted && len(steps) > 0 {
+		step := vu.pickStep()
+		vu.Metrics.RecordSelection(step.StepName())
+		steps = []*RequestConfig{step}
+	}
+	completed, err := vu.runSteps(ctx, steps)
+	vu.lastIterEnd = time.Now()
+	if !completed {
+		if err == nil {
+			err = ErrIterationInterrupted
+		}
+		return err
+	}
 
-	// Execute all requests in the scenario
-	for i, req := range vu.Scenario.Requests {
-		// Check for stop signal
-		select {
-		case <-ctx.Done():
-			vu.lastIterEnd = time.Now()
-			return ctx.Err()
-		case <-vu.stopCh:
-			vu.lastIterEnd = time.Now()
-			return nil // Graceful stop
-		default:
+	// A stop requested during the iteration must not be lost
+	vu.state.CompareAndSwap(int32(VUStateRunning), int32(VUStateIdle))
+	return nil
+}
+
+// runSteps executes steps in order. A step is a request or a group, and
+// is skipped when its When condition does not hold.
+//
+// Returns false if the iteration was interrupted, with the context's
+// error if it was cancelled rather than gracefully stopped.
+func (vu *VirtualUser) runSteps(ctx context.Context, steps []*RequestConfig) (bool, error) {
+	for _, step := range steps {
+		if stopped, err := vu.interrupted(ctx); stopped {
+			return false, err
 		}
 
-		// Execute the request
-		result := vu.executeRequest(ctx, req)
+		if step.When != nil && !vu.evaluateCondition(step.When) {
+			continue
+		}
 
-		// Record metrics
-		success := result.Error == nil && result.StatusCode < 400
-		vu.Metrics.RecordLatency(result.Duration, req.Name, success, result.BytesReceived)
+		if completed, err := vu.runStep(ctx, step); !completed {
+			return false, err
+		}
+	}
+	return true, nil
+}
 
-		// Apply think time between requests (not after the last one)
-		if req.ThinkTime > 0 && i < len(vu.Scenario.Requests)-1 {
-			vu.applyThinkTime(ctx, req.ThinkTime)
+// runStep runs a request or group Repeat times, or until its Until
+// condition holds after a run.
+func (vu *VirtualUser) runStep(ctx context.Context, step *RequestConfig) (bool, error) {
+	repeat := max(step.Repeat, 1)
+	for i := 0; i < repeat; i++ {
+		// Think time owed by the previous request, which is not part of a
+		// group's run
+		if stopped, err := vu.waitThinkTime(ctx); stopped {
+			return false, err
+		}
+
+		if step.Group != nil {
+			if completed, err := vu.runGroup(ctx, step.Group); !completed {
+				return false, err
+			}
+		} else {
+			vu.runRequest(ctx, step)
+			vu.pendingThinkTime = step.ThinkTime
+		}
+
+		if step.Until != nil && vu.evaluateCondition(step.Until) {
+			break
 		}
 	}
+	return true, nil
+}
 
-	vu.lastIterEnd = time.Now()
-	vu.state.Store(int32(VUStateIdle))
-	return nil
+// runGroup runs a group's steps once, recording the duration of the run.
+// Runs cut short by the test stopping are not recorded.
+func (vu *VirtualUser) runGroup(ctx context.Context, group *Group) (bool, error) {
+	start := time.Now()
+	completed, err := vu.runSteps(ctx, group.Requests)
+	if !completed {
+		return false, err
+	}
+	if ctx.Err() == nil {
+		vu.Metrics.RecordGroupDuration(group.Name, time.Since(start))
+	}
+	return true, nil
+}
+
+// waitThinkTime applies the previous request's think time, if any, and
+// reports whether the iteration was interrupted meanwhile.
+func (vu *VirtualUser) waitThinkTime(ctx context.Context) (bool, error) {
+	if vu.pendingThinkTime > 0 {
+		vu.applyThinkTime(ctx, vu.pendingThinkTime)
+		vu.pendingThinkTime = 0
+	}
+	return vu.interrupted(ctx)
+}
+
+// interrupted reports whether the iteration must end early, with the
+// context's error if it was cancelled rather than gracefully stopped.
+func (vu *VirtualUser) interrupted(ctx context.Context) (bool, error) {
+	select {
+	case <-ctx.Done():
+		return true, ctx.Err()
+	case <-vu.stopCh:
+		return true, nil // Graceful stop
+	default:
+		return false, nil
+	}
+}
+
+// runRequest executes a single request and records its results.
+func (vu *VirtualUser) runRequest(ctx context.Context, req *RequestConfig) {
+	result := vu.executeRequest(ctx, req)
+
+	// Include scheduling delay in the iteration's first request's latency
+	if !vu.intendedStart.IsZero() {
+		if vu.Scenario.LatencyFromIntendedStart && vu.intendedStart.Before(result.StartTime) {
+			result.Duration += result.StartTime.Sub(vu.intendedStart)
+		}
+		vu.intendedStart = time.Time{}
+	}
+
+	// Record metrics
+	vu.recordResult(req, result)
+	vu.lastStatus = result.StatusCode
+
+	// Evaluate assertions as checks (skip requests interrupted by shutdown)
+	if len(req.Assertions) > 0 && !(result.Error != nil && ctx.Err() != nil) {
+		vu.evaluateAssertions(req, result)
+	}
+}
+
+// recordResult records the metrics of an executed request.
+func (vu *VirtualUser) recordResult(req *RequestConfig, result *RequestResult) {
+	success := result.Error == nil && req.ExpectsStatus(result.StatusCode)
+	vu.Metrics.RecordLatency(result.Duration, req.Name, success, result.BytesReceived)
+	vu.Metrics.RecordRequestStatus(req.Name, result.StatusCode, success)
+	sample := metrics.Sample{
+		Time:      result.StartTime,
+		Scenario:  vu.Scenario.Name,
+		Name:      req.Name,
+		Method:    req.Method,
+		Status:    result.StatusCode,
+		Duration:  result.Duration,
+		Success:   success,
+		Bytes:     result.BytesReceived,
+		VU:        result.VUID,
+		Iteration: result.Iteration,
+		Timings:   result.Timings,
+		TraceID:   result.TraceID,
+		SpanID:    result.SpanID,
+	}
+	if result.Error != nil {
+		sample.Error = result.Error.Error()
+	}
+	vu.Metrics.RecordSample(sample)
+	if result.StatusCode > 0 {
+		vu.Metrics.RecordStatusCode(result.StatusCode)
+	}
+	if result.Error != nil {
+		category, message := classifyError(result)
+		vu.Metrics.RecordError(category, message)
+	}
+	if result.TimedOut {
+		vu.Metrics.RecordTimeout(req.Name)
+	}
+	if result.Timings != nil {
+		vu.Metrics.RecordTimings(req.Name, *result.Timings)
+	}
 }
 
 // executeRequest executes a single HTTP request and returns the result.
 func (vu *VirtualUser) executeRequest(ctx context.Context, req *RequestConfig) *RequestResult {
+	vu.builtins = newRequestBuiltins(vu)
 	startTime := time.Now()
 
 	result := &RequestResult{
@@ -177,8 +374,20 @@ func (vu *VirtualUser) executeRequest(ctx context.Context, req *RequestConfig) *
 		StartTime:   startTime,
 	}
 
+	// Bound the request, including reading its body, by its own timeout
+	reqCtx := ctx
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+
+	// Trace the request to time each phase
+	trace := &requestTrace{}
+	reqCtx = httptrace.WithClientTrace(reqCtx, trace.clientTrace())
+
 	// Build the HTTP request
-	httpReq, err := vu.buildRequest(ctx, req)
+	httpReq, err := vu.buildRequest(reqCtx, req)
 	if err != nil {
 		result.EndTime = time.Now()
 		result.Duration = result.EndTime.Sub(startTime)
@@ -186,6 +395,13 @@ func (vu *VirtualUser) executeRequest(ctx context.Context, req *RequestConfig) *
 		return result
 	}
 
+	// Start a trace the server can attach its spans to, unless the
+	// request sets its own
+	if vu.Scenario.Traceparent && httpReq.Header.Get(traceparentHeader) == "" {
+		result.TraceID, result.SpanID = newTraceContext()
+		httpReq.Header.Set(traceparentHeader, formatTraceparent(result.TraceID, result.SpanID))
+	}
+
 	// Execute the request
 	resp, err := vu.HTTPClient.Do(httpReq)
 	endTime := time.Now()
@@ -194,7 +410,7 @@ func (vu *VirtualUser) executeRequest(ctx context.Context, req *RequestConfig) *
 	result.Duration = endTime.Sub(startTime)
 
 	if err != nil {
-		result.Error = err
+		result.Error = vu.requestError(ctx, req, err, result)
 		return result
 	}
 
@@ -203,23 +419,47 @@ func (vu *VirtualUser) executeRequest(ctx context.Context, req *RequestConfig) *
 	// Read response body for byte counting and assertions
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		result.Error = fmt.Errorf("failed to read response body: %w", err)
+		result.EndTime = time.Now()
+		result.Duration = result.EndTime.Sub(startTime)
+		result.Error = fmt.Errorf("failed to read response body: %w", vu.requestError(ctx, req, err, result))
 		result.StatusCode = resp.StatusCode
 		return result
 	}
 
+	timings := trace.timings(time.Now())
+	result.Timings = &timings
 	result.StatusCode = resp.StatusCode
 	result.BytesReceived = int64(len(body))
 	result.ResponseBody = body
+	result.ResponseHeaders = resp.Header
 
 	// Extract variables if configured
 	if len(req.Extract) > 0 {
-		vu.extractVariables(req.Extract, resp, body)
+		result.ExtractionFailures = vu.extractVariables(req.Extract, resp, body)
 	}
 
 	return result
 }
 
+// requestError classifies the error of a failed request, marking result as
+// timed out when the request's own timeout or the client's expired. Errors
+// caused by ctx being cancelled, because the test is stopping, are not
+// timeouts.
+func (vu *VirtualUser) requestError(ctx context.Context, req *RequestConfig, err error, result *RequestResult) error {
+	if ctx.Err() != nil {
+		return err
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		result.TimedOut = true
+		if req.Timeout > 0 {
+			return fmt.Errorf("timed out after %s: %w", req.Timeout, err)
+		}
+	}
+	return err
+}
+
 // buildRequest builds an HTTP request from the configuration.
 func (vu *VirtualUser) buildRequest(ctx context.Context, req *RequestConfig) (*http.Request, error) {
 	// Resolve variables in URL
@@ -248,7 +488,21 @@ func (vu *VirtualUser) buildRequest(ctx context.Context, req *RequestConfig) (*h
 func (vu *VirtualUser) resolveVariables(input string) string {
 	result := input
 
-	// First, resolve from VU-local data
+	// Setup data comes first so VU-local values cannot shadow it
+	if vu.Scenario != nil {
+		for key, value := range vu.Scenario.SetupData {
+			placeholder := fmt.Sprintf("{{%s}}", key)
+			result = strings.ReplaceAll(result, placeholder, value)
+		}
+	}
+
+	// Then, the iteration's data row
+	for column, value := range vu.dataRow {
+		placeholder := fmt.Sprintf("{{data.%s}}", column)
+		result = strings.ReplaceAll(result, placeholder, value)
+	}
+
+	// Then, resolve from VU-local data
 	vu.dataMu.RLock()
 	for key, value := range vu.data {
 		placeholder := fmt.Sprintf("{{%s}}", key)
@@ -264,29 +518,143 @@ func (vu *VirtualUser) resolveVariables(input string) string {
 		}
 	}
 
-	return result
+	// Finally, built-in variables such as {{iteration}} and {{uuid}}
+	if vu.builtins == nil {
+		vu.builtins = newRequestBuiltins(vu)
+	}
+	return vu.builtins.resolve(result)
 }
 
 // extractVariables extracts values from the response and stores them in VU data.
-func (vu *VirtualUser) extractVariables(extracts []ExtractConfig, resp *http.Response, body []byte) {
-	for _, extract := range extracts {
-		var value string
-
-		switch extract.Source {
-		case "header":
-			value = resp.Header.Get(extract.Path)
-		case "status":
-			value = fmt.Sprintf("%d", resp.StatusCode)
-		case "body":
-			// Simple JSONPath-like extraction (basic implementation)
-			// TODO: Implement proper JSONPath support
-			value = string(body)
+//
+// Extractions that produce no value leave any previous value untouched and
+// are recorded as extraction failures. Returns the number of failures.
+func (vu *VirtualUser) extractVariables(extracts []ExtractConfig, resp *http.Response, body []byte) int {
+	failures := 0
+	for i := range extracts {
+		extract := &extracts[i]
+
+		value, ok := extractValue(extract, resp, body)
+		if !ok {
+			vu.Metrics.RecordExtractionFailure(extract.Name)
+			failures++
+			continue
 		}
 
-		if value != "" {
-			vu.SetData(extract.Name, value)
+		vu.SetData(extract.Name, value)
+	}
+	return failures
+}
+
+// extractValue extracts a single value from the response.
+//
+// For body extraction, Path is evaluated as JSONPath; without a Path the
+// whole body is used. If Regex is set, it is applied to the header or body
+// value and the first capture group (or the whole match) is returned.
+func extractValue(extract *ExtractConfig, resp *http.Response, body []byte) (string, bool) {
+	var value string
+
+	switch extract.Source {
+	case "header":
+		value = resp.Header.Get(extract.Path)
+	case "status":
+		return strconv.Itoa(resp.StatusCode), true
+	case "body":
+		value = string(body)
+		if extract.Path != "" {
+			v, err := jsonpath.Extract(value, extract.Path)
+			if err != nil {
+				return "", false
+			}
+			value = v
+		}
+	default:
+		return "", false
+	}
+
+	if extract.Regex != "" {
+		re, err := compileRegex(extract.Regex)
+		if err != nil {
+			return "", false
+		}
+		match := re.FindStringSubmatch(value)
+		if match == nil {
+			return "", false
+		}
+		if len(match) > 1 {
+			value = match[1]
+		} else {
+			value = match[0]
 		}
 	}
+
+	return value, value != ""
+}
+
+// evaluateAssertions evaluates the request's assertions and records them as checks.
+// Returns the first failed assertion and the error that failed it, if any,
+// or nil if all passed.
+func (vu *VirtualUser) evaluateAssertions(req *RequestConfig, result *RequestResult) (*AssertionConfig, error) {
+	var failed *AssertionConfig
+	var failedErr error
+	for i := range req.Assertions {
+		assertion := &req.Assertions[i]
+		expected := vu.resolveVariables(assertion.Value)
+		passed, err := evaluateAssertion(assertion, expected, result)
+		vu.Metrics.RecordCheck(assertion.CheckName(req.Name), passed)
+		if !passed && failed == nil {
+			failed, failedErr = assertion, err
+		}
+	}
+	return failed, failedErr
+}
+
+// RunOnce executes the scenario's requests once, in order, and stops at the
+// first request that fails: a transport error, an unexpected status, a failed
+// extraction or a failed assertion.
+//
+// It is used for the setup and teardown phases, where each request usually
+// depends on the previous one. Results are recorded in the VU's metrics.
+func (vu *VirtualUser) RunOnce(ctx context.Context) error {
+	vu.iteration.Add(1)
+
+	for i, req := range vu.Scenario.Requests {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if req.Group != nil {
+			return fmt.Errorf("group %s cannot run here: only single requests are supported", req.Group.Name)
+		}
+
+		result := vu.executeRequest(ctx, req)
+
+		vu.recordResult(req, result)
+
+		switch {
+		case result.Error != nil:
+			return fmt.Errorf("request %s failed: %w", req.Name, result.Error)
+		case !req.ExpectsStatus(result.StatusCode):
+			return fmt.Errorf("request %s failed: status %d", req.Name, result.StatusCode)
+		case result.ExtractionFailures > 0:
+			return fmt.Errorf("request %s failed: %d extraction(s) produced no value", req.Name, result.ExtractionFailures)
+		}
+
+		if len(req.Assertions) > 0 {
+			if failed, err := vu.evaluateAssertions(req, result); failed != nil {
+				if err != nil {
+					return fmt.Errorf("request %s failed: %s: %w", req.Name, failed.CheckName(req.Name), err)
+				}
+				return fmt.Errorf("request %s failed: %s", req.Name, failed.CheckName(req.Name))
+			}
+		}
+
+		if req.ThinkTime > 0 && i < len(vu.Scenario.Requests)-1 {
+			vu.applyThinkTime(ctx, req.ThinkTime)
+		}
+	}
+
+	return nil
 }
 
 // applyThinkTime waits for the specified duration or until stopped.
@@ -312,6 +680,11 @@ func (vu *VirtualUser) RequestStop() {
 	}
 }
 
+// Stopping returns a channel that is closed once the VU is asked to stop.
+func (vu *VirtualUser) Stopping() <-chan struct{} {
+	return vu.stopCh
+}
+
 // WaitForStop waits for the VU to stop with a timeout.
 //
 // Returns true if the VU stopped within the timeout, false otherwise.
@@ -351,6 +724,19 @@ func (vu *VirtualUser) GetData(key string) (interface{}, bool) {
 	return val, ok
 }
 
+// Variables returns a copy of the VU's variable scope, with values
+// formatted as strings.
+func (vu *VirtualUser) Variables() map[string]string {
+	vu.dataMu.RLock()
+	defer vu.dataMu.RUnlock()
+
+	vars := make(map[string]string, len(vu.data))
+	for key, value := range vu.data {
+		vars[key] = fmt.Sprintf("%v", value)
+	}
+	return vars
+}
+
 // ClearData removes a value from the VU's variable scope.
 func (vu *VirtualUser) ClearData(key string) {
 	vu.dataMu.Lock()
@@ -370,6 +756,24 @@ type RequestResult struct {
 	BytesReceived int64         `json:"bytesReceived"`
 	Error         error         `json:"error,omitempty"`
 	ResponseBody  []byte        `json:"-"` // Not serialized
+
+	// ExtractionFailures is the number of extractions that produced no value
+	ExtractionFailures int `json:"extractionFailures,omitempty"`
+
+	// TimedOut is set when the request failed because it timed out
+	TimedOut bool `json:"timedOut,omitempty"`
+
+	// Timings is the time spent in each phase of the request, set when the
+	// response was received in full
+	Timings *metrics.Timings `json:"timings,omitempty"`
+
+	// TraceID and SpanID identify the request in the traceparent header
+	// sent with it, if any
+	TraceID string `json:"traceId,omitempty"`
+	SpanID  string `json:"spanId,omitempty"`
+
+	// ResponseHeaders are the response headers (not serialized)
+	ResponseHeaders http.Header `json:"-"`
 }
 
 // Scenario defines what a VU executes during each iteration.
@@ -380,8 +784,29 @@ type Scenario struct {
 	// Variables available to all requests
 	Variables map[string]string `json:"variables,omitempty" yaml:"variables,omitempty"`
 
+	// SetupData holds the variables extracted by the test's setup phase.
+	// It is shared by all VUs, never modified once the scenario runs, and
+	// takes precedence over values a VU extracts itself.
+	SetupData map[string]string `json:"setupData,omitempty" yaml:"setupData,omitempty"`
+
+	// Data parameterizes iterations with rows of a data file
+	Data *DataSource `json:"-" yaml:"-"`
+
 	// Requests to execute in order
 	Requests []*RequestConfig `json:"requests" yaml:"requests"`
+
+	// Flow is how iterations run Requests (default: FlowSequential)
+	Flow Flow `json:"flow,omitempty" yaml:"flow,omitempty"`
+
+	// Seed, if set, makes the picks of a weighted flow reproducible
+	Seed *int64 `json:"seed,omitempty" yaml:"seed,omitempty"`
+
+	// LatencyFromIntendedStart measures the first request of each iteration
+	// from its scheduled start rather than the actual send time
+	LatencyFromIntendedStart bool `json:"latencyFromIntendedStart,omitempty" yaml:"latencyFromIntendedStart,omitempty"`
+
+	// Traceparent sends a W3C traceparent header with each request
+	Traceparent bool `json:"traceparent,omitempty" yaml:"traceparent,omitempty"`
 }
 
 // RequestConfig defines a single HTTP request.
@@ -401,7 +826,8 @@ type RequestConfig struct {
 	// Body (supports variable substitution)
 	Body string `json:"body,omitempty" yaml:"body,omitempty"`
 
-	// Timeout for this specific request (optional)
+	// Timeout for this specific request, covering the whole exchange
+	// including reading the response body (optional)
 	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
 
 	// Think time after this request
@@ -409,6 +835,51 @@ type RequestConfig struct {
 
 	// Variable extraction from response
 	Extract []ExtractConfig `json:"extract,omitempty" yaml:"extract,omitempty"`
+
+	// Assertions evaluated against the response and reported as checks
+	Assertions []AssertionConfig `json:"assertions,omitempty" yaml:"assertions,omitempty"`
+
+	// ExpectedStatuses are the response statuses that count as success
+	// (default: any status below 400)
+	ExpectedStatuses []StatusRange `json:"expectedStatuses,omitempty" yaml:"expectedStatuses,omitempty"`
+
+	// Group, if set, makes this entry a group of requests rather than a
+	// single request; only When, Repeat and Until then apply
+	Group *Group `json:"group,omitempty" yaml:"group,omitempty"`
+
+	// When, if set, runs this request or group only if the condition holds
+	When *ConditionConfig `json:"when,omitempty" yaml:"when,omitempty"`
+
+	// Repeat is how many times in a row this request or group runs
+	// (default: 1); with Until, the maximum number of runs
+	Repeat int `json:"repeat,omitempty" yaml:"repeat,omitempty"`
+
+	// Until, if set, stops the repetition once the condition holds after a run
+	Until *ConditionConfig `json:"until,omitempty" yaml:"until,omitempty"`
+
+	// Weight is the relative share of iterations of a weighted flow that
+	// pick this request or group
+	Weight int `json:"weight,omitempty" yaml:"weight,omitempty"`
+}
+
+// StatusRange is an inclusive range of HTTP status codes.
+type StatusRange struct {
+	Min int `json:"min" yaml:"min"`
+	Max int `json:"max" yaml:"max"`
+}
+
+// ExpectsStatus reports whether a response with the given status code
+// counts as a success for this request.
+func (r *RequestConfig) ExpectsStatus(code int) bool {
+	if len(r.ExpectedStatuses) == 0 {
+		return code < 400
+	}
+	for _, status := range r.ExpectedStatuses {
+		if code >= status.Min && code <= status.Max {
+			return true
+		}
+	}
+	return false
 }
 
 // ExtractConfig defines how to extract variables from a response.
@@ -422,6 +893,7 @@ type ExtractConfig struct {
 	// Path: header name, or JSONPath for body
 	Path string `json:"path,omitempty" yaml:"path,omitempty"`
 
-	// Regex pattern (optional, for body extraction)
+	// Regex pattern (optional, for body or header extraction).
+	// The first capture group is used if present, otherwise the whole match.
 	Regex string `json:"regex,omitempty" yaml:"regex,omitempty"`
 }
@@ -2,9 +2,13 @@ package v2_test
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -281,6 +285,44 @@ func TestVirtualUser_RunIteration_ContextCancelled(t *testing.T) {
 	}
 }
 
+func TestVirtualUser_RunIterationAt_LatencyFromIntendedStart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		fromIntent  bool
+		wantAtLeast time.Duration
+		wantBelow   time.Duration
+	}{
+		{"measured from send time", false, 0, 200 * time.Millisecond},
+		{"measured from intended start", true, 200 * time.Millisecond, time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metricsEngine := metrics.NewEngine()
+			defer metricsEngine.Stop()
+
+			scenario := createTestScenario(server.URL)
+			scenario.LatencyFromIntendedStart = tt.fromIntent
+			vu := createTestVU(scenario, metricsEngine)
+
+			// The iteration was due 200ms ago
+			if err := vu.RunIterationAt(context.Background(), time.Now().Add(-200*time.Millisecond)); err != nil {
+				t.Fatalf("RunIterationAt() error = %v", err)
+			}
+
+			latency := metricsEngine.GetSnapshot().Latency.Max
+			if latency < tt.wantAtLeast || latency >= tt.wantBelow {
+				t.Errorf("latency = %v, want in [%v, %v)", latency, tt.wantAtLeast, tt.wantBelow)
+			}
+		})
+	}
+}
+
 func TestVirtualUser_RunIteration_StoppedVU(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -488,6 +530,356 @@ func TestVirtualUser_ExtractVariables(t *testing.T) {
 	}
 }
 
+func TestVirtualUser_ExtractVariables_JSONPathAndRegex(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/orders/9876")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"data": {"id": 123, "token": "abc.def"}, "html": "<input name='csrf' value='xyz789'>"}`))
+	}))
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scenario := &v2.Scenario{
+		Name: "test-extract-jsonpath",
+		Requests: []*v2.RequestConfig{
+			{
+				Name:   "extract-test",
+				Method: "GET",
+				URL:    server.URL,
+				Extract: []v2.ExtractConfig{
+					{Name: "userId", Source: "body", Path: "$.data.id"},
+					{Name: "token", Source: "body", Path: "$.data.token"},
+					{Name: "csrf", Source: "body", Regex: `name='csrf' value='([^']+)'`},
+					{Name: "tokenPrefix", Source: "body", Path: "$.data.token", Regex: `^[a-z]+`},
+					{Name: "orderId", Source: "header", Path: "Location", Regex: `/orders/(\d+)`},
+					{Name: "missing", Source: "body", Path: "$.data.missing"},
+					{Name: "noMatch", Source: "header", Path: "Location", Regex: `/users/(\d+)`},
+				},
+			},
+		},
+	}
+
+	vu := createTestVU(scenario, metricsEngine)
+
+	if err := vu.RunIteration(context.Background()); err != nil {
+		t.Fatalf("RunIteration() error = %v", err)
+	}
+
+	want := map[string]string{
+		"userId":      "123",
+		"token":       "abc.def",
+		"csrf":        "xyz789",
+		"tokenPrefix": "abc",
+		"orderId":     "9876",
+	}
+	for name, expected := range want {
+		value, ok := vu.GetData(name)
+		if !ok || value != expected {
+			t.Errorf("Extracted %s = %v, %v, want %s, true", name, value, ok, expected)
+		}
+	}
+
+	for _, name := range []string{"missing", "noMatch"} {
+		if _, ok := vu.GetData(name); ok {
+			t.Errorf("%s should not be set after a failed extraction", name)
+		}
+	}
+
+	if got := metricsEngine.GetExtractionFailures(); got != 2 {
+		t.Errorf("GetExtractionFailures() = %d, want 2", got)
+	}
+
+	snapshot := metricsEngine.GetSnapshot()
+	if snapshot.ExtractionFailuresByName["missing"] != 1 || snapshot.ExtractionFailuresByName["noMatch"] != 1 {
+		t.Errorf("ExtractionFailuresByName = %v, want missing=1, noMatch=1", snapshot.ExtractionFailuresByName)
+	}
+}
+
+func TestVirtualUser_RequestChaining(t *testing.T) {
+	var authHeader string
+	var mu sync.Mutex
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"auth": {"token": "secret-token"}}`))
+		case "/profile":
+			mu.Lock()
+			authHeader = r.Header.Get("Authorization")
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scenario := &v2.Scenario{
+		Name: "test-chaining",
+		Requests: []*v2.RequestConfig{
+			{
+				Name:   "login",
+				Method: "POST",
+				URL:    server.URL + "/login",
+				Extract: []v2.ExtractConfig{
+					{Name: "token", Source: "body", Path: "$.auth.token"},
+				},
+			},
+			{
+				Name:    "profile",
+				Method:  "GET",
+				URL:     server.URL + "/profile",
+				Headers: map[string]string{"Authorization": "Bearer {{token}}"},
+			},
+		},
+	}
+
+	vu := createTestVU(scenario, metricsEngine)
+
+	if err := vu.RunIteration(context.Background()); err != nil {
+		t.Fatalf("RunIteration() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if authHeader != "Bearer secret-token" {
+		t.Errorf("Authorization header = %q, want %q", authHeader, "Bearer secret-token")
+	}
+}
+
+func TestVirtualUser_RunOnce(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/login":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"token": "abc"}`))
+		case "/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	t.Run("returns extracted variables", func(t *testing.T) {
+		scenario := &v2.Scenario{
+			Name: "setup",
+			Requests: []*v2.RequestConfig{
+				{
+					Name:    "login",
+					Method:  "POST",
+					URL:     server.URL + "/login",
+					Extract: []v2.ExtractConfig{{Name: "token", Source: "body", Path: "$.token"}},
+				},
+			},
+		}
+
+		vu := createTestVU(scenario, metricsEngine)
+		if err := vu.RunOnce(context.Background()); err != nil {
+			t.Fatalf("RunOnce() error = %v", err)
+		}
+		if got := vu.Variables()["token"]; got != "abc" {
+			t.Errorf("Variables()[token] = %q, want %q", got, "abc")
+		}
+	})
+
+	t.Run("stops at first failed request", func(t *testing.T) {
+		mu.Lock()
+		paths = nil
+		mu.Unlock()
+
+		scenario := &v2.Scenario{
+			Name: "setup",
+			Requests: []*v2.RequestConfig{
+				{Name: "broken", Method: "GET", URL: server.URL + "/broken"},
+				{Name: "after", Method: "GET", URL: server.URL + "/after"},
+			},
+		}
+
+		vu := createTestVU(scenario, metricsEngine)
+		err := vu.RunOnce(context.Background())
+		if err == nil {
+			t.Fatal("RunOnce() expected error for 500 response")
+		}
+		if !strings.Contains(err.Error(), "request broken failed") {
+			t.Errorf("RunOnce() error = %q, want it to name the failed request", err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if len(paths) != 1 {
+			t.Errorf("server received %v, want only /broken", paths)
+		}
+	})
+
+	t.Run("fails when assertion values cannot be compared", func(t *testing.T) {
+		scenario := &v2.Scenario{
+			Name: "setup",
+			Requests: []*v2.RequestConfig{
+				{
+					Name:       "login",
+					Method:     "POST",
+					URL:        server.URL + "/login",
+					Assertions: []v2.AssertionConfig{{Type: "body", Condition: "gt", Value: "abb", Path: "$.token"}},
+				},
+			},
+		}
+
+		vu := createTestVU(scenario, metricsEngine)
+		err := vu.RunOnce(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "values must be numbers or durations") {
+			t.Errorf("RunOnce() error = %v, want the values to be reported as not comparable", err)
+		}
+	})
+
+	t.Run("fails when extraction produces no value", func(t *testing.T) {
+		scenario := &v2.Scenario{
+			Name: "setup",
+			Requests: []*v2.RequestConfig{
+				{
+					Name:    "login",
+					Method:  "POST",
+					URL:     server.URL + "/login",
+					Extract: []v2.ExtractConfig{{Name: "missing", Source: "body", Path: "$.nope"}},
+				},
+			},
+		}
+
+		vu := createTestVU(scenario, metricsEngine)
+		if err := vu.RunOnce(context.Background()); err == nil {
+			t.Error("RunOnce() expected error for failed extraction")
+		}
+	})
+}
+
+func TestVirtualUser_SetupData(t *testing.T) {
+	var authHeader string
+	var mu sync.Mutex
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		authHeader = r.Header.Get("Authorization")
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"token": "vu-token"}`))
+	}))
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scenario := &v2.Scenario{
+		Name:      "test-setup-data",
+		SetupData: map[string]string{"token": "setup-token"},
+		Requests: []*v2.RequestConfig{
+			{
+				Name:    "request",
+				Method:  "GET",
+				URL:     server.URL,
+				Headers: map[string]string{"Authorization": "Bearer {{token}}"},
+				Extract: []v2.ExtractConfig{{Name: "token", Source: "body", Path: "$.token"}},
+			},
+		},
+	}
+
+	vu := createTestVU(scenario, metricsEngine)
+
+	// The second iteration must still see the setup value, not the one
+	// the VU extracted in the first.
+	for i := 0; i < 2; i++ {
+		if err := vu.RunIteration(context.Background()); err != nil {
+			t.Fatalf("RunIteration() error = %v", err)
+		}
+
+		mu.Lock()
+		got := authHeader
+		mu.Unlock()
+		if got != "Bearer setup-token" {
+			t.Errorf("iteration %d: Authorization header = %q, want %q", i, got, "Bearer setup-token")
+		}
+	}
+}
+
+func TestVirtualUser_Assertions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"user": {"id": 42, "name": "alice"}}`))
+	}))
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scenario := &v2.Scenario{
+		Name: "test-assertions",
+		Variables: map[string]string{
+			"expectedName": "alice",
+			"namePattern":  "^ali",
+		},
+		Requests: []*v2.RequestConfig{
+			{
+				Name:   "create",
+				Method: "POST",
+				URL:    server.URL,
+				Assertions: []v2.AssertionConfig{
+					{Type: "status", Condition: "eq", Value: "201"},
+					{Type: "status", Condition: "lt", Value: "300", Message: "status is 2xx"},
+					{Type: "body", Condition: "eq", Value: "{{expectedName}}", Path: "$.user.name"},
+					{Type: "body", Condition: "gte", Value: "40", Path: "$.user.id"},
+					{Type: "body", Condition: "gt", Value: "9", Path: "$.user.id", Message: "compared as numbers"},
+					{Type: "body", Condition: "matches", Value: "{{namePattern}}", Path: "$.user.name"},
+					{Type: "body", Condition: "contains", Value: "alice"},
+					{Type: "header", Condition: "matches", Value: "^application/json", Path: "Content-Type"},
+					{Type: "duration", Condition: "lt", Value: "5s"},
+					{Type: "status", Condition: "ne", Value: "201", Message: "always fails"},
+					{Type: "body", Condition: "gt", Value: "bob", Path: "$.user.name", Message: "not comparable"},
+				},
+			},
+		},
+	}
+
+	vu := createTestVU(scenario, metricsEngine)
+
+	for i := 0; i < 2; i++ {
+		if err := vu.RunIteration(context.Background()); err != nil {
+			t.Fatalf("RunIteration() error = %v", err)
+		}
+	}
+
+	checks := metricsEngine.GetChecks()
+	if len(checks) != 11 {
+		t.Fatalf("GetChecks() length = %d, want 11: %+v", len(checks), checks)
+	}
+
+	for _, check := range checks {
+		if check.Name == "create: always fails" || check.Name == "create: not comparable" {
+			if check.Passes != 0 || check.Fails != 2 {
+				t.Errorf("%s = %d passes, %d fails, want 0, 2", check.Name, check.Passes, check.Fails)
+			}
+			continue
+		}
+		if check.Passes != 2 || check.Fails != 0 {
+			t.Errorf("%s = %d passes, %d fails, want 2, 0", check.Name, check.Passes, check.Fails)
+		}
+	}
+}
+
 func TestVirtualUser_HTTPRequestWithHeaders(t *testing.T) {
 	var receivedHeaders http.Header
 	var mu sync.Mutex
@@ -544,6 +936,67 @@ func TestVirtualUser_HTTPRequestWithHeaders(t *testing.T) {
 	}
 }
 
+func TestVirtualUser_Traceparent(t *testing.T) {
+	var received []string
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = append(received, r.Header.Get("Traceparent"))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scenario := &v2.Scenario{
+		Name:        "test-traceparent",
+		Traceparent: true,
+		Requests: []*v2.RequestConfig{
+			{Name: "generated", Method: "GET", URL: server.URL},
+			{
+				Name:    "explicit",
+				Method:  "GET",
+				URL:     server.URL,
+				Headers: map[string]string{"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"},
+			},
+		},
+	}
+
+	run := func() []string {
+		mu.Lock()
+		received = nil
+		mu.Unlock()
+
+		vu := createTestVU(scenario, metricsEngine)
+		if err := vu.RunIteration(context.Background()); err != nil {
+			t.Fatalf("RunIteration() error = %v", err)
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if len(received) != 2 {
+			t.Fatalf("received %d requests, want 2", len(received))
+		}
+		return received
+	}
+
+	headers := run()
+	if !regexp.MustCompile(`^00-[0-9a-f]{32}-[0-9a-f]{16}-01$`).MatchString(headers[0]) {
+		t.Errorf("generated traceparent = %q, want a sampled W3C traceparent", headers[0])
+	}
+	if headers[1] != "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01" {
+		t.Errorf("explicit traceparent = %q, want it unchanged", headers[1])
+	}
+
+	// Without the setting no header is added
+	scenario.Traceparent = false
+	if headers := run(); headers[0] != "" {
+		t.Errorf("traceparent without setting = %q, want none", headers[0])
+	}
+}
+
 func TestVirtualUser_HTTPRequestWithBody(t *testing.T) {
 	var receivedBody string
 	var mu sync.Mutex
@@ -619,6 +1072,644 @@ func TestVirtualUser_HTTPRequestFailure(t *testing.T) {
 	}
 }
 
+func TestVirtualUser_RequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(300 * time.Millisecond):
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scenario := &v2.Scenario{
+		Name: "timeouts",
+		Requests: []*v2.RequestConfig{
+			{Name: "short", Method: "GET", URL: server.URL, Timeout: 50 * time.Millisecond},
+			{Name: "long", Method: "GET", URL: server.URL, Timeout: 2 * time.Second},
+		},
+	}
+	vu := createTestVU(scenario, metricsEngine)
+
+	start := time.Now()
+	if err := vu.RunIteration(context.Background()); err != nil {
+		t.Fatalf("RunIteration() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("iteration took %v, want the short request to give up after 50ms", elapsed)
+	}
+
+	snapshot := metricsEngine.GetSnapshot()
+	if snapshot.TimedOutRequests != 1 || snapshot.TimedOutRequestsByName["short"] != 1 {
+		t.Errorf("TimedOutRequests = %d (%v), want 1 for short", snapshot.TimedOutRequests, snapshot.TimedOutRequestsByName)
+	}
+	if snapshot.FailedRequests != 1 || snapshot.SuccessRequests != 1 {
+		t.Errorf("FailedRequests = %d, SuccessRequests = %d, want 1 and 1", snapshot.FailedRequests, snapshot.SuccessRequests)
+	}
+
+	// The error names the timeout
+	err := createTestVU(scenario, metricsEngine).RunOnce(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "timed out after 50ms") {
+		t.Errorf("RunOnce() error = %v, want a timeout", err)
+	}
+}
+
+func TestVirtualUser_RequestTimeout_Cancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scenario := &v2.Scenario{
+		Name: "cancelled",
+		Requests: []*v2.RequestConfig{
+			{Name: "request", Method: "GET", URL: server.URL, Timeout: 500 * time.Millisecond},
+		},
+	}
+	vu := createTestVU(scenario, metricsEngine)
+
+	// A request interrupted by the test stopping is not a timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_ = vu.RunIteration(ctx)
+
+	if got := metricsEngine.GetSnapshot().TimedOutRequests; got != 0 {
+		t.Errorf("TimedOutRequests = %d, want 0", got)
+	}
+}
+
+func TestVirtualUser_Timings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		time.Sleep(30 * time.Millisecond)
+		w.Write([]byte("done"))
+	}))
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scenario := createTestScenario(server.URL)
+	vu := createTestVU(scenario, metricsEngine)
+	for i := 0; i < 2; i++ {
+		if err := vu.RunIteration(context.Background()); err != nil {
+			t.Fatalf("RunIteration() error = %v", err)
+		}
+	}
+
+	timings := metricsEngine.GetSnapshot().Timings
+	if timings == nil {
+		t.Fatal("Snapshot.Timings = nil, want phase statistics")
+	}
+	if timings.Waiting.Count != 2 {
+		t.Errorf("Waiting.Count = %d, want 2", timings.Waiting.Count)
+	}
+	if timings.Waiting.Min < 45*time.Millisecond {
+		t.Errorf("Waiting.Min = %v, want at least the 50ms before the response", timings.Waiting.Min)
+	}
+	if timings.Receiving.Min < 25*time.Millisecond {
+		t.Errorf("Receiving.Min = %v, want at least the 30ms spent sending the body", timings.Receiving.Min)
+	}
+
+	// The connection is reused for the second request
+	if timings.Connect.Min != 0 {
+		t.Errorf("Connect.Min = %v, want 0 for the reused connection", timings.Connect.Min)
+	}
+}
+
+func TestVirtualUser_StatusCodesAndErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/slow":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		case "/truncated":
+			// Promise a longer body than is sent, then drop the connection
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Write([]byte("HTTP/1.1 200 OK\r\nContent-Length: 100\r\n\r\nshort"))
+			conn.Close()
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closedURL := closed.URL
+	closed.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scenario := &v2.Scenario{
+		Name: "errors",
+		Requests: []*v2.RequestConfig{
+			{Name: "ok", Method: "GET", URL: server.URL},
+			{Name: "missing", Method: "GET", URL: server.URL + "/missing"},
+			{Name: "slow", Method: "GET", URL: server.URL + "/slow", Timeout: 50 * time.Millisecond},
+			{Name: "truncated", Method: "GET", URL: server.URL + "/truncated"},
+			{Name: "refused", Method: "GET", URL: closedURL},
+		},
+	}
+	vu := createTestVU(scenario, metricsEngine)
+	if err := vu.RunIteration(context.Background()); err != nil {
+		t.Fatalf("RunIteration() error = %v", err)
+	}
+
+	snapshot := metricsEngine.GetSnapshot()
+	if snapshot.StatusCodes[200] != 2 || snapshot.StatusCodes[404] != 1 || len(snapshot.StatusCodes) != 2 {
+		t.Errorf("StatusCodes = %v, want 200:2 404:1", snapshot.StatusCodes)
+	}
+
+	wantCategories := map[metrics.ErrorCategory]int64{
+		metrics.ErrorTimeout:           1,
+		metrics.ErrorBodyRead:          1,
+		metrics.ErrorConnectionRefused: 1,
+	}
+	for category, want := range wantCategories {
+		if got := snapshot.ErrorCategories[category]; got != want {
+			t.Errorf("ErrorCategories[%s] = %d, want %d (all: %v)", category, got, want, snapshot.ErrorCategories)
+		}
+	}
+	if len(snapshot.ErrorCategories) != len(wantCategories) {
+		t.Errorf("ErrorCategories = %v, want only %v", snapshot.ErrorCategories, wantCategories)
+	}
+
+	// Messages leave out the request method and URL
+	for _, e := range snapshot.TopErrors {
+		if strings.Contains(e.Message, "Get \"") {
+			t.Errorf("TopErrors message %q should not include the request URL", e.Message)
+		}
+		if e.Category == metrics.ErrorTimeout && !strings.Contains(e.Message, "timed out after 50ms") {
+			t.Errorf("timeout message = %q, want it to name the timeout", e.Message)
+		}
+	}
+}
+
+func TestRequestConfig_ExpectsStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []v2.StatusRange
+		code     int
+		want     bool
+	}{
+		{name: "default 200", code: 200, want: true},
+		{name: "default 302", code: 302, want: true},
+		{name: "default 404", code: 404, want: false},
+		{name: "listed 404", statuses: []v2.StatusRange{{Min: 200, Max: 299}, {Min: 404, Max: 404}}, code: 404, want: true},
+		{name: "in range", statuses: []v2.StatusRange{{Min: 200, Max: 299}}, code: 204, want: true},
+		{name: "redirect not listed", statuses: []v2.StatusRange{{Min: 200, Max: 299}}, code: 302, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &v2.RequestConfig{ExpectedStatuses: tt.statuses}
+			if got := req.ExpectsStatus(tt.code); got != tt.want {
+				t.Errorf("ExpectsStatus(%d) = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVirtualUser_ExpectedStatuses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/moved":
+			w.WriteHeader(http.StatusNotModified)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	only2xx := []v2.StatusRange{{Min: 200, Max: 299}}
+	scenario := &v2.Scenario{
+		Name: "statuses",
+		Requests: []*v2.RequestConfig{
+			{Name: "missing", Method: "GET", URL: server.URL + "/missing", ExpectedStatuses: []v2.StatusRange{{Min: 404, Max: 404}}},
+			{Name: "moved", Method: "GET", URL: server.URL + "/moved", ExpectedStatuses: only2xx},
+		},
+	}
+	vu := createTestVU(scenario, metricsEngine)
+	if err := vu.RunIteration(context.Background()); err != nil {
+		t.Fatalf("RunIteration() error = %v", err)
+	}
+
+	snapshot := metricsEngine.GetSnapshot()
+	if snapshot.SuccessRequests != 1 || snapshot.FailedRequests != 1 {
+		t.Errorf("SuccessRequests = %d, FailedRequests = %d, want 1 and 1", snapshot.SuccessRequests, snapshot.FailedRequests)
+	}
+	if got := metricsEngine.GetRequestSnapshot("missing").FailedRequests; got != 0 {
+		t.Errorf("missing FailedRequests = %d, want 0 for an expected 404", got)
+	}
+
+	// RunOnce stops at the unexpected status
+	err := createTestVU(scenario, metricsEngine).RunOnce(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "request moved failed: status 304") {
+		t.Errorf("RunOnce() error = %v, want the unexpected 304 to fail", err)
+	}
+}
+
+func TestVirtualUser_Groups(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scenario := &v2.Scenario{
+		Name: "groups",
+		Requests: []*v2.RequestConfig{
+			{Name: "home", Method: "GET", URL: server.URL},
+			{Repeat: 2, Group: &v2.Group{
+				Name: "checkout",
+				Requests: []*v2.RequestConfig{
+					{Name: "cart", Method: "GET", URL: server.URL, ThinkTime: 50 * time.Millisecond},
+					{Group: &v2.Group{
+						Name: "checkout::payment",
+						Requests: []*v2.RequestConfig{
+							{Name: "pay", Method: "GET", URL: server.URL},
+						},
+					}},
+				},
+			}},
+		},
+	}
+	vu := createTestVU(scenario, metricsEngine)
+	if err := vu.RunIteration(context.Background()); err != nil {
+		t.Fatalf("RunIteration() error = %v", err)
+	}
+
+	if got := requests.Load(); got != 5 {
+		t.Errorf("server got %d requests, want 5 (home, then cart and pay twice)", got)
+	}
+
+	groups := metricsEngine.GetGroups()
+	if len(groups) != 2 || groups[0].Name != "checkout" || groups[1].Name != "checkout::payment" {
+		t.Fatalf("GetGroups() = %v, want checkout and checkout::payment", groups)
+	}
+	if groups[0].Duration.Count != 2 || groups[1].Duration.Count != 2 {
+		t.Errorf("group runs = %d and %d, want 2 each", groups[0].Duration.Count, groups[1].Duration.Count)
+	}
+	// Think time inside the group is part of its duration
+	if groups[0].Duration.Min < 45*time.Millisecond {
+		t.Errorf("checkout Min = %v, want at least the 50ms think time", groups[0].Duration.Min)
+	}
+
+	want := map[string]string{"cart": "checkout", "pay": "checkout::payment"}
+	got := scenario.RequestGroups()
+	if len(got) != len(want) || got["cart"] != want["cart"] || got["pay"] != want["pay"] {
+		t.Errorf("RequestGroups() = %v, want %v", got, want)
+	}
+}
+
+func TestVirtualUser_GroupStopped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scenario := &v2.Scenario{
+		Name: "groups",
+		Requests: []*v2.RequestConfig{
+			{Group: &v2.Group{
+				Name: "slow",
+				Requests: []*v2.RequestConfig{
+					{Name: "first", Method: "GET", URL: server.URL, ThinkTime: 5 * time.Second},
+					{Name: "second", Method: "GET", URL: server.URL},
+				},
+			}},
+		},
+	}
+	vu := createTestVU(scenario, metricsEngine)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		vu.RequestStop()
+	}()
+
+	start := time.Now()
+	if err := vu.RunIteration(context.Background()); !errors.Is(err, v2.ErrIterationInterrupted) {
+		t.Fatalf("RunIteration() error = %v, want ErrIterationInterrupted", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("iteration took %v, want the stop to interrupt the think time", elapsed)
+	}
+
+	// The interrupted run of the group is not recorded
+	if groups := metricsEngine.GetGroups(); groups != nil {
+		t.Errorf("GetGroups() = %v, want no completed group runs", groups)
+	}
+}
+
+func TestVirtualUser_When(t *testing.T) {
+	var mu sync.Mutex
+	hits := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.URL.Path]++
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"count": 0}`))
+	}))
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scenario := &v2.Scenario{
+		Name: "when",
+		Requests: []*v2.RequestConfig{
+			{
+				Name:    "cart",
+				Method:  "GET",
+				URL:     server.URL + "/cart",
+				Extract: []v2.ExtractConfig{{Name: "cartCount", Source: "body", Path: "$.count"}},
+			},
+			{
+				Name:   "add",
+				Method: "POST",
+				URL:    server.URL + "/add",
+				When:   &v2.ConditionConfig{Variable: "cartCount", Condition: "eq", Value: "0"},
+			},
+			{
+				Name:      "checkout",
+				Method:    "GET",
+				URL:       server.URL + "/checkout",
+				ThinkTime: 300 * time.Millisecond,
+				When:      &v2.ConditionConfig{Source: "status", Condition: "eq", Value: "200"},
+			},
+			{
+				Name:   "browse",
+				Method: "GET",
+				URL:    server.URL + "/browse",
+				When:   &v2.ConditionConfig{Variable: "cartCount", Condition: "gt", Value: "0"},
+			},
+			{
+				Name:   "unset",
+				Method: "GET",
+				URL:    server.URL + "/unset",
+				When:   &v2.ConditionConfig{Variable: "missing", Condition: "ne", Value: ""},
+			},
+		},
+	}
+	vu := createTestVU(scenario, metricsEngine)
+
+	start := time.Now()
+	if err := vu.RunIteration(context.Background()); err != nil {
+		t.Fatalf("RunIteration() error = %v", err)
+	}
+	// Think time is not applied when only skipped steps follow
+	if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+		t.Errorf("iteration took %v, want no think time after the last executed request", elapsed)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := map[string]int{"/cart": 1, "/add": 1, "/checkout": 1}
+	if len(hits) != len(want) {
+		t.Errorf("server hits = %v, want %v", hits, want)
+	}
+	for path, n := range want {
+		if hits[path] != n {
+			t.Errorf("hits[%s] = %d, want %d", path, hits[path], n)
+		}
+	}
+
+	// Skipped steps record no metrics
+	stats := metricsEngine.GetRequestStats()
+	for _, name := range []string{"browse", "unset"} {
+		if _, exists := stats[name]; exists {
+			t.Errorf("skipped request %s has metrics", name)
+		}
+	}
+}
+
+func TestVirtualUser_RepeatUntil(t *testing.T) {
+	var polls atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Path == "/job" && polls.Add(1) >= 3 {
+			w.Write([]byte(`{"state": "done"}`))
+			return
+		}
+		w.Write([]byte(`{"state": "pending"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		step         *v2.RequestConfig
+		wantRequests int64
+	}{
+		{
+			name:         "repeat",
+			step:         &v2.RequestConfig{Name: "poll", Method: "GET", URL: server.URL + "/other", Repeat: 3},
+			wantRequests: 3,
+		},
+		{
+			name: "until met",
+			step: &v2.RequestConfig{
+				Name:      "poll",
+				Method:    "GET",
+				URL:       server.URL + "/job",
+				ThinkTime: 20 * time.Millisecond,
+				Extract:   []v2.ExtractConfig{{Name: "state", Source: "body", Path: "$.state"}},
+				Repeat:    10,
+				Until:     &v2.ConditionConfig{Variable: "state", Condition: "eq", Value: "done"},
+			},
+			wantRequests: 3,
+		},
+		{
+			name: "until never met",
+			step: &v2.RequestConfig{
+				Name:   "poll",
+				Method: "GET",
+				URL:    server.URL + "/job",
+				Repeat: 2,
+				Until:  &v2.ConditionConfig{Source: "status", Condition: "eq", Value: "202"},
+			},
+			wantRequests: 2,
+		},
+		{
+			name: "group until met",
+			step: &v2.RequestConfig{
+				Group: &v2.Group{
+					Name: "wait",
+					Requests: []*v2.RequestConfig{
+						{
+							Name:    "poll",
+							Method:  "GET",
+							URL:     server.URL + "/job",
+							Extract: []v2.ExtractConfig{{Name: "state", Source: "body", Path: "$.state"}},
+						},
+					},
+				},
+				Repeat: 10,
+				Until:  &v2.ConditionConfig{Variable: "state", Condition: "eq", Value: "done"},
+			},
+			wantRequests: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls.Store(0)
+
+			metricsEngine := metrics.NewEngine()
+			defer metricsEngine.Stop()
+
+			scenario := &v2.Scenario{Name: "until", Requests: []*v2.RequestConfig{tt.step}}
+			vu := createTestVU(scenario, metricsEngine)
+			if err := vu.RunIteration(context.Background()); err != nil {
+				t.Fatalf("RunIteration() error = %v", err)
+			}
+
+			if got := metricsEngine.GetSnapshot().TotalRequests; got != tt.wantRequests {
+				t.Errorf("TotalRequests = %d, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestVirtualUser_UntilStopped(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	metricsEngine := metrics.NewEngine()
+	defer metricsEngine.Stop()
+
+	scenario := &v2.Scenario{
+		Name: "until",
+		Requests: []*v2.RequestConfig{
+			{
+				Name:      "poll",
+				Method:    "GET",
+				URL:       server.URL,
+				ThinkTime: 5 * time.Second,
+				Repeat:    1000,
+				Until:     &v2.ConditionConfig{Source: "status", Condition: "eq", Value: "204"},
+			},
+		},
+	}
+	vu := createTestVU(scenario, metricsEngine)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		vu.RequestStop()
+	}()
+
+	start := time.Now()
+	if err := vu.RunIteration(context.Background()); !errors.Is(err, v2.ErrIterationInterrupted) {
+		t.Fatalf("RunIteration() error = %v, want ErrIterationInterrupted", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("iteration took %v, want the stop to interrupt the loop", elapsed)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("server got %d requests, want 1 before the stop", got)
+	}
+}
+
+func TestVirtualUser_WeightedFlow(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	seed := int64(42)
+	run := func() ([]string, map[string]int64) {
+		mu.Lock()
+		paths = nil
+		mu.Unlock()
+
+		metricsEngine := metrics.NewEngine()
+		defer metricsEngine.Stop()
+
+		scenario := &v2.Scenario{
+			Name: "mix",
+			Flow: v2.FlowWeighted,
+			Seed: &seed,
+			Requests: []*v2.RequestConfig{
+				{Name: "search", Method: "GET", URL: server.URL + "/search", Weight: 3},
+				{Weight: 1, Group: &v2.Group{
+					Name: "purchase",
+					Requests: []*v2.RequestConfig{
+						{Name: "buy", Method: "POST", URL: server.URL + "/buy"},
+					},
+				}},
+			},
+		}
+		vu := createTestVU(scenario, metricsEngine)
+		for i := 0; i < 400; i++ {
+			if err := vu.RunIteration(context.Background()); err != nil {
+				t.Fatalf("RunIteration() error = %v", err)
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), paths...), metricsEngine.GetSelections()
+	}
+
+	first, selections := run()
+
+	// Each iteration runs exactly one of the steps
+	if len(first) != 400 {
+		t.Fatalf("server got %d requests, want 400", len(first))
+	}
+	if selections["search"]+selections["purchase"] != 400 {
+		t.Errorf("GetSelections() = %v, want 400 picks", selections)
+	}
+	if n := selections["search"]; n < 240 || n > 360 {
+		t.Errorf("search picked %d times, want about 300 (weight 3 of 4)", n)
+	}
+
+	// The same seed gives the same picks
+	second, _ := run()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("iteration %d ran %s, then %s with the same seed", i, first[i], second[i])
+		}
+	}
+}
+
 func TestVirtualUser_ConcurrentAccess(t *testing.T) {
 	metricsEngine := metrics.NewEngine()
 	defer metricsEngine.Stop()